@@ -0,0 +1,977 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v3.15.6
+// source: api/perfetto/perfetto.proto
+
+package perfetto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TracePacket_SequenceFlags int32
+
+const (
+	TracePacket_SEQ_UNSPECIFIED               TracePacket_SequenceFlags = 0
+	TracePacket_SEQ_INCREMENTAL_STATE_CLEARED TracePacket_SequenceFlags = 1
+	TracePacket_SEQ_NEEDS_INCREMENTAL_STATE   TracePacket_SequenceFlags = 2
+)
+
+// Enum value maps for TracePacket_SequenceFlags.
+var (
+	TracePacket_SequenceFlags_name = map[int32]string{
+		0: "SEQ_UNSPECIFIED",
+		1: "SEQ_INCREMENTAL_STATE_CLEARED",
+		2: "SEQ_NEEDS_INCREMENTAL_STATE",
+	}
+	TracePacket_SequenceFlags_value = map[string]int32{
+		"SEQ_UNSPECIFIED":               0,
+		"SEQ_INCREMENTAL_STATE_CLEARED": 1,
+		"SEQ_NEEDS_INCREMENTAL_STATE":   2,
+	}
+)
+
+func (x TracePacket_SequenceFlags) Enum() *TracePacket_SequenceFlags {
+	p := new(TracePacket_SequenceFlags)
+	*p = x
+	return p
+}
+
+func (x TracePacket_SequenceFlags) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TracePacket_SequenceFlags) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_perfetto_perfetto_proto_enumTypes[0].Descriptor()
+}
+
+func (TracePacket_SequenceFlags) Type() protoreflect.EnumType {
+	return &file_api_perfetto_perfetto_proto_enumTypes[0]
+}
+
+func (x TracePacket_SequenceFlags) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *TracePacket_SequenceFlags) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = TracePacket_SequenceFlags(num)
+	return nil
+}
+
+// Deprecated: Use TracePacket_SequenceFlags.Descriptor instead.
+func (TracePacket_SequenceFlags) EnumDescriptor() ([]byte, []int) {
+	return file_api_perfetto_perfetto_proto_rawDescGZIP(), []int{1, 0}
+}
+
+type CounterDescriptor_Unit int32
+
+const (
+	CounterDescriptor_UNIT_UNSPECIFIED CounterDescriptor_Unit = 0
+	CounterDescriptor_UNIT_TIME_NS     CounterDescriptor_Unit = 1
+	CounterDescriptor_UNIT_COUNT       CounterDescriptor_Unit = 2
+	CounterDescriptor_UNIT_SIZE_BYTES  CounterDescriptor_Unit = 3
+)
+
+// Enum value maps for CounterDescriptor_Unit.
+var (
+	CounterDescriptor_Unit_name = map[int32]string{
+		0: "UNIT_UNSPECIFIED",
+		1: "UNIT_TIME_NS",
+		2: "UNIT_COUNT",
+		3: "UNIT_SIZE_BYTES",
+	}
+	CounterDescriptor_Unit_value = map[string]int32{
+		"UNIT_UNSPECIFIED": 0,
+		"UNIT_TIME_NS":     1,
+		"UNIT_COUNT":       2,
+		"UNIT_SIZE_BYTES":  3,
+	}
+)
+
+func (x CounterDescriptor_Unit) Enum() *CounterDescriptor_Unit {
+	p := new(CounterDescriptor_Unit)
+	*p = x
+	return p
+}
+
+func (x CounterDescriptor_Unit) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CounterDescriptor_Unit) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_perfetto_perfetto_proto_enumTypes[1].Descriptor()
+}
+
+func (CounterDescriptor_Unit) Type() protoreflect.EnumType {
+	return &file_api_perfetto_perfetto_proto_enumTypes[1]
+}
+
+func (x CounterDescriptor_Unit) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *CounterDescriptor_Unit) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = CounterDescriptor_Unit(num)
+	return nil
+}
+
+// Deprecated: Use CounterDescriptor_Unit.Descriptor instead.
+func (CounterDescriptor_Unit) EnumDescriptor() ([]byte, []int) {
+	return file_api_perfetto_perfetto_proto_rawDescGZIP(), []int{3, 0}
+}
+
+type TrackEvent_Type int32
+
+const (
+	TrackEvent_TYPE_UNSPECIFIED TrackEvent_Type = 0
+	TrackEvent_TYPE_SLICE_BEGIN TrackEvent_Type = 1
+	TrackEvent_TYPE_SLICE_END   TrackEvent_Type = 2
+	TrackEvent_TYPE_INSTANT     TrackEvent_Type = 3
+	TrackEvent_TYPE_COUNTER     TrackEvent_Type = 4
+)
+
+// Enum value maps for TrackEvent_Type.
+var (
+	TrackEvent_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_SLICE_BEGIN",
+		2: "TYPE_SLICE_END",
+		3: "TYPE_INSTANT",
+		4: "TYPE_COUNTER",
+	}
+	TrackEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_SLICE_BEGIN": 1,
+		"TYPE_SLICE_END":   2,
+		"TYPE_INSTANT":     3,
+		"TYPE_COUNTER":     4,
+	}
+)
+
+func (x TrackEvent_Type) Enum() *TrackEvent_Type {
+	p := new(TrackEvent_Type)
+	*p = x
+	return p
+}
+
+func (x TrackEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TrackEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_perfetto_perfetto_proto_enumTypes[2].Descriptor()
+}
+
+func (TrackEvent_Type) Type() protoreflect.EnumType {
+	return &file_api_perfetto_perfetto_proto_enumTypes[2]
+}
+
+func (x TrackEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *TrackEvent_Type) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = TrackEvent_Type(num)
+	return nil
+}
+
+// Deprecated: Use TrackEvent_Type.Descriptor instead.
+func (TrackEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_api_perfetto_perfetto_proto_rawDescGZIP(), []int{4, 0}
+}
+
+type Trace struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Packet []*TracePacket `protobuf:"bytes,1,rep,name=packet" json:"packet,omitempty"`
+}
+
+func (x *Trace) Reset() {
+	*x = Trace{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_perfetto_perfetto_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Trace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trace) ProtoMessage() {}
+
+func (x *Trace) ProtoReflect() protoreflect.Message {
+	mi := &file_api_perfetto_perfetto_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trace.ProtoReflect.Descriptor instead.
+func (*Trace) Descriptor() ([]byte, []int) {
+	return file_api_perfetto_perfetto_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Trace) GetPacket() []*TracePacket {
+	if x != nil {
+		return x.Packet
+	}
+	return nil
+}
+
+type TracePacket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timestamp *uint64 `protobuf:"varint,8,opt,name=timestamp" json:"timestamp,omitempty"`
+	// Types that are assignable to Data:
+	//
+	//	*TracePacket_TrackEvent
+	//	*TracePacket_TrackDescriptor
+	Data isTracePacket_Data `protobuf_oneof:"data"`
+	// Types that are assignable to OptionalTrustedPacketSequenceId:
+	//
+	//	*TracePacket_TrustedPacketSequenceId
+	OptionalTrustedPacketSequenceId isTracePacket_OptionalTrustedPacketSequenceId `protobuf_oneof:"optional_trusted_packet_sequence_id"`
+	SequenceFlags                   *uint32                                       `protobuf:"varint,13,opt,name=sequence_flags,json=sequenceFlags" json:"sequence_flags,omitempty"`
+}
+
+func (x *TracePacket) Reset() {
+	*x = TracePacket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_perfetto_perfetto_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TracePacket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TracePacket) ProtoMessage() {}
+
+func (x *TracePacket) ProtoReflect() protoreflect.Message {
+	mi := &file_api_perfetto_perfetto_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TracePacket.ProtoReflect.Descriptor instead.
+func (*TracePacket) Descriptor() ([]byte, []int) {
+	return file_api_perfetto_perfetto_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TracePacket) GetTimestamp() uint64 {
+	if x != nil && x.Timestamp != nil {
+		return *x.Timestamp
+	}
+	return 0
+}
+
+func (m *TracePacket) GetData() isTracePacket_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (x *TracePacket) GetTrackEvent() *TrackEvent {
+	if x, ok := x.GetData().(*TracePacket_TrackEvent); ok {
+		return x.TrackEvent
+	}
+	return nil
+}
+
+func (x *TracePacket) GetTrackDescriptor() *TrackDescriptor {
+	if x, ok := x.GetData().(*TracePacket_TrackDescriptor); ok {
+		return x.TrackDescriptor
+	}
+	return nil
+}
+
+func (m *TracePacket) GetOptionalTrustedPacketSequenceId() isTracePacket_OptionalTrustedPacketSequenceId {
+	if m != nil {
+		return m.OptionalTrustedPacketSequenceId
+	}
+	return nil
+}
+
+func (x *TracePacket) GetTrustedPacketSequenceId() uint32 {
+	if x, ok := x.GetOptionalTrustedPacketSequenceId().(*TracePacket_TrustedPacketSequenceId); ok {
+		return x.TrustedPacketSequenceId
+	}
+	return 0
+}
+
+func (x *TracePacket) GetSequenceFlags() uint32 {
+	if x != nil && x.SequenceFlags != nil {
+		return *x.SequenceFlags
+	}
+	return 0
+}
+
+type isTracePacket_Data interface {
+	isTracePacket_Data()
+}
+
+type TracePacket_TrackEvent struct {
+	TrackEvent *TrackEvent `protobuf:"bytes,11,opt,name=track_event,json=trackEvent,oneof"`
+}
+
+type TracePacket_TrackDescriptor struct {
+	TrackDescriptor *TrackDescriptor `protobuf:"bytes,60,opt,name=track_descriptor,json=trackDescriptor,oneof"`
+}
+
+func (*TracePacket_TrackEvent) isTracePacket_Data() {}
+
+func (*TracePacket_TrackDescriptor) isTracePacket_Data() {}
+
+type isTracePacket_OptionalTrustedPacketSequenceId interface {
+	isTracePacket_OptionalTrustedPacketSequenceId()
+}
+
+type TracePacket_TrustedPacketSequenceId struct {
+	TrustedPacketSequenceId uint32 `protobuf:"varint,10,opt,name=trusted_packet_sequence_id,json=trustedPacketSequenceId,oneof"`
+}
+
+func (*TracePacket_TrustedPacketSequenceId) isTracePacket_OptionalTrustedPacketSequenceId() {}
+
+type TrackDescriptor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uuid       *uint64            `protobuf:"varint,1,opt,name=uuid" json:"uuid,omitempty"`
+	ParentUuid *uint64            `protobuf:"varint,5,opt,name=parent_uuid,json=parentUuid" json:"parent_uuid,omitempty"`
+	Name       *string            `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	Counter    *CounterDescriptor `protobuf:"bytes,8,opt,name=counter" json:"counter,omitempty"`
+}
+
+func (x *TrackDescriptor) Reset() {
+	*x = TrackDescriptor{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_perfetto_perfetto_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrackDescriptor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackDescriptor) ProtoMessage() {}
+
+func (x *TrackDescriptor) ProtoReflect() protoreflect.Message {
+	mi := &file_api_perfetto_perfetto_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackDescriptor.ProtoReflect.Descriptor instead.
+func (*TrackDescriptor) Descriptor() ([]byte, []int) {
+	return file_api_perfetto_perfetto_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TrackDescriptor) GetUuid() uint64 {
+	if x != nil && x.Uuid != nil {
+		return *x.Uuid
+	}
+	return 0
+}
+
+func (x *TrackDescriptor) GetParentUuid() uint64 {
+	if x != nil && x.ParentUuid != nil {
+		return *x.ParentUuid
+	}
+	return 0
+}
+
+func (x *TrackDescriptor) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *TrackDescriptor) GetCounter() *CounterDescriptor {
+	if x != nil {
+		return x.Counter
+	}
+	return nil
+}
+
+type CounterDescriptor struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Unit *CounterDescriptor_Unit `protobuf:"varint,3,opt,name=unit,enum=perfetto.protos.CounterDescriptor_Unit" json:"unit,omitempty"`
+}
+
+func (x *CounterDescriptor) Reset() {
+	*x = CounterDescriptor{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_perfetto_perfetto_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CounterDescriptor) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CounterDescriptor) ProtoMessage() {}
+
+func (x *CounterDescriptor) ProtoReflect() protoreflect.Message {
+	mi := &file_api_perfetto_perfetto_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CounterDescriptor.ProtoReflect.Descriptor instead.
+func (*CounterDescriptor) Descriptor() ([]byte, []int) {
+	return file_api_perfetto_perfetto_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CounterDescriptor) GetUnit() CounterDescriptor_Unit {
+	if x != nil && x.Unit != nil {
+		return *x.Unit
+	}
+	return CounterDescriptor_UNIT_UNSPECIFIED
+}
+
+type TrackEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Categories []string `protobuf:"bytes,22,rep,name=categories" json:"categories,omitempty"`
+	// Types that are assignable to NameField:
+	//
+	//	*TrackEvent_Name
+	NameField isTrackEvent_NameField `protobuf_oneof:"name_field"`
+	Type      *TrackEvent_Type       `protobuf:"varint,9,opt,name=type,enum=perfetto.protos.TrackEvent_Type" json:"type,omitempty"`
+	TrackUuid *uint64                `protobuf:"varint,11,opt,name=track_uuid,json=trackUuid" json:"track_uuid,omitempty"`
+	// Types that are assignable to CounterValueField:
+	//
+	//	*TrackEvent_CounterValue
+	CounterValueField isTrackEvent_CounterValueField `protobuf_oneof:"counter_value_field"`
+	DebugAnnotations  []*DebugAnnotation             `protobuf:"bytes,4,rep,name=debug_annotations,json=debugAnnotations" json:"debug_annotations,omitempty"`
+	FlowIds           []uint64                       `protobuf:"fixed64,47,rep,name=flow_ids,json=flowIds" json:"flow_ids,omitempty"`
+}
+
+func (x *TrackEvent) Reset() {
+	*x = TrackEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_perfetto_perfetto_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrackEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrackEvent) ProtoMessage() {}
+
+func (x *TrackEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_perfetto_perfetto_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrackEvent.ProtoReflect.Descriptor instead.
+func (*TrackEvent) Descriptor() ([]byte, []int) {
+	return file_api_perfetto_perfetto_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TrackEvent) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (m *TrackEvent) GetNameField() isTrackEvent_NameField {
+	if m != nil {
+		return m.NameField
+	}
+	return nil
+}
+
+func (x *TrackEvent) GetName() string {
+	if x, ok := x.GetNameField().(*TrackEvent_Name); ok {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TrackEvent) GetType() TrackEvent_Type {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return TrackEvent_TYPE_UNSPECIFIED
+}
+
+func (x *TrackEvent) GetTrackUuid() uint64 {
+	if x != nil && x.TrackUuid != nil {
+		return *x.TrackUuid
+	}
+	return 0
+}
+
+func (m *TrackEvent) GetCounterValueField() isTrackEvent_CounterValueField {
+	if m != nil {
+		return m.CounterValueField
+	}
+	return nil
+}
+
+func (x *TrackEvent) GetCounterValue() int64 {
+	if x, ok := x.GetCounterValueField().(*TrackEvent_CounterValue); ok {
+		return x.CounterValue
+	}
+	return 0
+}
+
+func (x *TrackEvent) GetDebugAnnotations() []*DebugAnnotation {
+	if x != nil {
+		return x.DebugAnnotations
+	}
+	return nil
+}
+
+func (x *TrackEvent) GetFlowIds() []uint64 {
+	if x != nil {
+		return x.FlowIds
+	}
+	return nil
+}
+
+type isTrackEvent_NameField interface {
+	isTrackEvent_NameField()
+}
+
+type TrackEvent_Name struct {
+	Name string `protobuf:"bytes,23,opt,name=name,oneof"`
+}
+
+func (*TrackEvent_Name) isTrackEvent_NameField() {}
+
+type isTrackEvent_CounterValueField interface {
+	isTrackEvent_CounterValueField()
+}
+
+type TrackEvent_CounterValue struct {
+	CounterValue int64 `protobuf:"varint,30,opt,name=counter_value,json=counterValue,oneof"`
+}
+
+func (*TrackEvent_CounterValue) isTrackEvent_CounterValueField() {}
+
+type DebugAnnotation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to NameField:
+	//
+	//	*DebugAnnotation_Name
+	NameField isDebugAnnotation_NameField `protobuf_oneof:"name_field"`
+	// Types that are assignable to Value:
+	//
+	//	*DebugAnnotation_StringValue
+	Value isDebugAnnotation_Value `protobuf_oneof:"value"`
+}
+
+func (x *DebugAnnotation) Reset() {
+	*x = DebugAnnotation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_perfetto_perfetto_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugAnnotation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugAnnotation) ProtoMessage() {}
+
+func (x *DebugAnnotation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_perfetto_perfetto_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugAnnotation.ProtoReflect.Descriptor instead.
+func (*DebugAnnotation) Descriptor() ([]byte, []int) {
+	return file_api_perfetto_perfetto_proto_rawDescGZIP(), []int{5}
+}
+
+func (m *DebugAnnotation) GetNameField() isDebugAnnotation_NameField {
+	if m != nil {
+		return m.NameField
+	}
+	return nil
+}
+
+func (x *DebugAnnotation) GetName() string {
+	if x, ok := x.GetNameField().(*DebugAnnotation_Name); ok {
+		return x.Name
+	}
+	return ""
+}
+
+func (m *DebugAnnotation) GetValue() isDebugAnnotation_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (x *DebugAnnotation) GetStringValue() string {
+	if x, ok := x.GetValue().(*DebugAnnotation_StringValue); ok {
+		return x.StringValue
+	}
+	return ""
+}
+
+type isDebugAnnotation_NameField interface {
+	isDebugAnnotation_NameField()
+}
+
+type DebugAnnotation_Name struct {
+	Name string `protobuf:"bytes,10,opt,name=name,oneof"`
+}
+
+func (*DebugAnnotation_Name) isDebugAnnotation_NameField() {}
+
+type isDebugAnnotation_Value interface {
+	isDebugAnnotation_Value()
+}
+
+type DebugAnnotation_StringValue struct {
+	StringValue string `protobuf:"bytes,6,opt,name=string_value,json=stringValue,oneof"`
+}
+
+func (*DebugAnnotation_StringValue) isDebugAnnotation_Value() {}
+
+var File_api_perfetto_perfetto_proto protoreflect.FileDescriptor
+
+var file_api_perfetto_perfetto_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x65, 0x72, 0x66, 0x65, 0x74, 0x74, 0x6f, 0x2f, 0x70,
+	0x65, 0x72, 0x66, 0x65, 0x74, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x70,
+	0x65, 0x72, 0x66, 0x65, 0x74, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x22, 0x3d,
+	0x0a, 0x05, 0x54, 0x72, 0x61, 0x63, 0x65, 0x12, 0x34, 0x0a, 0x06, 0x70, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x65, 0x72, 0x66, 0x65, 0x74,
+	0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x65, 0x50,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x06, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x22, 0xb9, 0x03,
+	0x0a, 0x0b, 0x54, 0x72, 0x61, 0x63, 0x65, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x3e, 0x0a, 0x0b, 0x74,
+	0x72, 0x61, 0x63, 0x6b, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x70, 0x65, 0x72, 0x66, 0x65, 0x74, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x00, 0x52,
+	0x0a, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x4d, 0x0a, 0x10, 0x74,
+	0x72, 0x61, 0x63, 0x6b, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x18,
+	0x3c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x65, 0x72, 0x66, 0x65, 0x74, 0x74, 0x6f,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x44, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x63, 0x6b,
+	0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x12, 0x3d, 0x0a, 0x1a, 0x74, 0x72,
+	0x75, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x73, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01,
+	0x52, 0x17, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x53,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0d, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x46, 0x6c, 0x61, 0x67, 0x73,
+	0x22, 0x68, 0x0a, 0x0d, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x46, 0x6c, 0x61, 0x67,
+	0x73, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x45, 0x51, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x21, 0x0a, 0x1d, 0x53, 0x45, 0x51, 0x5f, 0x49, 0x4e,
+	0x43, 0x52, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x41, 0x4c, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f,
+	0x43, 0x4c, 0x45, 0x41, 0x52, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1f, 0x0a, 0x1b, 0x53, 0x45, 0x51,
+	0x5f, 0x4e, 0x45, 0x45, 0x44, 0x53, 0x5f, 0x49, 0x4e, 0x43, 0x52, 0x45, 0x4d, 0x45, 0x4e, 0x54,
+	0x41, 0x4c, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x10, 0x02, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x42, 0x25, 0x0a, 0x23, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x74,
+	0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x73, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x22, 0x98, 0x01, 0x0a, 0x0f, 0x54, 0x72,
+	0x61, 0x63, 0x6b, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a,
+	0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x75, 0x75, 0x69,
+	0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x75, 0x75, 0x69, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x55, 0x75,
+	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3c, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65,
+	0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x70, 0x65, 0x72, 0x66, 0x65, 0x74,
+	0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65,
+	0x72, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x52, 0x07, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x65, 0x72, 0x22, 0xa5, 0x01, 0x0a, 0x11, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72,
+	0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x12, 0x3b, 0x0a, 0x04, 0x75, 0x6e,
+	0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x70, 0x65, 0x72, 0x66, 0x65,
+	0x74, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x65, 0x72, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x55, 0x6e, 0x69,
+	0x74, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x22, 0x53, 0x0a, 0x04, 0x55, 0x6e, 0x69, 0x74, 0x12,
+	0x14, 0x0a, 0x10, 0x55, 0x4e, 0x49, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x55, 0x4e, 0x49, 0x54, 0x5f, 0x54, 0x49,
+	0x4d, 0x45, 0x5f, 0x4e, 0x53, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x55, 0x4e, 0x49, 0x54, 0x5f,
+	0x43, 0x4f, 0x55, 0x4e, 0x54, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x55, 0x4e, 0x49, 0x54, 0x5f,
+	0x53, 0x49, 0x5a, 0x45, 0x5f, 0x42, 0x59, 0x54, 0x45, 0x53, 0x10, 0x03, 0x22, 0xb9, 0x03, 0x0a,
+	0x0a, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63,
+	0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x16, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0a, 0x63, 0x61, 0x74, 0x65, 0x67, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x34, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x20, 0x2e, 0x70, 0x65, 0x72, 0x66, 0x65, 0x74, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x63, 0x6b,
+	0x5f, 0x75, 0x75, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x72, 0x61,
+	0x63, 0x6b, 0x55, 0x75, 0x69, 0x64, 0x12, 0x25, 0x0a, 0x0d, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65,
+	0x72, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x03, 0x48, 0x01, 0x52,
+	0x0c, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x4d, 0x0a,
+	0x11, 0x64, 0x65, 0x62, 0x75, 0x67, 0x5f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x65, 0x72, 0x66, 0x65,
+	0x74, 0x74, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67,
+	0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x64, 0x65, 0x62, 0x75,
+	0x67, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x19, 0x0a, 0x08,
+	0x66, 0x6c, 0x6f, 0x77, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x2f, 0x20, 0x03, 0x28, 0x06, 0x52, 0x07,
+	0x66, 0x6c, 0x6f, 0x77, 0x49, 0x64, 0x73, 0x22, 0x6a, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x14, 0x0a, 0x10, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x4c,
+	0x49, 0x43, 0x45, 0x5f, 0x42, 0x45, 0x47, 0x49, 0x4e, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x53, 0x4c, 0x49, 0x43, 0x45, 0x5f, 0x45, 0x4e, 0x44, 0x10, 0x02, 0x12,
+	0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x49, 0x4e, 0x53, 0x54, 0x41, 0x4e, 0x54, 0x10,
+	0x03, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x45,
+	0x52, 0x10, 0x04, 0x42, 0x0c, 0x0a, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x42, 0x15, 0x0a, 0x13, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x22, 0x63, 0x0a, 0x0f, 0x44, 0x65, 0x62, 0x75,
+	0x67, 0x41, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x23, 0x0a, 0x0c, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52, 0x0b, 0x73, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x2d, 0x5a,
+	0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x61, 0x7a, 0x65,
+	0x6c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x2f, 0x72, 0x65, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x70, 0x65, 0x72, 0x66, 0x65, 0x74, 0x74, 0x6f,
+}
+
+var (
+	file_api_perfetto_perfetto_proto_rawDescOnce sync.Once
+	file_api_perfetto_perfetto_proto_rawDescData = file_api_perfetto_perfetto_proto_rawDesc
+)
+
+func file_api_perfetto_perfetto_proto_rawDescGZIP() []byte {
+	file_api_perfetto_perfetto_proto_rawDescOnce.Do(func() {
+		file_api_perfetto_perfetto_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_perfetto_perfetto_proto_rawDescData)
+	})
+	return file_api_perfetto_perfetto_proto_rawDescData
+}
+
+var file_api_perfetto_perfetto_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_api_perfetto_perfetto_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_api_perfetto_perfetto_proto_goTypes = []interface{}{
+	(TracePacket_SequenceFlags)(0), // 0: perfetto.protos.TracePacket.SequenceFlags
+	(CounterDescriptor_Unit)(0),    // 1: perfetto.protos.CounterDescriptor.Unit
+	(TrackEvent_Type)(0),           // 2: perfetto.protos.TrackEvent.Type
+	(*Trace)(nil),                  // 3: perfetto.protos.Trace
+	(*TracePacket)(nil),            // 4: perfetto.protos.TracePacket
+	(*TrackDescriptor)(nil),        // 5: perfetto.protos.TrackDescriptor
+	(*CounterDescriptor)(nil),      // 6: perfetto.protos.CounterDescriptor
+	(*TrackEvent)(nil),             // 7: perfetto.protos.TrackEvent
+	(*DebugAnnotation)(nil),        // 8: perfetto.protos.DebugAnnotation
+}
+var file_api_perfetto_perfetto_proto_depIdxs = []int32{
+	4, // 0: perfetto.protos.Trace.packet:type_name -> perfetto.protos.TracePacket
+	7, // 1: perfetto.protos.TracePacket.track_event:type_name -> perfetto.protos.TrackEvent
+	5, // 2: perfetto.protos.TracePacket.track_descriptor:type_name -> perfetto.protos.TrackDescriptor
+	6, // 3: perfetto.protos.TrackDescriptor.counter:type_name -> perfetto.protos.CounterDescriptor
+	1, // 4: perfetto.protos.CounterDescriptor.unit:type_name -> perfetto.protos.CounterDescriptor.Unit
+	2, // 5: perfetto.protos.TrackEvent.type:type_name -> perfetto.protos.TrackEvent.Type
+	8, // 6: perfetto.protos.TrackEvent.debug_annotations:type_name -> perfetto.protos.DebugAnnotation
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_api_perfetto_perfetto_proto_init() }
+func file_api_perfetto_perfetto_proto_init() {
+	if File_api_perfetto_perfetto_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_api_perfetto_perfetto_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Trace); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_perfetto_perfetto_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TracePacket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_perfetto_perfetto_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrackDescriptor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_perfetto_perfetto_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CounterDescriptor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_perfetto_perfetto_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrackEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_perfetto_perfetto_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugAnnotation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_api_perfetto_perfetto_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*TracePacket_TrackEvent)(nil),
+		(*TracePacket_TrackDescriptor)(nil),
+		(*TracePacket_TrustedPacketSequenceId)(nil),
+	}
+	file_api_perfetto_perfetto_proto_msgTypes[4].OneofWrappers = []interface{}{
+		(*TrackEvent_Name)(nil),
+		(*TrackEvent_CounterValue)(nil),
+	}
+	file_api_perfetto_perfetto_proto_msgTypes[5].OneofWrappers = []interface{}{
+		(*DebugAnnotation_Name)(nil),
+		(*DebugAnnotation_StringValue)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_api_perfetto_perfetto_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_perfetto_perfetto_proto_goTypes,
+		DependencyIndexes: file_api_perfetto_perfetto_proto_depIdxs,
+		EnumInfos:         file_api_perfetto_perfetto_proto_enumTypes,
+		MessageInfos:      file_api_perfetto_perfetto_proto_msgTypes,
+	}.Build()
+	File_api_perfetto_perfetto_proto = out.File
+	file_api_perfetto_perfetto_proto_rawDesc = nil
+	file_api_perfetto_perfetto_proto_goTypes = nil
+	file_api_perfetto_perfetto_proto_depIdxs = nil
+}
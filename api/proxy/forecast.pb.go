@@ -0,0 +1,251 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v3.15.6
+// source: api/proxy/forecast.proto
+
+package proxy
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ForecastDatabase struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Samples map[string]*ForecastSamples `protobuf:"bytes,1,rep,name=samples,proto3" json:"samples,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ForecastDatabase) Reset() {
+	*x = ForecastDatabase{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_forecast_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForecastDatabase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastDatabase) ProtoMessage() {}
+
+func (x *ForecastDatabase) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_forecast_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastDatabase.ProtoReflect.Descriptor instead.
+func (*ForecastDatabase) Descriptor() ([]byte, []int) {
+	return file_api_proxy_forecast_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ForecastDatabase) GetSamples() map[string]*ForecastSamples {
+	if x != nil {
+		return x.Samples
+	}
+	return nil
+}
+
+type ForecastSamples struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DownloadLatencies []int64 `protobuf:"varint,1,rep,packed,name=download_latencies,json=downloadLatencies,proto3" json:"download_latencies,omitempty"`
+	RemoteDurations   []int64 `protobuf:"varint,2,rep,packed,name=remote_durations,json=remoteDurations,proto3" json:"remote_durations,omitempty"`
+	LocalDurations    []int64 `protobuf:"varint,3,rep,packed,name=local_durations,json=localDurations,proto3" json:"local_durations,omitempty"`
+	CacheHits         []bool  `protobuf:"varint,4,rep,packed,name=cache_hits,json=cacheHits,proto3" json:"cache_hits,omitempty"`
+}
+
+func (x *ForecastSamples) Reset() {
+	*x = ForecastSamples{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_forecast_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ForecastSamples) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastSamples) ProtoMessage() {}
+
+func (x *ForecastSamples) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_forecast_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastSamples.ProtoReflect.Descriptor instead.
+func (*ForecastSamples) Descriptor() ([]byte, []int) {
+	return file_api_proxy_forecast_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ForecastSamples) GetDownloadLatencies() []int64 {
+	if x != nil {
+		return x.DownloadLatencies
+	}
+	return nil
+}
+
+func (x *ForecastSamples) GetRemoteDurations() []int64 {
+	if x != nil {
+		return x.RemoteDurations
+	}
+	return nil
+}
+
+func (x *ForecastSamples) GetLocalDurations() []int64 {
+	if x != nil {
+		return x.LocalDurations
+	}
+	return nil
+}
+
+func (x *ForecastSamples) GetCacheHits() []bool {
+	if x != nil {
+		return x.CacheHits
+	}
+	return nil
+}
+
+var File_api_proxy_forecast_proto protoreflect.FileDescriptor
+
+var file_api_proxy_forecast_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2f, 0x66, 0x6f, 0x72, 0x65,
+	0x63, 0x61, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x78,
+	0x79, 0x22, 0xa6, 0x01, 0x0a, 0x10, 0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x44, 0x61,
+	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e,
+	0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
+	0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x73,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x1a, 0x52, 0x0a, 0x0c, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e,
+	0x46, 0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb3, 0x01, 0x0a, 0x0f, 0x46,
+	0x6f, 0x72, 0x65, 0x63, 0x61, 0x73, 0x74, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x2d,
+	0x0a, 0x12, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x6c, 0x61, 0x74, 0x65, 0x6e,
+	0x63, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x03, 0x52, 0x11, 0x64, 0x6f, 0x77, 0x6e,
+	0x6c, 0x6f, 0x61, 0x64, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x12, 0x29, 0x0a,
+	0x10, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x03, 0x52, 0x0e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x68, 0x69, 0x74, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x08, 0x52, 0x09, 0x63, 0x61, 0x63, 0x68, 0x65, 0x48, 0x69, 0x74, 0x73,
+	0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62,
+	0x61, 0x7a, 0x65, 0x6c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x2f, 0x72, 0x65, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_proxy_forecast_proto_rawDescOnce sync.Once
+	file_api_proxy_forecast_proto_rawDescData = file_api_proxy_forecast_proto_rawDesc
+)
+
+func file_api_proxy_forecast_proto_rawDescGZIP() []byte {
+	file_api_proxy_forecast_proto_rawDescOnce.Do(func() {
+		file_api_proxy_forecast_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_proxy_forecast_proto_rawDescData)
+	})
+	return file_api_proxy_forecast_proto_rawDescData
+}
+
+var file_api_proxy_forecast_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_api_proxy_forecast_proto_goTypes = []interface{}{
+	(*ForecastDatabase)(nil), // 0: proxy.ForecastDatabase
+	(*ForecastSamples)(nil),  // 1: proxy.ForecastSamples
+	nil,                      // 2: proxy.ForecastDatabase.SamplesEntry
+}
+var file_api_proxy_forecast_proto_depIdxs = []int32{
+	2, // 0: proxy.ForecastDatabase.samples:type_name -> proxy.ForecastDatabase.SamplesEntry
+	1, // 1: proxy.ForecastDatabase.SamplesEntry.value:type_name -> proxy.ForecastSamples
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_api_proxy_forecast_proto_init() }
+func file_api_proxy_forecast_proto_init() {
+	if File_api_proxy_forecast_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_api_proxy_forecast_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ForecastDatabase); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proxy_forecast_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ForecastSamples); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_api_proxy_forecast_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_proxy_forecast_proto_goTypes,
+		DependencyIndexes: file_api_proxy_forecast_proto_depIdxs,
+		MessageInfos:      file_api_proxy_forecast_proto_msgTypes,
+	}.Build()
+	File_api_proxy_forecast_proto = out.File
+	file_api_proxy_forecast_proto_rawDesc = nil
+	file_api_proxy_forecast_proto_goTypes = nil
+	file_api_proxy_forecast_proto_depIdxs = nil
+}
@@ -0,0 +1,316 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v3.15.6
+// source: api/proxy/local_resources.proto
+
+package proxy
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LocalResourcesConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DefaultResources *LocalResources       `protobuf:"bytes,1,opt,name=default_resources,json=defaultResources,proto3" json:"default_resources,omitempty"`
+	Rules            []*LocalResourcesRule `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
+}
+
+func (x *LocalResourcesConfig) Reset() {
+	*x = LocalResourcesConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_local_resources_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LocalResourcesConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocalResourcesConfig) ProtoMessage() {}
+
+func (x *LocalResourcesConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_local_resources_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocalResourcesConfig.ProtoReflect.Descriptor instead.
+func (*LocalResourcesConfig) Descriptor() ([]byte, []int) {
+	return file_api_proxy_local_resources_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LocalResourcesConfig) GetDefaultResources() *LocalResources {
+	if x != nil {
+		return x.DefaultResources
+	}
+	return nil
+}
+
+func (x *LocalResourcesConfig) GetRules() []*LocalResourcesRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type LocalResourcesRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Labels    map[string]string `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Resources *LocalResources   `protobuf:"bytes,2,opt,name=resources,proto3" json:"resources,omitempty"`
+}
+
+func (x *LocalResourcesRule) Reset() {
+	*x = LocalResourcesRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_local_resources_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LocalResourcesRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocalResourcesRule) ProtoMessage() {}
+
+func (x *LocalResourcesRule) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_local_resources_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocalResourcesRule.ProtoReflect.Descriptor instead.
+func (*LocalResourcesRule) Descriptor() ([]byte, []int) {
+	return file_api_proxy_local_resources_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LocalResourcesRule) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *LocalResourcesRule) GetResources() *LocalResources {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+type LocalResources struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cpus  int64 `protobuf:"varint,1,opt,name=cpus,proto3" json:"cpus,omitempty"`
+	RamMb int64 `protobuf:"varint,2,opt,name=ram_mb,json=ramMb,proto3" json:"ram_mb,omitempty"`
+}
+
+func (x *LocalResources) Reset() {
+	*x = LocalResources{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_local_resources_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LocalResources) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocalResources) ProtoMessage() {}
+
+func (x *LocalResources) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_local_resources_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocalResources.ProtoReflect.Descriptor instead.
+func (*LocalResources) Descriptor() ([]byte, []int) {
+	return file_api_proxy_local_resources_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LocalResources) GetCpus() int64 {
+	if x != nil {
+		return x.Cpus
+	}
+	return 0
+}
+
+func (x *LocalResources) GetRamMb() int64 {
+	if x != nil {
+		return x.RamMb
+	}
+	return 0
+}
+
+var File_api_proxy_local_resources_proto protoreflect.FileDescriptor
+
+var file_api_proxy_local_resources_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2f, 0x6c, 0x6f, 0x63, 0x61,
+	0x6c, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x22, 0x8b, 0x01, 0x0a, 0x14, 0x4c, 0x6f, 0x63,
+	0x61, 0x6c, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x12, 0x42, 0x0a, 0x11, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70,
+	0x72, 0x6f, 0x78, 0x79, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x73, 0x52, 0x10, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x4c, 0x6f, 0x63,
+	0x61, 0x6c, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x75, 0x6c, 0x65, 0x52,
+	0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x22, 0xc3, 0x01, 0x0a, 0x12, 0x4c, 0x6f, 0x63, 0x61, 0x6c,
+	0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x3d, 0x0a,
+	0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e,
+	0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x73, 0x52, 0x75, 0x6c, 0x65, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x33, 0x0a, 0x09,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x3b, 0x0a, 0x0e,
+	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x63, 0x70, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x63, 0x70,
+	0x75, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x72, 0x61, 0x6d, 0x5f, 0x6d, 0x62, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x72, 0x61, 0x6d, 0x4d, 0x62, 0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x61, 0x7a, 0x65, 0x6c, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x2f, 0x72, 0x65, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x70, 0x72, 0x6f, 0x78, 0x79, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_proxy_local_resources_proto_rawDescOnce sync.Once
+	file_api_proxy_local_resources_proto_rawDescData = file_api_proxy_local_resources_proto_rawDesc
+)
+
+func file_api_proxy_local_resources_proto_rawDescGZIP() []byte {
+	file_api_proxy_local_resources_proto_rawDescOnce.Do(func() {
+		file_api_proxy_local_resources_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_proxy_local_resources_proto_rawDescData)
+	})
+	return file_api_proxy_local_resources_proto_rawDescData
+}
+
+var file_api_proxy_local_resources_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_api_proxy_local_resources_proto_goTypes = []interface{}{
+	(*LocalResourcesConfig)(nil), // 0: proxy.LocalResourcesConfig
+	(*LocalResourcesRule)(nil),   // 1: proxy.LocalResourcesRule
+	(*LocalResources)(nil),       // 2: proxy.LocalResources
+	nil,                          // 3: proxy.LocalResourcesRule.LabelsEntry
+}
+var file_api_proxy_local_resources_proto_depIdxs = []int32{
+	2, // 0: proxy.LocalResourcesConfig.default_resources:type_name -> proxy.LocalResources
+	1, // 1: proxy.LocalResourcesConfig.rules:type_name -> proxy.LocalResourcesRule
+	3, // 2: proxy.LocalResourcesRule.labels:type_name -> proxy.LocalResourcesRule.LabelsEntry
+	2, // 3: proxy.LocalResourcesRule.resources:type_name -> proxy.LocalResources
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_api_proxy_local_resources_proto_init() }
+func file_api_proxy_local_resources_proto_init() {
+	if File_api_proxy_local_resources_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_api_proxy_local_resources_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LocalResourcesConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proxy_local_resources_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LocalResourcesRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proxy_local_resources_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LocalResources); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_api_proxy_local_resources_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_proxy_local_resources_proto_goTypes,
+		DependencyIndexes: file_api_proxy_local_resources_proto_depIdxs,
+		MessageInfos:      file_api_proxy_local_resources_proto_msgTypes,
+	}.Build()
+	File_api_proxy_local_resources_proto = out.File
+	file_api_proxy_local_resources_proto_rawDesc = nil
+	file_api_proxy_local_resources_proto_goTypes = nil
+	file_api_proxy_local_resources_proto_depIdxs = nil
+}
@@ -16,6 +16,8 @@ import (
 	status "google.golang.org/grpc/status"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -27,6 +29,119 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ActionPhase_Value int32
+
+const (
+	ActionPhase_UNSPECIFIED        ActionPhase_Value = 0
+	ActionPhase_INPUT_PROCESSING   ActionPhase_Value = 1
+	ActionPhase_CACHE_LOOKUP       ActionPhase_Value = 2
+	ActionPhase_UPLOAD_AND_EXECUTE ActionPhase_Value = 3
+	ActionPhase_DOWNLOAD           ActionPhase_Value = 4
+	ActionPhase_LOCAL_QUEUE        ActionPhase_Value = 5
+	ActionPhase_LOCAL_EXECUTION    ActionPhase_Value = 6
+)
+
+// Enum value maps for ActionPhase_Value.
+var (
+	ActionPhase_Value_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "INPUT_PROCESSING",
+		2: "CACHE_LOOKUP",
+		3: "UPLOAD_AND_EXECUTE",
+		4: "DOWNLOAD",
+		5: "LOCAL_QUEUE",
+		6: "LOCAL_EXECUTION",
+	}
+	ActionPhase_Value_value = map[string]int32{
+		"UNSPECIFIED":        0,
+		"INPUT_PROCESSING":   1,
+		"CACHE_LOOKUP":       2,
+		"UPLOAD_AND_EXECUTE": 3,
+		"DOWNLOAD":           4,
+		"LOCAL_QUEUE":        5,
+		"LOCAL_EXECUTION":    6,
+	}
+)
+
+func (x ActionPhase_Value) Enum() *ActionPhase_Value {
+	p := new(ActionPhase_Value)
+	*p = x
+	return p
+}
+
+func (x ActionPhase_Value) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ActionPhase_Value) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proxy_proxy_proto_enumTypes[0].Descriptor()
+}
+
+func (ActionPhase_Value) Type() protoreflect.EnumType {
+	return &file_api_proxy_proxy_proto_enumTypes[0]
+}
+
+func (x ActionPhase_Value) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ActionPhase_Value.Descriptor instead.
+func (ActionPhase_Value) EnumDescriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{18, 0}
+}
+
+type ActionEvent_Type int32
+
+const (
+	ActionEvent_UNSPECIFIED   ActionEvent_Type = 0
+	ActionEvent_STARTED       ActionEvent_Type = 1
+	ActionEvent_PHASE_CHANGED ActionEvent_Type = 2
+	ActionEvent_COMPLETED     ActionEvent_Type = 3
+)
+
+// Enum value maps for ActionEvent_Type.
+var (
+	ActionEvent_Type_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "STARTED",
+		2: "PHASE_CHANGED",
+		3: "COMPLETED",
+	}
+	ActionEvent_Type_value = map[string]int32{
+		"UNSPECIFIED":   0,
+		"STARTED":       1,
+		"PHASE_CHANGED": 2,
+		"COMPLETED":     3,
+	}
+)
+
+func (x ActionEvent_Type) Enum() *ActionEvent_Type {
+	p := new(ActionEvent_Type)
+	*p = x
+	return p
+}
+
+func (x ActionEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ActionEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proxy_proxy_proto_enumTypes[1].Descriptor()
+}
+
+func (ActionEvent_Type) Type() protoreflect.EnumType {
+	return &file_api_proxy_proxy_proto_enumTypes[1]
+}
+
+func (x ActionEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ActionEvent_Type.Descriptor instead.
+func (ActionEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{19, 0}
+}
+
 type ExecutionStrategy_Value int32
 
 const (
@@ -66,11 +181,11 @@ func (x ExecutionStrategy_Value) String() string {
 }
 
 func (ExecutionStrategy_Value) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_proxy_proxy_proto_enumTypes[0].Descriptor()
+	return file_api_proxy_proxy_proto_enumTypes[2].Descriptor()
 }
 
 func (ExecutionStrategy_Value) Type() protoreflect.EnumType {
-	return &file_api_proxy_proxy_proto_enumTypes[0]
+	return &file_api_proxy_proxy_proto_enumTypes[2]
 }
 
 func (x ExecutionStrategy_Value) Number() protoreflect.EnumNumber {
@@ -79,7 +194,7 @@ func (x ExecutionStrategy_Value) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ExecutionStrategy_Value.Descriptor instead.
 func (ExecutionStrategy_Value) EnumDescriptor() ([]byte, []int) {
-	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{12, 0}
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{24, 0}
 }
 
 type LocalExecutionOptions_LocalExecutionPlatform int32
@@ -87,6 +202,7 @@ type LocalExecutionOptions_LocalExecutionPlatform int32
 const (
 	LocalExecutionOptions_UNSPECIFIED LocalExecutionOptions_LocalExecutionPlatform = 0
 	LocalExecutionOptions_DOCKER      LocalExecutionOptions_LocalExecutionPlatform = 1
+	LocalExecutionOptions_SANDBOX     LocalExecutionOptions_LocalExecutionPlatform = 2
 )
 
 // Enum value maps for LocalExecutionOptions_LocalExecutionPlatform.
@@ -94,10 +210,12 @@ var (
 	LocalExecutionOptions_LocalExecutionPlatform_name = map[int32]string{
 		0: "UNSPECIFIED",
 		1: "DOCKER",
+		2: "SANDBOX",
 	}
 	LocalExecutionOptions_LocalExecutionPlatform_value = map[string]int32{
 		"UNSPECIFIED": 0,
 		"DOCKER":      1,
+		"SANDBOX":     2,
 	}
 )
 
@@ -112,11 +230,11 @@ func (x LocalExecutionOptions_LocalExecutionPlatform) String() string {
 }
 
 func (LocalExecutionOptions_LocalExecutionPlatform) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_proxy_proxy_proto_enumTypes[1].Descriptor()
+	return file_api_proxy_proxy_proto_enumTypes[3].Descriptor()
 }
 
 func (LocalExecutionOptions_LocalExecutionPlatform) Type() protoreflect.EnumType {
-	return &file_api_proxy_proxy_proto_enumTypes[1]
+	return &file_api_proxy_proxy_proto_enumTypes[3]
 }
 
 func (x LocalExecutionOptions_LocalExecutionPlatform) Number() protoreflect.EnumNumber {
@@ -125,7 +243,92 @@ func (x LocalExecutionOptions_LocalExecutionPlatform) Number() protoreflect.Enum
 
 // Deprecated: Use LocalExecutionOptions_LocalExecutionPlatform.Descriptor instead.
 func (LocalExecutionOptions_LocalExecutionPlatform) EnumDescriptor() ([]byte, []int) {
-	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{13, 0}
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{25, 0}
+}
+
+type CancelCommandRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExecutionId string `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+}
+
+func (x *CancelCommandRequest) Reset() {
+	*x = CancelCommandRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_proxy_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelCommandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelCommandRequest) ProtoMessage() {}
+
+func (x *CancelCommandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_proxy_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelCommandRequest.ProtoReflect.Descriptor instead.
+func (*CancelCommandRequest) Descriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CancelCommandRequest) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+type CancelCommandResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *CancelCommandResponse) Reset() {
+	*x = CancelCommandResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_proxy_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelCommandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelCommandResponse) ProtoMessage() {}
+
+func (x *CancelCommandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_proxy_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelCommandResponse.ProtoReflect.Descriptor instead.
+func (*CancelCommandResponse) Descriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{1}
 }
 
 type ShutdownRequest struct {
@@ -137,7 +340,7 @@ type ShutdownRequest struct {
 func (x *ShutdownRequest) Reset() {
 	*x = ShutdownRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proxy_proxy_proto_msgTypes[0]
+		mi := &file_api_proxy_proxy_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -150,7 +353,7 @@ func (x *ShutdownRequest) String() string {
 func (*ShutdownRequest) ProtoMessage() {}
 
 func (x *ShutdownRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proxy_proxy_proto_msgTypes[0]
+	mi := &file_api_proxy_proxy_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -163,7 +366,92 @@ func (x *ShutdownRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShutdownRequest.ProtoReflect.Descriptor instead.
 func (*ShutdownRequest) Descriptor() ([]byte, []int) {
-	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{0}
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{2}
+}
+
+type ReloadConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ReloadConfigRequest) Reset() {
+	*x = ReloadConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_proxy_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReloadConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadConfigRequest) ProtoMessage() {}
+
+func (x *ReloadConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_proxy_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadConfigRequest.ProtoReflect.Descriptor instead.
+func (*ReloadConfigRequest) Descriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{3}
+}
+
+type ReloadConfigResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChangedFlags map[string]string `protobuf:"bytes,1,rep,name=changed_flags,json=changedFlags,proto3" json:"changed_flags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ReloadConfigResponse) Reset() {
+	*x = ReloadConfigResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_proxy_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReloadConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadConfigResponse) ProtoMessage() {}
+
+func (x *ReloadConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_proxy_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadConfigResponse.ProtoReflect.Descriptor instead.
+func (*ReloadConfigResponse) Descriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReloadConfigResponse) GetChangedFlags() map[string]string {
+	if x != nil {
+		return x.ChangedFlags
+	}
+	return nil
 }
 
 type ShutdownResponse struct {
@@ -177,7 +465,7 @@ type ShutdownResponse struct {
 func (x *ShutdownResponse) Reset() {
 	*x = ShutdownResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proxy_proxy_proto_msgTypes[1]
+		mi := &file_api_proxy_proxy_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -190,7 +478,7 @@ func (x *ShutdownResponse) String() string {
 func (*ShutdownResponse) ProtoMessage() {}
 
 func (x *ShutdownResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proxy_proxy_proto_msgTypes[1]
+	mi := &file_api_proxy_proxy_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -203,7 +491,7 @@ func (x *ShutdownResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ShutdownResponse.ProtoReflect.Descriptor instead.
 func (*ShutdownResponse) Descriptor() ([]byte, []int) {
-	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{1}
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ShutdownResponse) GetStats() *stats.Stats {
@@ -217,67 +505,697 @@ type GetStatusSummaryRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	InvocationId string `protobuf:"bytes,1,opt,name=invocation_id,json=invocationId,proto3" json:"invocation_id,omitempty"`
+}
+
+func (x *GetStatusSummaryRequest) Reset() {
+	*x = GetStatusSummaryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_proxy_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatusSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusSummaryRequest) ProtoMessage() {}
+
+func (x *GetStatusSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_proxy_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetStatusSummaryRequest) GetInvocationId() string {
+	if x != nil {
+		return x.InvocationId
+	}
+	return ""
+}
+
+type GetStatusSummaryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CompletedActionStats map[string]int32 `protobuf:"bytes,1,rep,name=completed_action_stats,json=completedActionStats,proto3" json:"completed_action_stats,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	RunningActions       int32            `protobuf:"varint,2,opt,name=running_actions,json=runningActions,proto3" json:"running_actions,omitempty"`
+	Qps                  int32            `protobuf:"varint,3,opt,name=qps,proto3" json:"qps,omitempty"`
+	InvocationIds        []string         `protobuf:"bytes,4,rep,name=invocation_ids,json=invocationIds,proto3" json:"invocation_ids,omitempty"`
+}
+
+func (x *GetStatusSummaryResponse) Reset() {
+	*x = GetStatusSummaryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_proxy_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStatusSummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusSummaryResponse) ProtoMessage() {}
+
+func (x *GetStatusSummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_proxy_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusSummaryResponse.ProtoReflect.Descriptor instead.
+func (*GetStatusSummaryResponse) Descriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetStatusSummaryResponse) GetCompletedActionStats() map[string]int32 {
+	if x != nil {
+		return x.CompletedActionStats
+	}
+	return nil
+}
+
+func (x *GetStatusSummaryResponse) GetRunningActions() int32 {
+	if x != nil {
+		return x.RunningActions
+	}
+	return 0
+}
+
+func (x *GetStatusSummaryResponse) GetQps() int32 {
+	if x != nil {
+		return x.Qps
+	}
+	return 0
+}
+
+func (x *GetStatusSummaryResponse) GetInvocationIds() []string {
+	if x != nil {
+		return x.InvocationIds
+	}
+	return nil
+}
+
+type GetInvocationStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InvocationId string `protobuf:"bytes,1,opt,name=invocation_id,json=invocationId,proto3" json:"invocation_id,omitempty"`
+}
+
+func (x *GetInvocationStatsRequest) Reset() {
+	*x = GetInvocationStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_proxy_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInvocationStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvocationStatsRequest) ProtoMessage() {}
+
+func (x *GetInvocationStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_proxy_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvocationStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetInvocationStatsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetInvocationStatsRequest) GetInvocationId() string {
+	if x != nil {
+		return x.InvocationId
+	}
+	return ""
+}
+
+type GetInvocationStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stats *stats.Stats `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *GetInvocationStatsResponse) Reset() {
+	*x = GetInvocationStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_proxy_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetInvocationStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvocationStatsResponse) ProtoMessage() {}
+
+func (x *GetInvocationStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_proxy_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvocationStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetInvocationStatsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetInvocationStatsResponse) GetStats() *stats.Stats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type GetRecordsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CompletionStatuses []log.CompletionStatus `protobuf:"varint,1,rep,packed,name=completion_statuses,json=completionStatuses,proto3,enum=log.CompletionStatus" json:"completion_statuses,omitempty"`
+	Labels             map[string]string      `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	InvocationId       string                 `protobuf:"bytes,3,opt,name=invocation_id,json=invocationId,proto3" json:"invocation_id,omitempty"`
+	CompletedAfter     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=completed_after,json=completedAfter,proto3" json:"completed_after,omitempty"`
+	CompletedBefore    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=completed_before,json=completedBefore,proto3" json:"completed_before,omitempty"`
+	MinDuration        *durationpb.Duration   `protobuf:"bytes,6,opt,name=min_duration,json=minDuration,proto3" json:"min_duration,omitempty"`
+	MostRecentFirst    bool                   `protobuf:"varint,7,opt,name=most_recent_first,json=mostRecentFirst,proto3" json:"most_recent_first,omitempty"`
+	PageSize           int32                  `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken          string                 `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *GetRecordsRequest) Reset() {
+	*x = GetRecordsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_proxy_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecordsRequest) ProtoMessage() {}
+
+func (x *GetRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_proxy_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecordsRequest.ProtoReflect.Descriptor instead.
+func (*GetRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetRecordsRequest) GetCompletionStatuses() []log.CompletionStatus {
+	if x != nil {
+		return x.CompletionStatuses
+	}
+	return nil
+}
+
+func (x *GetRecordsRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *GetRecordsRequest) GetInvocationId() string {
+	if x != nil {
+		return x.InvocationId
+	}
+	return ""
+}
+
+func (x *GetRecordsRequest) GetCompletedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAfter
+	}
+	return nil
+}
+
+func (x *GetRecordsRequest) GetCompletedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedBefore
+	}
+	return nil
+}
+
+func (x *GetRecordsRequest) GetMinDuration() *durationpb.Duration {
+	if x != nil {
+		return x.MinDuration
+	}
+	return nil
+}
+
+func (x *GetRecordsRequest) GetMostRecentFirst() bool {
+	if x != nil {
+		return x.MostRecentFirst
+	}
+	return false
+}
+
+func (x *GetRecordsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetRecordsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type GetRecordsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Records       []*log.LogRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	NextPageToken string           `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *GetRecordsResponse) Reset() {
+	*x = GetRecordsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_proxy_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecordsResponse) ProtoMessage() {}
+
+func (x *GetRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_proxy_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecordsResponse.ProtoReflect.Descriptor instead.
+func (*GetRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetRecordsResponse) GetRecords() []*log.LogRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *GetRecordsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type AddProxyEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventTimes map[string]*command.TimeInterval `protobuf:"bytes,1,rep,name=event_times,json=eventTimes,proto3" json:"event_times,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *AddProxyEventsRequest) Reset() {
+	*x = AddProxyEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_proxy_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddProxyEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProxyEventsRequest) ProtoMessage() {}
+
+func (x *AddProxyEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_proxy_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProxyEventsRequest.ProtoReflect.Descriptor instead.
+func (*AddProxyEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AddProxyEventsRequest) GetEventTimes() map[string]*command.TimeInterval {
+	if x != nil {
+		return x.EventTimes
+	}
+	return nil
+}
+
+type AddProxyEventsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AddProxyEventsResponse) Reset() {
+	*x = AddProxyEventsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_proxy_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddProxyEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProxyEventsResponse) ProtoMessage() {}
+
+func (x *AddProxyEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_proxy_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProxyEventsResponse.ProtoReflect.Descriptor instead.
+func (*AddProxyEventsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{13}
+}
+
+type WatchActionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InvocationId string `protobuf:"bytes,1,opt,name=invocation_id,json=invocationId,proto3" json:"invocation_id,omitempty"`
+}
+
+func (x *WatchActionsRequest) Reset() {
+	*x = WatchActionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_proxy_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchActionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchActionsRequest) ProtoMessage() {}
+
+func (x *WatchActionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_proxy_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchActionsRequest.ProtoReflect.Descriptor instead.
+func (*WatchActionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WatchActionsRequest) GetInvocationId() string {
+	if x != nil {
+		return x.InvocationId
+	}
+	return ""
+}
+
+type GetRunningActionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InvocationId string `protobuf:"bytes,1,opt,name=invocation_id,json=invocationId,proto3" json:"invocation_id,omitempty"`
+	MaxActions   int32  `protobuf:"varint,2,opt,name=max_actions,json=maxActions,proto3" json:"max_actions,omitempty"`
+}
+
+func (x *GetRunningActionsRequest) Reset() {
+	*x = GetRunningActionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_proxy_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRunningActionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRunningActionsRequest) ProtoMessage() {}
+
+func (x *GetRunningActionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_proxy_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRunningActionsRequest.ProtoReflect.Descriptor instead.
+func (*GetRunningActionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetRunningActionsRequest) GetInvocationId() string {
+	if x != nil {
+		return x.InvocationId
+	}
+	return ""
+}
+
+func (x *GetRunningActionsRequest) GetMaxActions() int32 {
+	if x != nil {
+		return x.MaxActions
+	}
+	return 0
+}
+
+type RunningAction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExecutionId       string                  `protobuf:"bytes,1,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	InvocationId      string                  `protobuf:"bytes,2,opt,name=invocation_id,json=invocationId,proto3" json:"invocation_id,omitempty"`
+	CommandId         string                  `protobuf:"bytes,3,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	Labels            map[string]string       `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ExecutionStrategy ExecutionStrategy_Value `protobuf:"varint,5,opt,name=execution_strategy,json=executionStrategy,proto3,enum=proxy.ExecutionStrategy_Value" json:"execution_strategy,omitempty"`
+	StartTime         *timestamppb.Timestamp  `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	Phase             ActionPhase_Value       `protobuf:"varint,7,opt,name=phase,proto3,enum=proxy.ActionPhase_Value" json:"phase,omitempty"`
+	PhaseStartTime    *timestamppb.Timestamp  `protobuf:"bytes,8,opt,name=phase_start_time,json=phaseStartTime,proto3" json:"phase_start_time,omitempty"`
+}
+
+func (x *RunningAction) Reset() {
+	*x = RunningAction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_proxy_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunningAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunningAction) ProtoMessage() {}
+
+func (x *RunningAction) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_proxy_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunningAction.ProtoReflect.Descriptor instead.
+func (*RunningAction) Descriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RunningAction) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
+}
+
+func (x *RunningAction) GetInvocationId() string {
+	if x != nil {
+		return x.InvocationId
+	}
+	return ""
+}
+
+func (x *RunningAction) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
 }
 
-func (x *GetStatusSummaryRequest) Reset() {
-	*x = GetStatusSummaryRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proxy_proxy_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *RunningAction) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
 	}
+	return nil
 }
 
-func (x *GetStatusSummaryRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *RunningAction) GetExecutionStrategy() ExecutionStrategy_Value {
+	if x != nil {
+		return x.ExecutionStrategy
+	}
+	return ExecutionStrategy_UNSPECIFIED
 }
 
-func (*GetStatusSummaryRequest) ProtoMessage() {}
+func (x *RunningAction) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
 
-func (x *GetStatusSummaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proxy_proxy_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *RunningAction) GetPhase() ActionPhase_Value {
+	if x != nil {
+		return x.Phase
 	}
-	return mi.MessageOf(x)
+	return ActionPhase_UNSPECIFIED
 }
 
-// Deprecated: Use GetStatusSummaryRequest.ProtoReflect.Descriptor instead.
-func (*GetStatusSummaryRequest) Descriptor() ([]byte, []int) {
-	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{2}
+func (x *RunningAction) GetPhaseStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PhaseStartTime
+	}
+	return nil
 }
 
-type GetStatusSummaryResponse struct {
+type GetRunningActionsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CompletedActionStats map[string]int32 `protobuf:"bytes,1,rep,name=completed_action_stats,json=completedActionStats,proto3" json:"completed_action_stats,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	RunningActions       int32            `protobuf:"varint,2,opt,name=running_actions,json=runningActions,proto3" json:"running_actions,omitempty"`
-	Qps                  int32            `protobuf:"varint,3,opt,name=qps,proto3" json:"qps,omitempty"`
+	Actions          []*RunningAction `protobuf:"bytes,1,rep,name=actions,proto3" json:"actions,omitempty"`
+	TotalActions     int32            `protobuf:"varint,2,opt,name=total_actions,json=totalActions,proto3" json:"total_actions,omitempty"`
+	LocalQueueLength int64            `protobuf:"varint,3,opt,name=local_queue_length,json=localQueueLength,proto3" json:"local_queue_length,omitempty"`
 }
 
-func (x *GetStatusSummaryResponse) Reset() {
-	*x = GetStatusSummaryResponse{}
+func (x *GetRunningActionsResponse) Reset() {
+	*x = GetRunningActionsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proxy_proxy_proto_msgTypes[3]
+		mi := &file_api_proxy_proxy_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetStatusSummaryResponse) String() string {
+func (x *GetRunningActionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetStatusSummaryResponse) ProtoMessage() {}
+func (*GetRunningActionsResponse) ProtoMessage() {}
 
-func (x *GetStatusSummaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proxy_proxy_proto_msgTypes[3]
+func (x *GetRunningActionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_proxy_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -288,55 +1206,55 @@ func (x *GetStatusSummaryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetStatusSummaryResponse.ProtoReflect.Descriptor instead.
-func (*GetStatusSummaryResponse) Descriptor() ([]byte, []int) {
-	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use GetRunningActionsResponse.ProtoReflect.Descriptor instead.
+func (*GetRunningActionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *GetStatusSummaryResponse) GetCompletedActionStats() map[string]int32 {
+func (x *GetRunningActionsResponse) GetActions() []*RunningAction {
 	if x != nil {
-		return x.CompletedActionStats
+		return x.Actions
 	}
 	return nil
 }
 
-func (x *GetStatusSummaryResponse) GetRunningActions() int32 {
+func (x *GetRunningActionsResponse) GetTotalActions() int32 {
 	if x != nil {
-		return x.RunningActions
+		return x.TotalActions
 	}
 	return 0
 }
 
-func (x *GetStatusSummaryResponse) GetQps() int32 {
+func (x *GetRunningActionsResponse) GetLocalQueueLength() int64 {
 	if x != nil {
-		return x.Qps
+		return x.LocalQueueLength
 	}
 	return 0
 }
 
-type GetRecordsRequest struct {
+type ActionPhase struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 }
 
-func (x *GetRecordsRequest) Reset() {
-	*x = GetRecordsRequest{}
+func (x *ActionPhase) Reset() {
+	*x = ActionPhase{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proxy_proxy_proto_msgTypes[4]
+		mi := &file_api_proxy_proxy_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetRecordsRequest) String() string {
+func (x *ActionPhase) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRecordsRequest) ProtoMessage() {}
+func (*ActionPhase) ProtoMessage() {}
 
-func (x *GetRecordsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proxy_proxy_proto_msgTypes[4]
+func (x *ActionPhase) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_proxy_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -347,36 +1265,46 @@ func (x *GetRecordsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRecordsRequest.ProtoReflect.Descriptor instead.
-func (*GetRecordsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ActionPhase.ProtoReflect.Descriptor instead.
+func (*ActionPhase) Descriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{18}
 }
 
-type GetRecordsResponse struct {
+type ActionEvent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Records []*log.LogRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
-}
-
-func (x *GetRecordsResponse) Reset() {
-	*x = GetRecordsResponse{}
+	Type              ActionEvent_Type        `protobuf:"varint,1,opt,name=type,proto3,enum=proxy.ActionEvent_Type" json:"type,omitempty"`
+	Time              *timestamppb.Timestamp  `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	ExecutionId       string                  `protobuf:"bytes,3,opt,name=execution_id,json=executionId,proto3" json:"execution_id,omitempty"`
+	InvocationId      string                  `protobuf:"bytes,4,opt,name=invocation_id,json=invocationId,proto3" json:"invocation_id,omitempty"`
+	CommandId         string                  `protobuf:"bytes,5,opt,name=command_id,json=commandId,proto3" json:"command_id,omitempty"`
+	Labels            map[string]string       `protobuf:"bytes,6,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ExecutionStrategy ExecutionStrategy_Value `protobuf:"varint,7,opt,name=execution_strategy,json=executionStrategy,proto3,enum=proxy.ExecutionStrategy_Value" json:"execution_strategy,omitempty"`
+	Phase             ActionPhase_Value       `protobuf:"varint,8,opt,name=phase,proto3,enum=proxy.ActionPhase_Value" json:"phase,omitempty"`
+	CompletionStatus  log.CompletionStatus    `protobuf:"varint,9,opt,name=completion_status,json=completionStatus,proto3,enum=log.CompletionStatus" json:"completion_status,omitempty"`
+	Result            *command.CommandResult  `protobuf:"bytes,10,opt,name=result,proto3" json:"result,omitempty"`
+	DroppedEvents     int64                   `protobuf:"varint,11,opt,name=dropped_events,json=droppedEvents,proto3" json:"dropped_events,omitempty"`
+}
+
+func (x *ActionEvent) Reset() {
+	*x = ActionEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proxy_proxy_proto_msgTypes[5]
+		mi := &file_api_proxy_proxy_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetRecordsResponse) String() string {
+func (x *ActionEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRecordsResponse) ProtoMessage() {}
+func (*ActionEvent) ProtoMessage() {}
 
-func (x *GetRecordsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proxy_proxy_proto_msgTypes[5]
+func (x *ActionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_proxy_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -387,101 +1315,86 @@ func (x *GetRecordsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRecordsResponse.ProtoReflect.Descriptor instead.
-func (*GetRecordsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ActionEvent.ProtoReflect.Descriptor instead.
+func (*ActionEvent) Descriptor() ([]byte, []int) {
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *GetRecordsResponse) GetRecords() []*log.LogRecord {
+func (x *ActionEvent) GetType() ActionEvent_Type {
 	if x != nil {
-		return x.Records
+		return x.Type
 	}
-	return nil
-}
-
-type AddProxyEventsRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	EventTimes map[string]*command.TimeInterval `protobuf:"bytes,1,rep,name=event_times,json=eventTimes,proto3" json:"event_times,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	return ActionEvent_UNSPECIFIED
 }
 
-func (x *AddProxyEventsRequest) Reset() {
-	*x = AddProxyEventsRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proxy_proxy_proto_msgTypes[6]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *ActionEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
 	}
+	return nil
 }
 
-func (x *AddProxyEventsRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ActionEvent) GetExecutionId() string {
+	if x != nil {
+		return x.ExecutionId
+	}
+	return ""
 }
 
-func (*AddProxyEventsRequest) ProtoMessage() {}
-
-func (x *AddProxyEventsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proxy_proxy_proto_msgTypes[6]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *ActionEvent) GetInvocationId() string {
+	if x != nil {
+		return x.InvocationId
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use AddProxyEventsRequest.ProtoReflect.Descriptor instead.
-func (*AddProxyEventsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{6}
+func (x *ActionEvent) GetCommandId() string {
+	if x != nil {
+		return x.CommandId
+	}
+	return ""
 }
 
-func (x *AddProxyEventsRequest) GetEventTimes() map[string]*command.TimeInterval {
+func (x *ActionEvent) GetLabels() map[string]string {
 	if x != nil {
-		return x.EventTimes
+		return x.Labels
 	}
 	return nil
 }
 
-type AddProxyEventsResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *ActionEvent) GetExecutionStrategy() ExecutionStrategy_Value {
+	if x != nil {
+		return x.ExecutionStrategy
+	}
+	return ExecutionStrategy_UNSPECIFIED
 }
 
-func (x *AddProxyEventsResponse) Reset() {
-	*x = AddProxyEventsResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proxy_proxy_proto_msgTypes[7]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *ActionEvent) GetPhase() ActionPhase_Value {
+	if x != nil {
+		return x.Phase
 	}
+	return ActionPhase_UNSPECIFIED
 }
 
-func (x *AddProxyEventsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *ActionEvent) GetCompletionStatus() log.CompletionStatus {
+	if x != nil {
+		return x.CompletionStatus
+	}
+	return log.CompletionStatus(0)
 }
 
-func (*AddProxyEventsResponse) ProtoMessage() {}
-
-func (x *AddProxyEventsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proxy_proxy_proto_msgTypes[7]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *ActionEvent) GetResult() *command.CommandResult {
+	if x != nil {
+		return x.Result
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use AddProxyEventsResponse.ProtoReflect.Descriptor instead.
-func (*AddProxyEventsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{7}
+func (x *ActionEvent) GetDroppedEvents() int64 {
+	if x != nil {
+		return x.DroppedEvents
+	}
+	return 0
 }
 
 type RunRequest struct {
@@ -499,7 +1412,7 @@ type RunRequest struct {
 func (x *RunRequest) Reset() {
 	*x = RunRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proxy_proxy_proto_msgTypes[8]
+		mi := &file_api_proxy_proxy_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -512,7 +1425,7 @@ func (x *RunRequest) String() string {
 func (*RunRequest) ProtoMessage() {}
 
 func (x *RunRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proxy_proxy_proto_msgTypes[8]
+	mi := &file_api_proxy_proxy_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -525,7 +1438,7 @@ func (x *RunRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunRequest.ProtoReflect.Descriptor instead.
 func (*RunRequest) Descriptor() ([]byte, []int) {
-	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{8}
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *RunRequest) GetCommand() *command.Command {
@@ -579,7 +1492,7 @@ type RunResponse struct {
 func (x *RunResponse) Reset() {
 	*x = RunResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proxy_proxy_proto_msgTypes[9]
+		mi := &file_api_proxy_proxy_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -592,7 +1505,7 @@ func (x *RunResponse) String() string {
 func (*RunResponse) ProtoMessage() {}
 
 func (x *RunResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proxy_proxy_proto_msgTypes[9]
+	mi := &file_api_proxy_proxy_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -605,7 +1518,7 @@ func (x *RunResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunResponse.ProtoReflect.Descriptor instead.
 func (*RunResponse) Descriptor() ([]byte, []int) {
-	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{9}
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *RunResponse) GetStdout() []byte {
@@ -663,7 +1576,7 @@ type RemoteFallbackInfo struct {
 func (x *RemoteFallbackInfo) Reset() {
 	*x = RemoteFallbackInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proxy_proxy_proto_msgTypes[10]
+		mi := &file_api_proxy_proxy_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -676,7 +1589,7 @@ func (x *RemoteFallbackInfo) String() string {
 func (*RemoteFallbackInfo) ProtoMessage() {}
 
 func (x *RemoteFallbackInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proxy_proxy_proto_msgTypes[10]
+	mi := &file_api_proxy_proxy_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -689,7 +1602,7 @@ func (x *RemoteFallbackInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoteFallbackInfo.ProtoReflect.Descriptor instead.
 func (*RemoteFallbackInfo) Descriptor() ([]byte, []int) {
-	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{10}
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *RemoteFallbackInfo) GetExitCode() int32 {
@@ -735,7 +1648,7 @@ type ProxyExecutionOptions struct {
 func (x *ProxyExecutionOptions) Reset() {
 	*x = ProxyExecutionOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proxy_proxy_proto_msgTypes[11]
+		mi := &file_api_proxy_proxy_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -748,7 +1661,7 @@ func (x *ProxyExecutionOptions) String() string {
 func (*ProxyExecutionOptions) ProtoMessage() {}
 
 func (x *ProxyExecutionOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proxy_proxy_proto_msgTypes[11]
+	mi := &file_api_proxy_proxy_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -761,7 +1674,7 @@ func (x *ProxyExecutionOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProxyExecutionOptions.ProtoReflect.Descriptor instead.
 func (*ProxyExecutionOptions) Descriptor() ([]byte, []int) {
-	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{11}
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ProxyExecutionOptions) GetExecutionStrategy() ExecutionStrategy_Value {
@@ -857,7 +1770,7 @@ type ExecutionStrategy struct {
 func (x *ExecutionStrategy) Reset() {
 	*x = ExecutionStrategy{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proxy_proxy_proto_msgTypes[12]
+		mi := &file_api_proxy_proxy_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -870,7 +1783,7 @@ func (x *ExecutionStrategy) String() string {
 func (*ExecutionStrategy) ProtoMessage() {}
 
 func (x *ExecutionStrategy) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proxy_proxy_proto_msgTypes[12]
+	mi := &file_api_proxy_proxy_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -883,7 +1796,7 @@ func (x *ExecutionStrategy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecutionStrategy.ProtoReflect.Descriptor instead.
 func (*ExecutionStrategy) Descriptor() ([]byte, []int) {
-	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{12}
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{24}
 }
 
 type LocalExecutionOptions struct {
@@ -891,16 +1804,19 @@ type LocalExecutionOptions struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Platform     LocalExecutionOptions_LocalExecutionPlatform `protobuf:"varint,1,opt,name=platform,proto3,enum=proxy.LocalExecutionOptions_LocalExecutionPlatform" json:"platform,omitempty"`
-	DoNotCache   bool                                         `protobuf:"varint,2,opt,name=do_not_cache,json=doNotCache,proto3" json:"do_not_cache,omitempty"`
-	AcceptCached bool                                         `protobuf:"varint,3,opt,name=accept_cached,json=acceptCached,proto3" json:"accept_cached,omitempty"`
-	Wrapper      string                                       `protobuf:"bytes,4,opt,name=wrapper,proto3" json:"wrapper,omitempty"`
+	Platform         LocalExecutionOptions_LocalExecutionPlatform `protobuf:"varint,1,opt,name=platform,proto3,enum=proxy.LocalExecutionOptions_LocalExecutionPlatform" json:"platform,omitempty"`
+	DoNotCache       bool                                         `protobuf:"varint,2,opt,name=do_not_cache,json=doNotCache,proto3" json:"do_not_cache,omitempty"`
+	AcceptCached     bool                                         `protobuf:"varint,3,opt,name=accept_cached,json=acceptCached,proto3" json:"accept_cached,omitempty"`
+	Wrapper          string                                       `protobuf:"bytes,4,opt,name=wrapper,proto3" json:"wrapper,omitempty"`
+	Cpus             int64                                        `protobuf:"varint,5,opt,name=cpus,proto3" json:"cpus,omitempty"`
+	RamMb            int64                                        `protobuf:"varint,6,opt,name=ram_mb,json=ramMb,proto3" json:"ram_mb,omitempty"`
+	ExecutionTimeout int32                                        `protobuf:"varint,7,opt,name=execution_timeout,json=executionTimeout,proto3" json:"execution_timeout,omitempty"`
 }
 
 func (x *LocalExecutionOptions) Reset() {
 	*x = LocalExecutionOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proxy_proxy_proto_msgTypes[13]
+		mi := &file_api_proxy_proxy_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -913,7 +1829,7 @@ func (x *LocalExecutionOptions) String() string {
 func (*LocalExecutionOptions) ProtoMessage() {}
 
 func (x *LocalExecutionOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proxy_proxy_proto_msgTypes[13]
+	mi := &file_api_proxy_proxy_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -926,7 +1842,7 @@ func (x *LocalExecutionOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LocalExecutionOptions.ProtoReflect.Descriptor instead.
 func (*LocalExecutionOptions) Descriptor() ([]byte, []int) {
-	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{13}
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *LocalExecutionOptions) GetPlatform() LocalExecutionOptions_LocalExecutionPlatform {
@@ -957,6 +1873,27 @@ func (x *LocalExecutionOptions) GetWrapper() string {
 	return ""
 }
 
+func (x *LocalExecutionOptions) GetCpus() int64 {
+	if x != nil {
+		return x.Cpus
+	}
+	return 0
+}
+
+func (x *LocalExecutionOptions) GetRamMb() int64 {
+	if x != nil {
+		return x.RamMb
+	}
+	return 0
+}
+
+func (x *LocalExecutionOptions) GetExecutionTimeout() int32 {
+	if x != nil {
+		return x.ExecutionTimeout
+	}
+	return 0
+}
+
 type RemoteExecutionOptions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -973,7 +1910,7 @@ type RemoteExecutionOptions struct {
 func (x *RemoteExecutionOptions) Reset() {
 	*x = RemoteExecutionOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proxy_proxy_proto_msgTypes[14]
+		mi := &file_api_proxy_proxy_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -986,7 +1923,7 @@ func (x *RemoteExecutionOptions) String() string {
 func (*RemoteExecutionOptions) ProtoMessage() {}
 
 func (x *RemoteExecutionOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proxy_proxy_proto_msgTypes[14]
+	mi := &file_api_proxy_proxy_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -999,7 +1936,7 @@ func (x *RemoteExecutionOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoteExecutionOptions.ProtoReflect.Descriptor instead.
 func (*RemoteExecutionOptions) Descriptor() ([]byte, []int) {
-	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{14}
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *RemoteExecutionOptions) GetAcceptCached() bool {
@@ -1056,7 +1993,7 @@ type Metadata struct {
 func (x *Metadata) Reset() {
 	*x = Metadata{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proxy_proxy_proto_msgTypes[15]
+		mi := &file_api_proxy_proxy_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1069,7 +2006,7 @@ func (x *Metadata) String() string {
 func (*Metadata) ProtoMessage() {}
 
 func (x *Metadata) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proxy_proxy_proto_msgTypes[15]
+	mi := &file_api_proxy_proxy_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1082,7 +2019,7 @@ func (x *Metadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
 func (*Metadata) Descriptor() ([]byte, []int) {
-	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{15}
+	return file_api_proxy_proxy_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *Metadata) GetEventTimes() map[string]*command.TimeInterval {
@@ -1108,218 +2045,427 @@ var file_api_proxy_proxy_proto_rawDesc = []byte{
 	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11, 0x61, 0x70,
 	0x69, 0x2f, 0x6c, 0x6f, 0x67, 0x2f, 0x6c, 0x6f, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
 	0x15, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x73,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x11, 0x0a, 0x0f, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f,
-	0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x36, 0x0a, 0x10, 0x53, 0x68, 0x75,
-	0x74, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a,
-	0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x73,
-	0x74, 0x61, 0x74, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74,
-	0x73, 0x22, 0x19, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x75,
-	0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x8f, 0x02, 0x0a,
-	0x18, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72,
-	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6f, 0x0a, 0x16, 0x63, 0x6f, 0x6d,
-	0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74,
-	0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x70, 0x72, 0x6f, 0x78,
-	0x79, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x75, 0x6d, 0x6d, 0x61,
-	0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6c,
-	0x65, 0x74, 0x65, 0x64, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x52, 0x14, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x75,
-	0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0e, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x41, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x71, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x03, 0x71, 0x70, 0x73, 0x1a, 0x47, 0x0a, 0x19, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
-	0x65, 0x64, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x13,
-	0x0a, 0x11, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x22, 0x3e, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x07, 0x72, 0x65, 0x63,
-	0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6c, 0x6f, 0x67,
-	0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x73, 0x22, 0xb8, 0x01, 0x0a, 0x15, 0x41, 0x64, 0x64, 0x50, 0x72, 0x6f, 0x78, 0x79,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4d, 0x0a,
-	0x0b, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x41, 0x64, 0x64, 0x50, 0x72,
-	0x6f, 0x78, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x52, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x1a, 0x50, 0x0a, 0x0f,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x27, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x11, 0x2e, 0x63, 0x6d, 0x64, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72,
-	0x76, 0x61, 0x6c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x18,
-	0x0a, 0x16, 0x41, 0x64, 0x64, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xc9, 0x02, 0x0a, 0x0a, 0x52, 0x75, 0x6e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61,
-	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x63, 0x6d, 0x64, 0x2e, 0x43,
-	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12,
-	0x35, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06,
-	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x49, 0x0a, 0x11, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74,
-	0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x45,
-	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
-	0x10, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x12, 0x2b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x4d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x29,
-	0x0a, 0x10, 0x74, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x6e, 0x70, 0x75,
-	0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x74, 0x6f, 0x6f, 0x6c, 0x63, 0x68,
-	0x61, 0x69, 0x6e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62,
-	0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x39, 0x0a, 0x14, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x21, 0x0a, 0x0c, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x22, 0x17, 0x0a, 0x15, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x43, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x11, 0x0a, 0x0f, 0x53,
+	0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x15,
+	0x0a, 0x13, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xab, 0x01, 0x0a, 0x14, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52,
+	0x0a, 0x0d, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x52, 0x65,
+	0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x46, 0x6c, 0x61,
+	0x67, 0x73, 0x1a, 0x3f, 0x0a, 0x11, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x46, 0x6c, 0x61,
+	0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x36, 0x0a, 0x10, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0x3e, 0x0a, 0x17, 0x47,
+	0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x76, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69,
+	0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0xb6, 0x02, 0x0a, 0x18,
+	0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6f, 0x0a, 0x16, 0x63, 0x6f, 0x6d, 0x70,
+	0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79,
+	0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x14, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x75, 0x6e,
+	0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0e, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x41, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x71, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x03, 0x71, 0x70, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x69, 0x6e,
+	0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x73, 0x1a, 0x47, 0x0a, 0x19, 0x43,
+	0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x22, 0x88, 0x02, 0x0a, 0x0b, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x74,
-	0x64, 0x65, 0x72, 0x72, 0x12, 0x2a, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x63, 0x6d, 0x64, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
-	0x6e, 0x64, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x12, 0x21, 0x0a, 0x0c, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
-	0x6e, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x6f,
-	0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6c, 0x6f, 0x67, 0x2e, 0x4c, 0x6f,
-	0x67, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x09, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c,
-	0x6f, 0x67, 0x12, 0x4b, 0x0a, 0x14, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x66, 0x61, 0x6c,
-	0x6c, 0x62, 0x61, 0x63, 0x6b, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x46,
-	0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x12, 0x72, 0x65, 0x6d,
-	0x6f, 0x74, 0x65, 0x46, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x22,
-	0x61, 0x0a, 0x12, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x46, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63,
-	0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f,
-	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f,
-	0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
-	0x64, 0x65, 0x72, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65,
-	0x72, 0x72, 0x22, 0xb5, 0x05, 0x0a, 0x15, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x45, 0x78, 0x65, 0x63,
-	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x4d, 0x0a, 0x12,
-	0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65,
-	0x67, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79,
-	0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65,
-	0x67, 0x79, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x11, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74,
-	0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x57, 0x0a, 0x18, 0x72,
-	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
-	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
-	0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x45, 0x78, 0x65, 0x63,
-	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x16, 0x72, 0x65,
-	0x6d, 0x6f, 0x74, 0x65, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x54, 0x0a, 0x17, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x65, 0x78,
-	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x4c, 0x6f,
-	0x63, 0x61, 0x6c, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x52, 0x15, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
-	0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6f,
-	0x6d, 0x70, 0x61, 0x72, 0x65, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x57,
-	0x69, 0x74, 0x68, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x12, 0x39, 0x0a, 0x19, 0x6e, 0x75, 0x6d, 0x5f,
-	0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x5f, 0x69, 0x66, 0x5f, 0x6d, 0x69, 0x73, 0x6d, 0x61,
-	0x74, 0x63, 0x68, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x16, 0x6e, 0x75, 0x6d,
-	0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x49, 0x66, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63,
-	0x68, 0x65, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x6e, 0x75, 0x6d, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
-	0x5f, 0x72, 0x65, 0x72, 0x75, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6e,
-	0x75, 0x6d, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x52, 0x65, 0x72, 0x75, 0x6e, 0x73, 0x12, 0x2a, 0x0a,
-	0x11, 0x6e, 0x75, 0x6d, 0x5f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x72, 0x75,
-	0x6e, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x6e, 0x75, 0x6d, 0x52, 0x65, 0x6d,
-	0x6f, 0x74, 0x65, 0x52, 0x65, 0x72, 0x75, 0x6e, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6c, 0x6f, 0x67,
-	0x5f, 0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x0e, 0x6c, 0x6f, 0x67, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65,
-	0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x6f, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10,
-	0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x67,
-	0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x69, 0x6d,
-	0x65, 0x6f, 0x75, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x6c,
-	0x69, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x36, 0x0a, 0x17, 0x65,
-	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x61, 0x74, 0x6f, 0x6d, 0x69, 0x63, 0x5f, 0x64, 0x6f, 0x77,
-	0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x65, 0x6e,
-	0x61, 0x62, 0x6c, 0x65, 0x41, 0x74, 0x6f, 0x6d, 0x69, 0x63, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
-	0x61, 0x64, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f,
-	0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x6f, 0x77,
-	0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x67, 0x65, 0x78, 0x22, 0x6b, 0x0a, 0x11, 0x45, 0x78,
-	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x22,
-	0x56, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50,
-	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x4c, 0x4f, 0x43,
-	0x41, 0x4c, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x45, 0x4d, 0x4f, 0x54, 0x45, 0x10, 0x02,
-	0x12, 0x19, 0x0a, 0x15, 0x52, 0x45, 0x4d, 0x4f, 0x54, 0x45, 0x5f, 0x4c, 0x4f, 0x43, 0x41, 0x4c,
-	0x5f, 0x46, 0x41, 0x4c, 0x4c, 0x42, 0x41, 0x43, 0x4b, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x52,
-	0x41, 0x43, 0x49, 0x4e, 0x47, 0x10, 0x04, 0x22, 0x80, 0x02, 0x0a, 0x15, 0x4c, 0x6f, 0x63, 0x61,
-	0x6c, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x12, 0x4f, 0x0a, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x33, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x4c, 0x6f, 0x63, 0x61,
-	0x6c, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e,
-	0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f,
-	0x72, 0x6d, 0x12, 0x20, 0x0a, 0x0c, 0x64, 0x6f, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x63, 0x61, 0x63,
-	0x68, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x6f, 0x4e, 0x6f, 0x74, 0x43,
-	0x61, 0x63, 0x68, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x5f, 0x63,
-	0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x61, 0x63, 0x63,
-	0x65, 0x70, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x72, 0x61,
-	0x70, 0x70, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x72, 0x61, 0x70,
-	0x70, 0x65, 0x72, 0x22, 0x35, 0x0a, 0x16, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x45, 0x78, 0x65, 0x63,
-	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x0f, 0x0a,
-	0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a,
-	0x0a, 0x06, 0x44, 0x4f, 0x43, 0x4b, 0x45, 0x52, 0x10, 0x01, 0x22, 0xab, 0x02, 0x0a, 0x16, 0x52,
-	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x5f,
-	0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x61, 0x63,
-	0x63, 0x65, 0x70, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0c, 0x64, 0x6f,
-	0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x0a, 0x64, 0x6f, 0x4e, 0x6f, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x29, 0x0a, 0x10,
-	0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64,
-	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x72, 0x61, 0x70, 0x70,
-	0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65,
-	0x72, 0x12, 0x38, 0x0a, 0x18, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x69, 0x7a,
-	0x65, 0x5f, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x16, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x69, 0x7a,
-	0x65, 0x57, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x12, 0x45, 0x0a, 0x1f, 0x70,
-	0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x5f, 0x75, 0x6e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x64, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x6d, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x1c, 0x70, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x55, 0x6e,
-	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4d, 0x74, 0x69,
-	0x6d, 0x65, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x22, 0xc0, 0x01, 0x0a, 0x08, 0x4d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x40, 0x0a, 0x0b, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74,
-	0x69, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f,
-	0x78, 0x79, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x65, 0x76, 0x65,
-	0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x6e, 0x76, 0x69, 0x72,
-	0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x6e,
-	0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0x50, 0x0a, 0x0f, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x27,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
-	0x63, 0x6d, 0x64, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0x80, 0x01, 0x0a, 0x08,
-	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x12, 0x35, 0x0a, 0x0a, 0x52, 0x75, 0x6e, 0x43,
-	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x52,
-	0x75, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x78,
-	0x79, 0x2e, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x3d, 0x0a, 0x08, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x16, 0x2e, 0x70, 0x72,
-	0x6f, 0x78, 0x79, 0x2e, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x53, 0x68, 0x75, 0x74,
-	0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x9d,
-	0x01, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x43, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52,
-	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x47,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x40, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x76, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x40, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x73, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0xab, 0x04, 0x0a, 0x11, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x46,
+	0x0a, 0x13, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x6c, 0x6f,
+	0x67, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x12, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x12, 0x3c, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x47,
 	0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a,
-	0x0e, 0x41, 0x64, 0x64, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12,
-	0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x41, 0x64, 0x64, 0x50, 0x72, 0x6f, 0x78, 0x79,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
+	0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x76,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x43, 0x0a, 0x0f, 0x63, 0x6f, 0x6d,
+	0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e,
+	0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x45,
+	0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x65, 0x66, 0x6f,
+	0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x42,
+	0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x3c, 0x0a, 0x0c, 0x6d, 0x69, 0x6e, 0x5f, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x6f, 0x73, 0x74, 0x5f, 0x72, 0x65, 0x63, 0x65,
+	0x6e, 0x74, 0x5f, 0x66, 0x69, 0x72, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f,
+	0x6d, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x63, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x72, 0x73, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x1a, 0x39, 0x0a, 0x0b, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x66, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x07,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x6c, 0x6f, 0x67, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x72,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xb8,
+	0x01, 0x0a, 0x15, 0x41, 0x64, 0x64, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4d, 0x0a, 0x0b, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e,
 	0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x41, 0x64, 0x64, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x5f,
-	0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x55, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1e, 0x2e, 0x70,
-	0x72, 0x6f, 0x78, 0x79, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x75,
-	0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70,
-	0x72, 0x6f, 0x78, 0x79, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x75,
-	0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42,
-	0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x61,
-	0x7a, 0x65, 0x6c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x2f, 0x72, 0x65, 0x63, 0x6c, 0x69, 0x65, 0x6e,
-	0x74, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x1a, 0x50, 0x0a, 0x0f, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x27, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x63, 0x6d,
+	0x64, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x18, 0x0a, 0x16, 0x41, 0x64, 0x64,
+	0x50, 0x72, 0x6f, 0x78, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x3a, 0x0a, 0x13, 0x57, 0x61, 0x74, 0x63, 0x68, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e,
+	0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x69, 0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22,
+	0x60, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x69,
+	0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x22, 0xeb, 0x03, 0x0a, 0x0d, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x76, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69,
+	0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x49, 0x64, 0x12, 0x38, 0x0a, 0x06, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x72, 0x6f,
+	0x78, 0x79, 0x2e, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x12, 0x4d, 0x0a, 0x12, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x11, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x61, 0x74,
+	0x65, 0x67, 0x79, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2e,
+	0x0a, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e,
+	0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x68, 0x61, 0x73,
+	0x65, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12, 0x44,
+	0x0a, 0x10, 0x70, 0x68, 0x61, 0x73, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0e, 0x70, 0x68, 0x61, 0x73, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x54, 0x69, 0x6d, 0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x9e, 0x01, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a,
+	0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x23, 0x0a,
+	0x0d, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x41, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x71, 0x75, 0x65, 0x75,
+	0x65, 0x5f, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10,
+	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x51, 0x75, 0x65, 0x75, 0x65, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68,
+	0x22, 0x9c, 0x01, 0x0a, 0x0b, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x68, 0x61, 0x73, 0x65,
+	0x22, 0x8c, 0x01, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x49,
+	0x4e, 0x50, 0x55, 0x54, 0x5f, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x10,
+	0x01, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x41, 0x43, 0x48, 0x45, 0x5f, 0x4c, 0x4f, 0x4f, 0x4b, 0x55,
+	0x50, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x55, 0x50, 0x4c, 0x4f, 0x41, 0x44, 0x5f, 0x41, 0x4e,
+	0x44, 0x5f, 0x45, 0x58, 0x45, 0x43, 0x55, 0x54, 0x45, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x44,
+	0x4f, 0x57, 0x4e, 0x4c, 0x4f, 0x41, 0x44, 0x10, 0x04, 0x12, 0x0f, 0x0a, 0x0b, 0x4c, 0x4f, 0x43,
+	0x41, 0x4c, 0x5f, 0x51, 0x55, 0x45, 0x55, 0x45, 0x10, 0x05, 0x12, 0x13, 0x0a, 0x0f, 0x4c, 0x4f,
+	0x43, 0x41, 0x4c, 0x5f, 0x45, 0x58, 0x45, 0x43, 0x55, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x06, 0x22,
+	0xa2, 0x05, 0x0a, 0x0b, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12,
+	0x2b, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e,
+	0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2e, 0x0a, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12,
+	0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x5f,
+	0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
+	0x64, 0x49, 0x64, 0x12, 0x36, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x4d, 0x0a, 0x12, 0x65,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67,
+	0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e,
+	0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67,
+	0x79, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x11, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x2e, 0x0a, 0x05, 0x70, 0x68,
+	0x61, 0x73, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x78,
+	0x79, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x68, 0x61, 0x73, 0x65, 0x2e, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x11, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x6c, 0x6f, 0x67, 0x2e, 0x43, 0x6f, 0x6d, 0x70,
+	0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x10, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2a,
+	0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x63, 0x6d, 0x64, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x72,
+	0x6f, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0d, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x46, 0x0a, 0x04,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x54, 0x41, 0x52, 0x54, 0x45, 0x44,
+	0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x43, 0x48, 0x41, 0x4e,
+	0x47, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54,
+	0x45, 0x44, 0x10, 0x03, 0x22, 0xc9, 0x02, 0x0a, 0x0a, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x63, 0x6d, 0x64, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x35, 0x0a, 0x06, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72,
+	0x6f, 0x78, 0x79, 0x2e, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x12, 0x49, 0x0a, 0x11, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x45, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x10, 0x65, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2b, 0x0a,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x6f,
+	0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x74, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x49,
+	0x6e, 0x70, 0x75, 0x74, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x88, 0x02, 0x0a, 0x0b, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x65,
+	0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72,
+	0x12, 0x2a, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x63, 0x6d, 0x64, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x21, 0x0a, 0x0c,
+	0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12,
+	0x2d, 0x0a, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x6f, 0x67, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x6c, 0x6f, 0x67, 0x2e, 0x4c, 0x6f, 0x67, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x52, 0x09, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x67, 0x12, 0x4b,
+	0x0a, 0x14, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x66, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63,
+	0x6b, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70,
+	0x72, 0x6f, 0x78, 0x79, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x46, 0x61, 0x6c, 0x6c, 0x62,
+	0x61, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x12, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x46,
+	0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x61, 0x0a, 0x12, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x46, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x22, 0xb5,
+	0x05, 0x0a, 0x15, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x4d, 0x0a, 0x12, 0x65, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x45, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x2e, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x11, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x12, 0x57, 0x0a, 0x18, 0x72, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x5f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x78,
+	0x79, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x16, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x54, 0x0a, 0x17, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x45,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x15, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72,
+	0x65, 0x5f, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x57, 0x69, 0x74, 0x68, 0x4c,
+	0x6f, 0x63, 0x61, 0x6c, 0x12, 0x39, 0x0a, 0x19, 0x6e, 0x75, 0x6d, 0x5f, 0x72, 0x65, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x5f, 0x69, 0x66, 0x5f, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x16, 0x6e, 0x75, 0x6d, 0x52, 0x65, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x49, 0x66, 0x4d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64, 0x12,
+	0x28, 0x0a, 0x10, 0x6e, 0x75, 0x6d, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x72, 0x65, 0x72,
+	0x75, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6e, 0x75, 0x6d, 0x4c, 0x6f,
+	0x63, 0x61, 0x6c, 0x52, 0x65, 0x72, 0x75, 0x6e, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x6e, 0x75, 0x6d,
+	0x5f, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x72, 0x75, 0x6e, 0x73, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x6e, 0x75, 0x6d, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x52,
+	0x65, 0x72, 0x75, 0x6e, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x6c, 0x6f, 0x67, 0x5f, 0x65, 0x6e, 0x76,
+	0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e,
+	0x6c, 0x6f, 0x67, 0x45, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x2c,
+	0x0a, 0x12, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x6c, 0x6f, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x69, 0x6e, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x67, 0x12, 0x29, 0x0a, 0x10,
+	0x72, 0x65, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x36, 0x0a, 0x17, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x5f, 0x61, 0x74, 0x6f, 0x6d, 0x69, 0x63, 0x5f, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
+	0x64, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x41, 0x74, 0x6f, 0x6d, 0x69, 0x63, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12,
+	0x25, 0x0a, 0x0e, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x72, 0x65, 0x67, 0x65,
+	0x78, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
+	0x64, 0x52, 0x65, 0x67, 0x65, 0x78, 0x22, 0x6b, 0x0a, 0x11, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x74, 0x72, 0x61, 0x74, 0x65, 0x67, 0x79, 0x22, 0x56, 0x0a, 0x05, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x4c, 0x4f, 0x43, 0x41, 0x4c, 0x10, 0x01,
+	0x12, 0x0a, 0x0a, 0x06, 0x52, 0x45, 0x4d, 0x4f, 0x54, 0x45, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15,
+	0x52, 0x45, 0x4d, 0x4f, 0x54, 0x45, 0x5f, 0x4c, 0x4f, 0x43, 0x41, 0x4c, 0x5f, 0x46, 0x41, 0x4c,
+	0x4c, 0x42, 0x41, 0x43, 0x4b, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x41, 0x43, 0x49, 0x4e,
+	0x47, 0x10, 0x04, 0x22, 0xe5, 0x02, 0x0a, 0x15, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x45, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x4f, 0x0a,
+	0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x33, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x45, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4c, 0x6f,
+	0x63, 0x61, 0x6c, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6c, 0x61, 0x74,
+	0x66, 0x6f, 0x72, 0x6d, 0x52, 0x08, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x20,
+	0x0a, 0x0c, 0x64, 0x6f, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x6f, 0x4e, 0x6f, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x43,
+	0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x12,
+	0x12, 0x0a, 0x04, 0x63, 0x70, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x63,
+	0x70, 0x75, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x72, 0x61, 0x6d, 0x5f, 0x6d, 0x62, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x72, 0x61, 0x6d, 0x4d, 0x62, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e,
+	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0x42, 0x0a, 0x16, 0x4c, 0x6f, 0x63, 0x61, 0x6c,
+	0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72,
+	0x6d, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x4f, 0x43, 0x4b, 0x45, 0x52, 0x10, 0x01, 0x12, 0x0b,
+	0x0a, 0x07, 0x53, 0x41, 0x4e, 0x44, 0x42, 0x4f, 0x58, 0x10, 0x02, 0x22, 0xab, 0x02, 0x0a, 0x16,
+	0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74,
+	0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x61,
+	0x63, 0x63, 0x65, 0x70, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0c, 0x64,
+	0x6f, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x64, 0x6f, 0x4e, 0x6f, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x29, 0x0a,
+	0x10, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61,
+	0x64, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x72, 0x61, 0x70,
+	0x70, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x72, 0x61, 0x70, 0x70,
+	0x65, 0x72, 0x12, 0x38, 0x0a, 0x18, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x69,
+	0x7a, 0x65, 0x5f, 0x77, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x69, 0x72, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x63, 0x61, 0x6e, 0x6f, 0x6e, 0x69, 0x63, 0x61, 0x6c, 0x69,
+	0x7a, 0x65, 0x57, 0x6f, 0x72, 0x6b, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x72, 0x12, 0x45, 0x0a, 0x1f,
+	0x70, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x5f, 0x75, 0x6e, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x6d, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1c, 0x70, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x55,
+	0x6e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4d, 0x74,
+	0x69, 0x6d, 0x65, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x22, 0xc0, 0x01, 0x0a, 0x08, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x40, 0x0a, 0x0b, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72,
+	0x6f, 0x78, 0x79, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x65, 0x6e, 0x76, 0x69,
+	0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x65,
+	0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0x50, 0x0a, 0x0f, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x27, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x63, 0x6d, 0x64, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61,
+	0x6c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0xd8, 0x02, 0x0a,
+	0x08, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x12, 0x35, 0x0a, 0x0a, 0x52, 0x75, 0x6e,
+	0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e,
+	0x52, 0x75, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f,
+	0x78, 0x79, 0x2e, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x3d, 0x0a, 0x10, 0x52, 0x75, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x12, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x52, 0x75, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e,
+	0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12,
+	0x4c, 0x0a, 0x0d, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x43,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e,
+	0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x43, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a,
+	0x08, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x78,
+	0x79, 0x2e, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f,
+	0x77, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0c,
+	0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1a, 0x2e, 0x70,
+	0x72, 0x6f, 0x78, 0x79, 0x2e, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79,
+	0x2e, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0xbb, 0x02, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x43, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12,
+	0x18, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x78,
+	0x79, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x50, 0x72, 0x6f,
+	0x78, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79,
+	0x2e, 0x41, 0x64, 0x64, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x41,
+	0x64, 0x64, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x58, 0x0a, 0x11, 0x47,
+	0x65, 0x74, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x75, 0x6e, 0x6e,
+	0x69, 0x6e, 0x67, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x75, 0x6e,
+	0x6e, 0x69, 0x6e, 0x67, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0xbc, 0x01, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x55, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x75, 0x6d,
+	0x6d, 0x61, 0x72, 0x79, 0x12, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x47, 0x65, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x47, 0x65, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5b, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x49, 0x6e,
+	0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x20, 0x2e,
+	0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x21, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x6e, 0x76, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x62, 0x61, 0x7a, 0x65, 0x6c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x2f, 0x72, 0x65,
+	0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x78, 0x79,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1334,71 +2480,124 @@ func file_api_proxy_proxy_proto_rawDescGZIP() []byte {
 	return file_api_proxy_proxy_proto_rawDescData
 }
 
-var file_api_proxy_proxy_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_proxy_proxy_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_api_proxy_proxy_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_api_proxy_proxy_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_api_proxy_proxy_proto_goTypes = []interface{}{
-	(ExecutionStrategy_Value)(0),                      // 0: proxy.ExecutionStrategy.Value
-	(LocalExecutionOptions_LocalExecutionPlatform)(0), // 1: proxy.LocalExecutionOptions.LocalExecutionPlatform
-	(*ShutdownRequest)(nil),                           // 2: proxy.ShutdownRequest
-	(*ShutdownResponse)(nil),                          // 3: proxy.ShutdownResponse
-	(*GetStatusSummaryRequest)(nil),                   // 4: proxy.GetStatusSummaryRequest
-	(*GetStatusSummaryResponse)(nil),                  // 5: proxy.GetStatusSummaryResponse
-	(*GetRecordsRequest)(nil),                         // 6: proxy.GetRecordsRequest
-	(*GetRecordsResponse)(nil),                        // 7: proxy.GetRecordsResponse
-	(*AddProxyEventsRequest)(nil),                     // 8: proxy.AddProxyEventsRequest
-	(*AddProxyEventsResponse)(nil),                    // 9: proxy.AddProxyEventsResponse
-	(*RunRequest)(nil),                                // 10: proxy.RunRequest
-	(*RunResponse)(nil),                               // 11: proxy.RunResponse
-	(*RemoteFallbackInfo)(nil),                        // 12: proxy.RemoteFallbackInfo
-	(*ProxyExecutionOptions)(nil),                     // 13: proxy.ProxyExecutionOptions
-	(*ExecutionStrategy)(nil),                         // 14: proxy.ExecutionStrategy
-	(*LocalExecutionOptions)(nil),                     // 15: proxy.LocalExecutionOptions
-	(*RemoteExecutionOptions)(nil),                    // 16: proxy.RemoteExecutionOptions
-	(*Metadata)(nil),                                  // 17: proxy.Metadata
-	nil,                                               // 18: proxy.GetStatusSummaryResponse.CompletedActionStatsEntry
-	nil,                                               // 19: proxy.AddProxyEventsRequest.EventTimesEntry
-	nil,                                               // 20: proxy.RunRequest.LabelsEntry
-	nil,                                               // 21: proxy.Metadata.EventTimesEntry
-	(*stats.Stats)(nil),                               // 22: stats.Stats
-	(*log.LogRecord)(nil),                             // 23: log.LogRecord
-	(*command.Command)(nil),                           // 24: cmd.Command
-	(*command.CommandResult)(nil),                     // 25: cmd.CommandResult
-	(*command.TimeInterval)(nil),                      // 26: cmd.TimeInterval
+	(ActionPhase_Value)(0),                            // 0: proxy.ActionPhase.Value
+	(ActionEvent_Type)(0),                             // 1: proxy.ActionEvent.Type
+	(ExecutionStrategy_Value)(0),                      // 2: proxy.ExecutionStrategy.Value
+	(LocalExecutionOptions_LocalExecutionPlatform)(0), // 3: proxy.LocalExecutionOptions.LocalExecutionPlatform
+	(*CancelCommandRequest)(nil),                      // 4: proxy.CancelCommandRequest
+	(*CancelCommandResponse)(nil),                     // 5: proxy.CancelCommandResponse
+	(*ShutdownRequest)(nil),                           // 6: proxy.ShutdownRequest
+	(*ReloadConfigRequest)(nil),                       // 7: proxy.ReloadConfigRequest
+	(*ReloadConfigResponse)(nil),                      // 8: proxy.ReloadConfigResponse
+	(*ShutdownResponse)(nil),                          // 9: proxy.ShutdownResponse
+	(*GetStatusSummaryRequest)(nil),                   // 10: proxy.GetStatusSummaryRequest
+	(*GetStatusSummaryResponse)(nil),                  // 11: proxy.GetStatusSummaryResponse
+	(*GetInvocationStatsRequest)(nil),                 // 12: proxy.GetInvocationStatsRequest
+	(*GetInvocationStatsResponse)(nil),                // 13: proxy.GetInvocationStatsResponse
+	(*GetRecordsRequest)(nil),                         // 14: proxy.GetRecordsRequest
+	(*GetRecordsResponse)(nil),                        // 15: proxy.GetRecordsResponse
+	(*AddProxyEventsRequest)(nil),                     // 16: proxy.AddProxyEventsRequest
+	(*AddProxyEventsResponse)(nil),                    // 17: proxy.AddProxyEventsResponse
+	(*WatchActionsRequest)(nil),                       // 18: proxy.WatchActionsRequest
+	(*GetRunningActionsRequest)(nil),                  // 19: proxy.GetRunningActionsRequest
+	(*RunningAction)(nil),                             // 20: proxy.RunningAction
+	(*GetRunningActionsResponse)(nil),                 // 21: proxy.GetRunningActionsResponse
+	(*ActionPhase)(nil),                               // 22: proxy.ActionPhase
+	(*ActionEvent)(nil),                               // 23: proxy.ActionEvent
+	(*RunRequest)(nil),                                // 24: proxy.RunRequest
+	(*RunResponse)(nil),                               // 25: proxy.RunResponse
+	(*RemoteFallbackInfo)(nil),                        // 26: proxy.RemoteFallbackInfo
+	(*ProxyExecutionOptions)(nil),                     // 27: proxy.ProxyExecutionOptions
+	(*ExecutionStrategy)(nil),                         // 28: proxy.ExecutionStrategy
+	(*LocalExecutionOptions)(nil),                     // 29: proxy.LocalExecutionOptions
+	(*RemoteExecutionOptions)(nil),                    // 30: proxy.RemoteExecutionOptions
+	(*Metadata)(nil),                                  // 31: proxy.Metadata
+	nil,                                               // 32: proxy.ReloadConfigResponse.ChangedFlagsEntry
+	nil,                                               // 33: proxy.GetStatusSummaryResponse.CompletedActionStatsEntry
+	nil,                                               // 34: proxy.GetRecordsRequest.LabelsEntry
+	nil,                                               // 35: proxy.AddProxyEventsRequest.EventTimesEntry
+	nil,                                               // 36: proxy.RunningAction.LabelsEntry
+	nil,                                               // 37: proxy.ActionEvent.LabelsEntry
+	nil,                                               // 38: proxy.RunRequest.LabelsEntry
+	nil,                                               // 39: proxy.Metadata.EventTimesEntry
+	(*stats.Stats)(nil),                               // 40: stats.Stats
+	(log.CompletionStatus)(0),                         // 41: log.CompletionStatus
+	(*timestamppb.Timestamp)(nil),                     // 42: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),                       // 43: google.protobuf.Duration
+	(*log.LogRecord)(nil),                             // 44: log.LogRecord
+	(*command.CommandResult)(nil),                     // 45: cmd.CommandResult
+	(*command.Command)(nil),                           // 46: cmd.Command
+	(*command.TimeInterval)(nil),                      // 47: cmd.TimeInterval
 }
 var file_api_proxy_proxy_proto_depIdxs = []int32{
-	22, // 0: proxy.ShutdownResponse.stats:type_name -> stats.Stats
-	18, // 1: proxy.GetStatusSummaryResponse.completed_action_stats:type_name -> proxy.GetStatusSummaryResponse.CompletedActionStatsEntry
-	23, // 2: proxy.GetRecordsResponse.records:type_name -> log.LogRecord
-	19, // 3: proxy.AddProxyEventsRequest.event_times:type_name -> proxy.AddProxyEventsRequest.EventTimesEntry
-	24, // 4: proxy.RunRequest.command:type_name -> cmd.Command
-	20, // 5: proxy.RunRequest.labels:type_name -> proxy.RunRequest.LabelsEntry
-	13, // 6: proxy.RunRequest.execution_options:type_name -> proxy.ProxyExecutionOptions
-	17, // 7: proxy.RunRequest.metadata:type_name -> proxy.Metadata
-	25, // 8: proxy.RunResponse.result:type_name -> cmd.CommandResult
-	23, // 9: proxy.RunResponse.action_log:type_name -> log.LogRecord
-	12, // 10: proxy.RunResponse.remote_fallback_info:type_name -> proxy.RemoteFallbackInfo
-	0,  // 11: proxy.ProxyExecutionOptions.execution_strategy:type_name -> proxy.ExecutionStrategy.Value
-	16, // 12: proxy.ProxyExecutionOptions.remote_execution_options:type_name -> proxy.RemoteExecutionOptions
-	15, // 13: proxy.ProxyExecutionOptions.local_execution_options:type_name -> proxy.LocalExecutionOptions
-	1,  // 14: proxy.LocalExecutionOptions.platform:type_name -> proxy.LocalExecutionOptions.LocalExecutionPlatform
-	21, // 15: proxy.Metadata.event_times:type_name -> proxy.Metadata.EventTimesEntry
-	26, // 16: proxy.AddProxyEventsRequest.EventTimesEntry.value:type_name -> cmd.TimeInterval
-	26, // 17: proxy.Metadata.EventTimesEntry.value:type_name -> cmd.TimeInterval
-	10, // 18: proxy.Commands.RunCommand:input_type -> proxy.RunRequest
-	2,  // 19: proxy.Commands.Shutdown:input_type -> proxy.ShutdownRequest
-	6,  // 20: proxy.Stats.GetRecords:input_type -> proxy.GetRecordsRequest
-	8,  // 21: proxy.Stats.AddProxyEvents:input_type -> proxy.AddProxyEventsRequest
-	4,  // 22: proxy.Status.GetStatusSummary:input_type -> proxy.GetStatusSummaryRequest
-	11, // 23: proxy.Commands.RunCommand:output_type -> proxy.RunResponse
-	3,  // 24: proxy.Commands.Shutdown:output_type -> proxy.ShutdownResponse
-	7,  // 25: proxy.Stats.GetRecords:output_type -> proxy.GetRecordsResponse
-	9,  // 26: proxy.Stats.AddProxyEvents:output_type -> proxy.AddProxyEventsResponse
-	5,  // 27: proxy.Status.GetStatusSummary:output_type -> proxy.GetStatusSummaryResponse
-	23, // [23:28] is the sub-list for method output_type
-	18, // [18:23] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	32, // 0: proxy.ReloadConfigResponse.changed_flags:type_name -> proxy.ReloadConfigResponse.ChangedFlagsEntry
+	40, // 1: proxy.ShutdownResponse.stats:type_name -> stats.Stats
+	33, // 2: proxy.GetStatusSummaryResponse.completed_action_stats:type_name -> proxy.GetStatusSummaryResponse.CompletedActionStatsEntry
+	40, // 3: proxy.GetInvocationStatsResponse.stats:type_name -> stats.Stats
+	41, // 4: proxy.GetRecordsRequest.completion_statuses:type_name -> log.CompletionStatus
+	34, // 5: proxy.GetRecordsRequest.labels:type_name -> proxy.GetRecordsRequest.LabelsEntry
+	42, // 6: proxy.GetRecordsRequest.completed_after:type_name -> google.protobuf.Timestamp
+	42, // 7: proxy.GetRecordsRequest.completed_before:type_name -> google.protobuf.Timestamp
+	43, // 8: proxy.GetRecordsRequest.min_duration:type_name -> google.protobuf.Duration
+	44, // 9: proxy.GetRecordsResponse.records:type_name -> log.LogRecord
+	35, // 10: proxy.AddProxyEventsRequest.event_times:type_name -> proxy.AddProxyEventsRequest.EventTimesEntry
+	36, // 11: proxy.RunningAction.labels:type_name -> proxy.RunningAction.LabelsEntry
+	2,  // 12: proxy.RunningAction.execution_strategy:type_name -> proxy.ExecutionStrategy.Value
+	42, // 13: proxy.RunningAction.start_time:type_name -> google.protobuf.Timestamp
+	0,  // 14: proxy.RunningAction.phase:type_name -> proxy.ActionPhase.Value
+	42, // 15: proxy.RunningAction.phase_start_time:type_name -> google.protobuf.Timestamp
+	20, // 16: proxy.GetRunningActionsResponse.actions:type_name -> proxy.RunningAction
+	1,  // 17: proxy.ActionEvent.type:type_name -> proxy.ActionEvent.Type
+	42, // 18: proxy.ActionEvent.time:type_name -> google.protobuf.Timestamp
+	37, // 19: proxy.ActionEvent.labels:type_name -> proxy.ActionEvent.LabelsEntry
+	2,  // 20: proxy.ActionEvent.execution_strategy:type_name -> proxy.ExecutionStrategy.Value
+	0,  // 21: proxy.ActionEvent.phase:type_name -> proxy.ActionPhase.Value
+	41, // 22: proxy.ActionEvent.completion_status:type_name -> log.CompletionStatus
+	45, // 23: proxy.ActionEvent.result:type_name -> cmd.CommandResult
+	46, // 24: proxy.RunRequest.command:type_name -> cmd.Command
+	38, // 25: proxy.RunRequest.labels:type_name -> proxy.RunRequest.LabelsEntry
+	27, // 26: proxy.RunRequest.execution_options:type_name -> proxy.ProxyExecutionOptions
+	31, // 27: proxy.RunRequest.metadata:type_name -> proxy.Metadata
+	45, // 28: proxy.RunResponse.result:type_name -> cmd.CommandResult
+	44, // 29: proxy.RunResponse.action_log:type_name -> log.LogRecord
+	26, // 30: proxy.RunResponse.remote_fallback_info:type_name -> proxy.RemoteFallbackInfo
+	2,  // 31: proxy.ProxyExecutionOptions.execution_strategy:type_name -> proxy.ExecutionStrategy.Value
+	30, // 32: proxy.ProxyExecutionOptions.remote_execution_options:type_name -> proxy.RemoteExecutionOptions
+	29, // 33: proxy.ProxyExecutionOptions.local_execution_options:type_name -> proxy.LocalExecutionOptions
+	3,  // 34: proxy.LocalExecutionOptions.platform:type_name -> proxy.LocalExecutionOptions.LocalExecutionPlatform
+	39, // 35: proxy.Metadata.event_times:type_name -> proxy.Metadata.EventTimesEntry
+	47, // 36: proxy.AddProxyEventsRequest.EventTimesEntry.value:type_name -> cmd.TimeInterval
+	47, // 37: proxy.Metadata.EventTimesEntry.value:type_name -> cmd.TimeInterval
+	24, // 38: proxy.Commands.RunCommand:input_type -> proxy.RunRequest
+	24, // 39: proxy.Commands.RunCommandStream:input_type -> proxy.RunRequest
+	4,  // 40: proxy.Commands.CancelCommand:input_type -> proxy.CancelCommandRequest
+	6,  // 41: proxy.Commands.Shutdown:input_type -> proxy.ShutdownRequest
+	7,  // 42: proxy.Commands.ReloadConfig:input_type -> proxy.ReloadConfigRequest
+	14, // 43: proxy.Stats.GetRecords:input_type -> proxy.GetRecordsRequest
+	16, // 44: proxy.Stats.AddProxyEvents:input_type -> proxy.AddProxyEventsRequest
+	18, // 45: proxy.Stats.WatchActions:input_type -> proxy.WatchActionsRequest
+	19, // 46: proxy.Stats.GetRunningActions:input_type -> proxy.GetRunningActionsRequest
+	10, // 47: proxy.Status.GetStatusSummary:input_type -> proxy.GetStatusSummaryRequest
+	12, // 48: proxy.Status.GetInvocationStats:input_type -> proxy.GetInvocationStatsRequest
+	25, // 49: proxy.Commands.RunCommand:output_type -> proxy.RunResponse
+	25, // 50: proxy.Commands.RunCommandStream:output_type -> proxy.RunResponse
+	5,  // 51: proxy.Commands.CancelCommand:output_type -> proxy.CancelCommandResponse
+	9,  // 52: proxy.Commands.Shutdown:output_type -> proxy.ShutdownResponse
+	8,  // 53: proxy.Commands.ReloadConfig:output_type -> proxy.ReloadConfigResponse
+	15, // 54: proxy.Stats.GetRecords:output_type -> proxy.GetRecordsResponse
+	17, // 55: proxy.Stats.AddProxyEvents:output_type -> proxy.AddProxyEventsResponse
+	23, // 56: proxy.Stats.WatchActions:output_type -> proxy.ActionEvent
+	21, // 57: proxy.Stats.GetRunningActions:output_type -> proxy.GetRunningActionsResponse
+	11, // 58: proxy.Status.GetStatusSummary:output_type -> proxy.GetStatusSummaryResponse
+	13, // 59: proxy.Status.GetInvocationStats:output_type -> proxy.GetInvocationStatsResponse
+	49, // [49:60] is the sub-list for method output_type
+	38, // [38:49] is the sub-list for method input_type
+	38, // [38:38] is the sub-list for extension type_name
+	38, // [38:38] is the sub-list for extension extendee
+	0,  // [0:38] is the sub-list for field type_name
 }
 
 func init() { file_api_proxy_proxy_proto_init() }
@@ -1408,6 +2607,30 @@ func file_api_proxy_proxy_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_api_proxy_proxy_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelCommandRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proxy_proxy_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelCommandResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proxy_proxy_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ShutdownRequest); i {
 			case 0:
 				return &v.state
@@ -1419,7 +2642,31 @@ func file_api_proxy_proxy_proto_init() {
 				return nil
 			}
 		}
-		file_api_proxy_proxy_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proxy_proxy_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReloadConfigRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proxy_proxy_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReloadConfigResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proxy_proxy_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ShutdownResponse); i {
 			case 0:
 				return &v.state
@@ -1431,7 +2678,7 @@ func file_api_proxy_proxy_proto_init() {
 				return nil
 			}
 		}
-		file_api_proxy_proxy_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proxy_proxy_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetStatusSummaryRequest); i {
 			case 0:
 				return &v.state
@@ -1443,7 +2690,7 @@ func file_api_proxy_proxy_proto_init() {
 				return nil
 			}
 		}
-		file_api_proxy_proxy_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proxy_proxy_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetStatusSummaryResponse); i {
 			case 0:
 				return &v.state
@@ -1455,7 +2702,31 @@ func file_api_proxy_proxy_proto_init() {
 				return nil
 			}
 		}
-		file_api_proxy_proxy_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proxy_proxy_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetInvocationStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proxy_proxy_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetInvocationStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proxy_proxy_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetRecordsRequest); i {
 			case 0:
 				return &v.state
@@ -1467,7 +2738,7 @@ func file_api_proxy_proxy_proto_init() {
 				return nil
 			}
 		}
-		file_api_proxy_proxy_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proxy_proxy_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetRecordsResponse); i {
 			case 0:
 				return &v.state
@@ -1479,7 +2750,7 @@ func file_api_proxy_proxy_proto_init() {
 				return nil
 			}
 		}
-		file_api_proxy_proxy_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proxy_proxy_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AddProxyEventsRequest); i {
 			case 0:
 				return &v.state
@@ -1491,7 +2762,7 @@ func file_api_proxy_proxy_proto_init() {
 				return nil
 			}
 		}
-		file_api_proxy_proxy_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proxy_proxy_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*AddProxyEventsResponse); i {
 			case 0:
 				return &v.state
@@ -1503,7 +2774,79 @@ func file_api_proxy_proxy_proto_init() {
 				return nil
 			}
 		}
-		file_api_proxy_proxy_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proxy_proxy_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchActionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proxy_proxy_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRunningActionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proxy_proxy_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunningAction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proxy_proxy_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRunningActionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proxy_proxy_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionPhase); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proxy_proxy_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proxy_proxy_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RunRequest); i {
 			case 0:
 				return &v.state
@@ -1515,7 +2858,7 @@ func file_api_proxy_proxy_proto_init() {
 				return nil
 			}
 		}
-		file_api_proxy_proxy_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proxy_proxy_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RunResponse); i {
 			case 0:
 				return &v.state
@@ -1527,7 +2870,7 @@ func file_api_proxy_proxy_proto_init() {
 				return nil
 			}
 		}
-		file_api_proxy_proxy_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proxy_proxy_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RemoteFallbackInfo); i {
 			case 0:
 				return &v.state
@@ -1539,7 +2882,7 @@ func file_api_proxy_proxy_proto_init() {
 				return nil
 			}
 		}
-		file_api_proxy_proxy_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proxy_proxy_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ProxyExecutionOptions); i {
 			case 0:
 				return &v.state
@@ -1551,7 +2894,7 @@ func file_api_proxy_proxy_proto_init() {
 				return nil
 			}
 		}
-		file_api_proxy_proxy_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proxy_proxy_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ExecutionStrategy); i {
 			case 0:
 				return &v.state
@@ -1563,7 +2906,7 @@ func file_api_proxy_proxy_proto_init() {
 				return nil
 			}
 		}
-		file_api_proxy_proxy_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proxy_proxy_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LocalExecutionOptions); i {
 			case 0:
 				return &v.state
@@ -1575,7 +2918,7 @@ func file_api_proxy_proxy_proto_init() {
 				return nil
 			}
 		}
-		file_api_proxy_proxy_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proxy_proxy_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RemoteExecutionOptions); i {
 			case 0:
 				return &v.state
@@ -1587,7 +2930,7 @@ func file_api_proxy_proxy_proto_init() {
 				return nil
 			}
 		}
-		file_api_proxy_proxy_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_api_proxy_proxy_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Metadata); i {
 			case 0:
 				return &v.state
@@ -1605,8 +2948,8 @@ func file_api_proxy_proxy_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_proxy_proxy_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   20,
+			NumEnums:      4,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   3,
 		},
@@ -1634,7 +2977,10 @@ const _ = grpc.SupportPackageIsVersion6
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type CommandsClient interface {
 	RunCommand(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (*RunResponse, error)
+	RunCommandStream(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (Commands_RunCommandStreamClient, error)
+	CancelCommand(ctx context.Context, in *CancelCommandRequest, opts ...grpc.CallOption) (*CancelCommandResponse, error)
 	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
+	ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error)
 }
 
 type commandsClient struct {
@@ -1654,6 +3000,47 @@ func (c *commandsClient) RunCommand(ctx context.Context, in *RunRequest, opts ..
 	return out, nil
 }
 
+func (c *commandsClient) RunCommandStream(ctx context.Context, in *RunRequest, opts ...grpc.CallOption) (Commands_RunCommandStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Commands_serviceDesc.Streams[0], "/proxy.Commands/RunCommandStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &commandsRunCommandStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Commands_RunCommandStreamClient interface {
+	Recv() (*RunResponse, error)
+	grpc.ClientStream
+}
+
+type commandsRunCommandStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *commandsRunCommandStreamClient) Recv() (*RunResponse, error) {
+	m := new(RunResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *commandsClient) CancelCommand(ctx context.Context, in *CancelCommandRequest, opts ...grpc.CallOption) (*CancelCommandResponse, error) {
+	out := new(CancelCommandResponse)
+	err := c.cc.Invoke(ctx, "/proxy.Commands/CancelCommand", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *commandsClient) Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error) {
 	out := new(ShutdownResponse)
 	err := c.cc.Invoke(ctx, "/proxy.Commands/Shutdown", in, out, opts...)
@@ -1663,10 +3050,22 @@ func (c *commandsClient) Shutdown(ctx context.Context, in *ShutdownRequest, opts
 	return out, nil
 }
 
+func (c *commandsClient) ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error) {
+	out := new(ReloadConfigResponse)
+	err := c.cc.Invoke(ctx, "/proxy.Commands/ReloadConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CommandsServer is the server API for Commands service.
 type CommandsServer interface {
 	RunCommand(context.Context, *RunRequest) (*RunResponse, error)
+	RunCommandStream(*RunRequest, Commands_RunCommandStreamServer) error
+	CancelCommand(context.Context, *CancelCommandRequest) (*CancelCommandResponse, error)
 	Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
+	ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error)
 }
 
 // UnimplementedCommandsServer can be embedded to have forward compatible implementations.
@@ -1676,9 +3075,18 @@ type UnimplementedCommandsServer struct {
 func (*UnimplementedCommandsServer) RunCommand(context.Context, *RunRequest) (*RunResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RunCommand not implemented")
 }
+func (*UnimplementedCommandsServer) RunCommandStream(*RunRequest, Commands_RunCommandStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method RunCommandStream not implemented")
+}
+func (*UnimplementedCommandsServer) CancelCommand(context.Context, *CancelCommandRequest) (*CancelCommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelCommand not implemented")
+}
 func (*UnimplementedCommandsServer) Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
 }
+func (*UnimplementedCommandsServer) ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfig not implemented")
+}
 
 func RegisterCommandsServer(s *grpc.Server, srv CommandsServer) {
 	s.RegisterService(&_Commands_serviceDesc, srv)
@@ -1702,6 +3110,45 @@ func _Commands_RunCommand_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Commands_RunCommandStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RunRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CommandsServer).RunCommandStream(m, &commandsRunCommandStreamServer{stream})
+}
+
+type Commands_RunCommandStreamServer interface {
+	Send(*RunResponse) error
+	grpc.ServerStream
+}
+
+type commandsRunCommandStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *commandsRunCommandStreamServer) Send(m *RunResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Commands_CancelCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelCommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommandsServer).CancelCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proxy.Commands/CancelCommand",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommandsServer).CancelCommand(ctx, req.(*CancelCommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Commands_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ShutdownRequest)
 	if err := dec(in); err != nil {
@@ -1720,6 +3167,24 @@ func _Commands_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Commands_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommandsServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proxy.Commands/ReloadConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommandsServer).ReloadConfig(ctx, req.(*ReloadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Commands_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proxy.Commands",
 	HandlerType: (*CommandsServer)(nil),
@@ -1728,12 +3193,26 @@ var _Commands_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RunCommand",
 			Handler:    _Commands_RunCommand_Handler,
 		},
+		{
+			MethodName: "CancelCommand",
+			Handler:    _Commands_CancelCommand_Handler,
+		},
 		{
 			MethodName: "Shutdown",
 			Handler:    _Commands_Shutdown_Handler,
 		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _Commands_ReloadConfig_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RunCommandStream",
+			Handler:       _Commands_RunCommandStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proxy/proxy.proto",
 }
 
@@ -1743,6 +3222,8 @@ var _Commands_serviceDesc = grpc.ServiceDesc{
 type StatsClient interface {
 	GetRecords(ctx context.Context, in *GetRecordsRequest, opts ...grpc.CallOption) (*GetRecordsResponse, error)
 	AddProxyEvents(ctx context.Context, in *AddProxyEventsRequest, opts ...grpc.CallOption) (*AddProxyEventsResponse, error)
+	WatchActions(ctx context.Context, in *WatchActionsRequest, opts ...grpc.CallOption) (Stats_WatchActionsClient, error)
+	GetRunningActions(ctx context.Context, in *GetRunningActionsRequest, opts ...grpc.CallOption) (*GetRunningActionsResponse, error)
 }
 
 type statsClient struct {
@@ -1771,10 +3252,53 @@ func (c *statsClient) AddProxyEvents(ctx context.Context, in *AddProxyEventsRequ
 	return out, nil
 }
 
+func (c *statsClient) WatchActions(ctx context.Context, in *WatchActionsRequest, opts ...grpc.CallOption) (Stats_WatchActionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Stats_serviceDesc.Streams[0], "/proxy.Stats/WatchActions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &statsWatchActionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Stats_WatchActionsClient interface {
+	Recv() (*ActionEvent, error)
+	grpc.ClientStream
+}
+
+type statsWatchActionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *statsWatchActionsClient) Recv() (*ActionEvent, error) {
+	m := new(ActionEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *statsClient) GetRunningActions(ctx context.Context, in *GetRunningActionsRequest, opts ...grpc.CallOption) (*GetRunningActionsResponse, error) {
+	out := new(GetRunningActionsResponse)
+	err := c.cc.Invoke(ctx, "/proxy.Stats/GetRunningActions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StatsServer is the server API for Stats service.
 type StatsServer interface {
 	GetRecords(context.Context, *GetRecordsRequest) (*GetRecordsResponse, error)
 	AddProxyEvents(context.Context, *AddProxyEventsRequest) (*AddProxyEventsResponse, error)
+	WatchActions(*WatchActionsRequest, Stats_WatchActionsServer) error
+	GetRunningActions(context.Context, *GetRunningActionsRequest) (*GetRunningActionsResponse, error)
 }
 
 // UnimplementedStatsServer can be embedded to have forward compatible implementations.
@@ -1787,6 +3311,12 @@ func (*UnimplementedStatsServer) GetRecords(context.Context, *GetRecordsRequest)
 func (*UnimplementedStatsServer) AddProxyEvents(context.Context, *AddProxyEventsRequest) (*AddProxyEventsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddProxyEvents not implemented")
 }
+func (*UnimplementedStatsServer) WatchActions(*WatchActionsRequest, Stats_WatchActionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchActions not implemented")
+}
+func (*UnimplementedStatsServer) GetRunningActions(context.Context, *GetRunningActionsRequest) (*GetRunningActionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRunningActions not implemented")
+}
 
 func RegisterStatsServer(s *grpc.Server, srv StatsServer) {
 	s.RegisterService(&_Stats_serviceDesc, srv)
@@ -1828,6 +3358,45 @@ func _Stats_AddProxyEvents_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Stats_WatchActions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchActionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StatsServer).WatchActions(m, &statsWatchActionsServer{stream})
+}
+
+type Stats_WatchActionsServer interface {
+	Send(*ActionEvent) error
+	grpc.ServerStream
+}
+
+type statsWatchActionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *statsWatchActionsServer) Send(m *ActionEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Stats_GetRunningActions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRunningActionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatsServer).GetRunningActions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proxy.Stats/GetRunningActions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatsServer).GetRunningActions(ctx, req.(*GetRunningActionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Stats_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proxy.Stats",
 	HandlerType: (*StatsServer)(nil),
@@ -1840,8 +3409,18 @@ var _Stats_serviceDesc = grpc.ServiceDesc{
 			MethodName: "AddProxyEvents",
 			Handler:    _Stats_AddProxyEvents_Handler,
 		},
+		{
+			MethodName: "GetRunningActions",
+			Handler:    _Stats_GetRunningActions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchActions",
+			Handler:       _Stats_WatchActions_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proxy/proxy.proto",
 }
 
@@ -1850,6 +3429,7 @@ var _Stats_serviceDesc = grpc.ServiceDesc{
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type StatusClient interface {
 	GetStatusSummary(ctx context.Context, in *GetStatusSummaryRequest, opts ...grpc.CallOption) (*GetStatusSummaryResponse, error)
+	GetInvocationStats(ctx context.Context, in *GetInvocationStatsRequest, opts ...grpc.CallOption) (*GetInvocationStatsResponse, error)
 }
 
 type statusClient struct {
@@ -1869,9 +3449,19 @@ func (c *statusClient) GetStatusSummary(ctx context.Context, in *GetStatusSummar
 	return out, nil
 }
 
+func (c *statusClient) GetInvocationStats(ctx context.Context, in *GetInvocationStatsRequest, opts ...grpc.CallOption) (*GetInvocationStatsResponse, error) {
+	out := new(GetInvocationStatsResponse)
+	err := c.cc.Invoke(ctx, "/proxy.Status/GetInvocationStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StatusServer is the server API for Status service.
 type StatusServer interface {
 	GetStatusSummary(context.Context, *GetStatusSummaryRequest) (*GetStatusSummaryResponse, error)
+	GetInvocationStats(context.Context, *GetInvocationStatsRequest) (*GetInvocationStatsResponse, error)
 }
 
 // UnimplementedStatusServer can be embedded to have forward compatible implementations.
@@ -1881,6 +3471,9 @@ type UnimplementedStatusServer struct {
 func (*UnimplementedStatusServer) GetStatusSummary(context.Context, *GetStatusSummaryRequest) (*GetStatusSummaryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStatusSummary not implemented")
 }
+func (*UnimplementedStatusServer) GetInvocationStats(context.Context, *GetInvocationStatsRequest) (*GetInvocationStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInvocationStats not implemented")
+}
 
 func RegisterStatusServer(s *grpc.Server, srv StatusServer) {
 	s.RegisterService(&_Status_serviceDesc, srv)
@@ -1904,6 +3497,24 @@ func _Status_GetStatusSummary_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Status_GetInvocationStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInvocationStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StatusServer).GetInvocationStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proxy.Status/GetInvocationStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServer).GetInvocationStats(ctx, req.(*GetInvocationStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Status_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "proxy.Status",
 	HandlerType: (*StatusServer)(nil),
@@ -1912,6 +3523,10 @@ var _Status_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetStatusSummary",
 			Handler:    _Status_GetStatusSummary_Handler,
 		},
+		{
+			MethodName: "GetInvocationStats",
+			Handler:    _Status_GetInvocationStats_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proxy/proxy.proto",
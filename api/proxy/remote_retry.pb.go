@@ -0,0 +1,290 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        v3.15.6
+// source: api/proxy/remote_retry.proto
+
+package proxy
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RemoteRetryPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Transient        *RemoteRetryRule `protobuf:"bytes,1,opt,name=transient,proto3" json:"transient,omitempty"`
+	WorkerLost       *RemoteRetryRule `protobuf:"bytes,2,opt,name=worker_lost,json=workerLost,proto3" json:"worker_lost,omitempty"`
+	DeadlineExceeded *RemoteRetryRule `protobuf:"bytes,3,opt,name=deadline_exceeded,json=deadlineExceeded,proto3" json:"deadline_exceeded,omitempty"`
+	NonZeroExit      *RemoteRetryRule `protobuf:"bytes,4,opt,name=non_zero_exit,json=nonZeroExit,proto3" json:"non_zero_exit,omitempty"`
+}
+
+func (x *RemoteRetryPolicy) Reset() {
+	*x = RemoteRetryPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_remote_retry_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoteRetryPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteRetryPolicy) ProtoMessage() {}
+
+func (x *RemoteRetryPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_remote_retry_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteRetryPolicy.ProtoReflect.Descriptor instead.
+func (*RemoteRetryPolicy) Descriptor() ([]byte, []int) {
+	return file_api_proxy_remote_retry_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RemoteRetryPolicy) GetTransient() *RemoteRetryRule {
+	if x != nil {
+		return x.Transient
+	}
+	return nil
+}
+
+func (x *RemoteRetryPolicy) GetWorkerLost() *RemoteRetryRule {
+	if x != nil {
+		return x.WorkerLost
+	}
+	return nil
+}
+
+func (x *RemoteRetryPolicy) GetDeadlineExceeded() *RemoteRetryRule {
+	if x != nil {
+		return x.DeadlineExceeded
+	}
+	return nil
+}
+
+func (x *RemoteRetryPolicy) GetNonZeroExit() *RemoteRetryRule {
+	if x != nil {
+		return x.NonZeroExit
+	}
+	return nil
+}
+
+type RemoteRetryRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxRetries       int32             `protobuf:"varint,1,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
+	BackoffMs        int64             `protobuf:"varint,2,opt,name=backoff_ms,json=backoffMs,proto3" json:"backoff_ms,omitempty"`
+	MaxBackoffMs     int64             `protobuf:"varint,3,opt,name=max_backoff_ms,json=maxBackoffMs,proto3" json:"max_backoff_ms,omitempty"`
+	EscalatePlatform map[string]string `protobuf:"bytes,4,rep,name=escalate_platform,json=escalatePlatform,proto3" json:"escalate_platform,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *RemoteRetryRule) Reset() {
+	*x = RemoteRetryRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proxy_remote_retry_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoteRetryRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteRetryRule) ProtoMessage() {}
+
+func (x *RemoteRetryRule) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proxy_remote_retry_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteRetryRule.ProtoReflect.Descriptor instead.
+func (*RemoteRetryRule) Descriptor() ([]byte, []int) {
+	return file_api_proxy_remote_retry_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RemoteRetryRule) GetMaxRetries() int32 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+func (x *RemoteRetryRule) GetBackoffMs() int64 {
+	if x != nil {
+		return x.BackoffMs
+	}
+	return 0
+}
+
+func (x *RemoteRetryRule) GetMaxBackoffMs() int64 {
+	if x != nil {
+		return x.MaxBackoffMs
+	}
+	return 0
+}
+
+func (x *RemoteRetryRule) GetEscalatePlatform() map[string]string {
+	if x != nil {
+		return x.EscalatePlatform
+	}
+	return nil
+}
+
+var File_api_proxy_remote_retry_proto protoreflect.FileDescriptor
+
+var file_api_proxy_remote_retry_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2f, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05,
+	0x70, 0x72, 0x6f, 0x78, 0x79, 0x22, 0x83, 0x02, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x52, 0x65, 0x74, 0x72, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x34, 0x0a, 0x09, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x74,
+	0x72, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x09, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x65, 0x6e,
+	0x74, 0x12, 0x37, 0x0a, 0x0b, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x5f, 0x6c, 0x6f, 0x73, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x74, 0x72, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x0a,
+	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x4c, 0x6f, 0x73, 0x74, 0x12, 0x43, 0x0a, 0x11, 0x64, 0x65,
+	0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x65, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x52, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x74, 0x72, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x10, 0x64,
+	0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x45, 0x78, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x12,
+	0x3a, 0x0a, 0x0d, 0x6e, 0x6f, 0x6e, 0x5f, 0x7a, 0x65, 0x72, 0x6f, 0x5f, 0x65, 0x78, 0x69, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x74, 0x72, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x0b,
+	0x6e, 0x6f, 0x6e, 0x5a, 0x65, 0x72, 0x6f, 0x45, 0x78, 0x69, 0x74, 0x22, 0x97, 0x02, 0x0a, 0x0f,
+	0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x74, 0x72, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x12,
+	0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x5f, 0x6d, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x4d, 0x73, 0x12,
+	0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6f, 0x66, 0x66, 0x5f, 0x6d,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x42, 0x61, 0x63, 0x6b,
+	0x6f, 0x66, 0x66, 0x4d, 0x73, 0x12, 0x59, 0x0a, 0x11, 0x65, 0x73, 0x63, 0x61, 0x6c, 0x61, 0x74,
+	0x65, 0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2c, 0x2e, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x52,
+	0x65, 0x74, 0x72, 0x79, 0x52, 0x75, 0x6c, 0x65, 0x2e, 0x45, 0x73, 0x63, 0x61, 0x6c, 0x61, 0x74,
+	0x65, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x10,
+	0x65, 0x73, 0x63, 0x61, 0x6c, 0x61, 0x74, 0x65, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d,
+	0x1a, 0x43, 0x0a, 0x15, 0x45, 0x73, 0x63, 0x61, 0x6c, 0x61, 0x74, 0x65, 0x50, 0x6c, 0x61, 0x74,
+	0x66, 0x6f, 0x72, 0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x61, 0x7a, 0x65, 0x6c, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x2f, 0x72,
+	0x65, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x78,
+	0x79, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_proxy_remote_retry_proto_rawDescOnce sync.Once
+	file_api_proxy_remote_retry_proto_rawDescData = file_api_proxy_remote_retry_proto_rawDesc
+)
+
+func file_api_proxy_remote_retry_proto_rawDescGZIP() []byte {
+	file_api_proxy_remote_retry_proto_rawDescOnce.Do(func() {
+		file_api_proxy_remote_retry_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_proxy_remote_retry_proto_rawDescData)
+	})
+	return file_api_proxy_remote_retry_proto_rawDescData
+}
+
+var file_api_proxy_remote_retry_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_api_proxy_remote_retry_proto_goTypes = []interface{}{
+	(*RemoteRetryPolicy)(nil), // 0: proxy.RemoteRetryPolicy
+	(*RemoteRetryRule)(nil),   // 1: proxy.RemoteRetryRule
+	nil,                       // 2: proxy.RemoteRetryRule.EscalatePlatformEntry
+}
+var file_api_proxy_remote_retry_proto_depIdxs = []int32{
+	1, // 0: proxy.RemoteRetryPolicy.transient:type_name -> proxy.RemoteRetryRule
+	1, // 1: proxy.RemoteRetryPolicy.worker_lost:type_name -> proxy.RemoteRetryRule
+	1, // 2: proxy.RemoteRetryPolicy.deadline_exceeded:type_name -> proxy.RemoteRetryRule
+	1, // 3: proxy.RemoteRetryPolicy.non_zero_exit:type_name -> proxy.RemoteRetryRule
+	2, // 4: proxy.RemoteRetryRule.escalate_platform:type_name -> proxy.RemoteRetryRule.EscalatePlatformEntry
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_api_proxy_remote_retry_proto_init() }
+func file_api_proxy_remote_retry_proto_init() {
+	if File_api_proxy_remote_retry_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_api_proxy_remote_retry_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoteRetryPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proxy_remote_retry_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoteRetryRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_api_proxy_remote_retry_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_api_proxy_remote_retry_proto_goTypes,
+		DependencyIndexes: file_api_proxy_remote_retry_proto_depIdxs,
+		MessageInfos:      file_api_proxy_remote_retry_proto_msgTypes,
+	}.Build()
+	File_api_proxy_remote_retry_proto = out.File
+	file_api_proxy_remote_retry_proto_rawDesc = nil
+	file_api_proxy_remote_retry_proto_goTypes = nil
+	file_api_proxy_remote_retry_proto_depIdxs = nil
+}
@@ -25,6 +25,7 @@ import (
 	_ "net/http/pprof"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"runtime"
 	"runtime/pprof"
 	"strings"
@@ -32,8 +33,12 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/bazelbuild/reclient/internal/pkg/actioncache"
 	"github.com/bazelbuild/reclient/internal/pkg/auth"
 	"github.com/bazelbuild/reclient/internal/pkg/auxiliary"
+	"github.com/bazelbuild/reclient/internal/pkg/cgroups"
+	"github.com/bazelbuild/reclient/internal/pkg/diskcas"
+	"github.com/bazelbuild/reclient/internal/pkg/filetrace"
 	"github.com/bazelbuild/reclient/internal/pkg/ignoremismatch"
 	"github.com/bazelbuild/reclient/internal/pkg/interceptors"
 	"github.com/bazelbuild/reclient/internal/pkg/ipc"
@@ -42,10 +47,12 @@ import (
 	"github.com/bazelbuild/reclient/internal/pkg/logger"
 	"github.com/bazelbuild/reclient/internal/pkg/loghttp"
 	"github.com/bazelbuild/reclient/internal/pkg/monitoring"
+	"github.com/bazelbuild/reclient/internal/pkg/otlptrace"
 	"github.com/bazelbuild/reclient/internal/pkg/pathtranslator"
 	"github.com/bazelbuild/reclient/internal/pkg/rbeflag"
 	"github.com/bazelbuild/reclient/internal/pkg/reproxy"
 	"github.com/bazelbuild/reclient/internal/pkg/reproxypid"
+	"github.com/bazelbuild/reclient/internal/pkg/sandbox"
 	"github.com/bazelbuild/reclient/internal/pkg/stats"
 	"github.com/bazelbuild/reclient/internal/pkg/subprocess"
 	"github.com/bazelbuild/reclient/internal/pkg/version"
@@ -75,6 +82,7 @@ var (
 
 var (
 	proxyLogDir                []string
+	otlpResourceAttributes     map[string]string
 	clangDepScanIgnoredPlugins = flag.String("clang_depscan_ignored_plugins", "", `Comma-separated list of plugins that should be ignored by clang dependency scanner.
 	Use this flag if you're using custom llvm build as your toolchain and your llvm plugins cause dependency scanning failures.`)
 	serverAddr               = flag.String("server_address", "", "The server address in the format of host:port for network, or unix:///file for unix domain sockets.")
@@ -87,6 +95,15 @@ var (
 	// TODO(b/157446611): remove this flag.
 	_                     = flag.String("cpp_dependency_scanner_plugin", "", "Deprecated: Location of the CPP dependency scanner plugin.")
 	localResourceFraction = flag.Float64("local_resource_fraction", 1, "Number [0,1] indicating how much of the local machine resources are available for local execution, 1 being all of the machine's CPUs and RAM, 0 being no resources available for local execution.")
+	dynamicLocalResources = flag.Bool("dynamic_local_resources", false, "Whether to periodically sample the load of the system (load average, available memory and memory pressure) and shrink or grow the local resources available for local execution accordingly, within the limits set by local_resource_fraction. Only supported on Linux.")
+	validateOutputs       = flag.Bool("validate_outputs", false, "Whether to check that successful actions produced all their declared outputs. Missing outputs are recorded in the output_validation field of the log record of the action.")
+	detectUndeclared      = flag.Bool("detect_undeclared_outputs", false, "Whether to report files written by locally executed actions next to their declared output files and named after one of them, e.g. foo.dwo next to foo.o, that are not declared outputs. Undeclared outputs are recorded in the output_validation field of the log record of the action.")
+	failOnInvalidOutputs  = flag.Bool("fail_on_invalid_outputs", false, "Whether to fail actions with missing or undeclared outputs found by validate_outputs or detect_undeclared_outputs.")
+	logInputManifest      = flag.Bool("log_input_manifest", false, "Whether to log the digests of the input files, arguments and environment variables of each action in the input_manifest field of its log record, also in the reducedtext log format, so that reproxytool --operation=explain_cache_misses can tell why the digests of actions changed between builds. Input files not digested for remote execution are digested for this.")
+	traceLocalInputs      = flag.Bool("trace_local_inputs", false, "Whether to trace the files accessed by locally executed commands with ptrace, and record in the input_trace field of the local metadata of their log record the files under the exec root they read without declaring them as inputs, and the declared inputs they did not access. Tracing noticeably slows down local commands. Only supported on Linux amd64 and arm64.")
+	localResourcesConfig  = flag.String("local_resources_config_path", "", "If provided, path to a LocalResourcesConfig text proto setting the CPUs and RAM reserved for locally executed actions, by action labels.")
+	localMemoryLimits     = flag.Bool("local_memory_limits", false, "Whether to run each locally executed command in its own cgroup v2 with a memory limit set to the RAM it reserves, as configured with local_resources_config_path. Commands exceeding their limit are killed and reported as OOM killed in the local metadata of their log record. Only supported on Linux.")
+	localCgroupPath       = flag.String("local_cgroup_path", "", "Cgroup v2 delegated to the current user in which the cgroups of locally executed commands are created when local_memory_limits is set, e.g. /sys/fs/cgroup/user.slice/user-1000.slice/reproxy. It must not contain any process. If empty, the cgroup of reproxy is used, and reproxy is moved to a child cgroup of it.")
 	cacheSilo             = flag.String("cache_silo", "", "Cache silo key to be used for all the actions. Usually used to segregate cache-hits between various builds.")
 	versionCacheSilo      = flag.Bool("version_cache_silo", false, "Indicates whether to add a re-client version as cache-silo key to all remotely-executed actions. Not applicable for actions run in local-execution-remote-cache (LERC) mode.")
 	remoteDisabled        = flag.Bool("remote_disabled", false, "Whether to disable all remote operations and run all actions locally.")
@@ -103,17 +120,33 @@ var (
 	logKeepDuration       = flag.Duration("log_keep_duration", 24*time.Hour, "Delete all RE logs older than the specified duration on startup.")
 	idleTimeout           = flag.Duration("proxy_idle_timeout", 6*time.Hour, "Inactivity period after which the running reproxy process will be killed. Default is 6 hours. When set to 0, idle timeout is disabled.")
 	depsCacheMaxMb        = flag.Int("deps_cache_max_mb", 128, "Maximum size of the deps cache file (for goma input processor only).")
+	enableLocalCache      = flag.Bool("enable_local_action_cache", false, "Enables a persistent local action cache under --cache_dir for actions executed locally in remote_disabled and LERC modes.")
+	localCacheMaxMb       = flag.Int("local_action_cache_max_mb", 10240, "Maximum size of the local action cache. Least recently used actions are evicted once it is exceeded.")
+	enableDiskCAS         = flag.Bool("enable_disk_cas", false, "Enables a local disk CAS under --cache_dir that outputs of remote actions are served from before downloading them from the remote CAS.")
+	diskCASMaxMb          = flag.Int("disk_cas_max_mb", 10240, "Maximum size of the disk CAS. Least recently used blobs are evicted once it is exceeded.")
+	diskCASUseHardlinks   = flag.Bool("disk_cas_use_hardlinks", false, "Whether outputs can be materialized from the disk CAS as hard links when reflinks are not supported by the filesystem. Outputs materialized as hard links are read-only.")
 	// TODO(b/233275188): remove this flag.
 	_                                 = flag.Duration("ip_reset_min_delay", 3*time.Minute, "Deprecated. The minimum time after the input processor has been reset before it can be reset again. Negative values disable resetting.")
 	ipTimeout                         = flag.Duration("ip_timeout", 10*time.Minute, "The maximum time to wait for an input processor action. Zero and negative values disable timeout.")
 	metricsProject                    = flag.String("metrics_project", "", "If set, action and build metrics are exported to Cloud Monitoring in the specified GCP project")
 	metricsPrefix                     = flag.String("metrics_prefix", "", "Prefix of metrics exported to Cloud Monitoring")
 	metricsNamespace                  = flag.String("metrics_namespace", "", "Namespace of metrics exported to Cloud Monitoring (e.g. RBE project)")
+	metricsListenAddress              = flag.String("metrics_listen_address", "", "If set, e.g. to localhost:9100, action and build metrics are served in the OpenMetrics text format at /metrics on this address for scraping by Prometheus, along with gauges of running actions, queued local actions and resource usage. Works with or without metrics_project.")
+	otlpEndpoint                      = flag.String("otlp_endpoint", "", "If set, e.g. to localhost:4317, each action is exported while the build runs as an OpenTelemetry trace, made of spans of the events of its log record, to the OTLP/gRPC collector at this address.")
+	otlpInsecure                      = flag.Bool("otlp_insecure", false, "Whether to connect to otlp_endpoint without TLS.")
+	otlpFile                          = flag.String("otlp_file", "", "If set, each action is exported while the build runs as an OpenTelemetry trace, made of spans of the events of its log record, to this file in the OTLP JSON format, one export request per line. Cannot be used with otlp_endpoint.")
 	experimentalCredentialsHelper     = flag.String(auth.CredshelperPathFlag, "", "Path to the credentials helper binary. If given execrel://, looks for the `credshelper` binary in the same folder as reproxy")
 	experimentalCredentialsHelperArgs = flag.String(auth.CredshelperArgsFlag, "", "Arguments for the experimental credentials helper, separated by space.")
 	failEarlyMinActionCount   = flag.Int64("fail_early_min_action_count", 0, "Minimum number of actions received by reproxy before the fail early mechanism can take effect. 0 indicates fail early is disabled.")
 	failEarlyMinFallbackRatio = flag.Float64("fail_early_min_fallback_ratio", 0, "Minimum ratio of fallbacks to total actions above which the build terminates early. Ratio is a number in the range [0,1]. 0 indicates fail early is disabled.")
 	failEarlyWindow           = flag.Duration("fail_early_window", 0, "Window of time to consider for fail_early_min_action_count and fail_early_min_fallback_ratio. 0 indicates all datapoints should be used.")
+	breakerErrorRatio         = flag.Float64("circuit_breaker_error_ratio", 0, "Ratio of remote executions of remote_local_fallback and racing actions that failed, timed out or exceeded circuit_breaker_slow_threshold, above which the circuit breaker trips and these actions run locally without attempting remote execution. Ratio is a number in the range [0,1]. 0 indicates the circuit breaker is disabled.")
+	breakerMinActions         = flag.Int64("circuit_breaker_min_actions", 20, "Minimum number of remote executions in circuit_breaker_window before the circuit breaker can trip.")
+	breakerWindow             = flag.Duration("circuit_breaker_window", time.Minute, "Window of time of the remote executions to consider for circuit_breaker_error_ratio. 0 indicates all remote executions should be considered.")
+	breakerSlowThreshold      = flag.Duration("circuit_breaker_slow_threshold", 0, "Time to get the result of a remote execution, including input processing, above which it counts as unhealthy for circuit_breaker_error_ratio. 0 indicates latency is not considered.")
+	breakerOpenDuration       = flag.Duration("circuit_breaker_open_duration", 30*time.Second, "Time for which remote execution is skipped once the circuit breaker trips, before probe actions are executed remotely again.")
+	breakerProbes             = flag.Int("circuit_breaker_probes", 3, "Number of probe actions that must succeed remotely for the circuit breaker to close and remote execution to resume. A single failed probe trips the circuit breaker again.")
+	remoteRetryPolicy         = flag.String("remote_retry_policy_path", "", "If provided, path to a RemoteRetryPolicy text proto setting how many times and after which delay remote executions of remote and remote_local_fallback actions are retried, by class of error: transient RPC errors, worker OOM or eviction, deadline exceeded and non-zero exit. Retries may escalate the platform of actions, e.g. to a bigger machine-type. All attempts are recorded in the remote metadata of their log record.")
 	racingBias                = flag.Float64("racing_bias", 0.75, "Value between [0,1] to indicate how racing manages the tradeoff of saving bandwidth (0) versus speed (1). The default is to prefer speed over bandwidth.")
 	racingTmp                 = flag.String("racing_tmp_dir", "", "DEPRECATED. Use download_tmp_dir instead.")
 	downloadTmp               = flag.String("download_tmp_dir", "", "Directory where reproxy should store outputs temporarily before moving them to the desired location. This should be on the same device as the output directory for the build. The default is outputs will be written to a subdirectory inside the action's working directory. Note that the download_tmp_dir will only be used if the action has racing as its exec strategy or it explicitly sets EnableAtomicDownloads=true. See proxy.proto for details.")
@@ -138,34 +171,30 @@ var (
 )
 
 func verifyFlags() {
-	if *localResourceFraction < 0 || *localResourceFraction > 1 {
-		log.Exitf("Invalid local_resource_fraction: %v, want [0,1]", *localResourceFraction)
-	}
-	if *failEarlyMinActionCount < 0 {
-		log.Exitf("Invalid fail_early_min_action_acount: %v, want [0,MaxInt64]", *failEarlyMinActionCount)
-	}
-	if *failEarlyMinFallbackRatio < 0 {
-		log.Exitf("Invalid fail_early_min_fallback_ratio: %v, want [0,1]", *failEarlyMinFallbackRatio)
-	}
-	if *failEarlyWindow < 0 {
-		log.Exitf("Invalid fail_early_window: %v, want >0", *failEarlyWindow)
+	if err := verifyReloadableFlags(); err != nil {
+		log.Exitf("%v", err)
 	}
-	if *racingBias < 0 || *racingBias > 1 {
-		log.Exitf("Invalid racing_bias: %v, want [0,1]", *racingBias)
+	if *breakerErrorRatio < 0 || *breakerErrorRatio > 1 {
+		log.Exitf("Invalid circuit_breaker_error_ratio: %v, want [0,1]", *breakerErrorRatio)
 	}
-	if *failEarlyMinActionCount == 0 && *failEarlyMinFallbackRatio > 0 {
-		log.Exitf("fail_early_min_fallback_ratio is set to %v while fail_early_min_action_count is disabled", *failEarlyMinFallbackRatio)
+	if *otlpEndpoint != "" && *otlpFile != "" {
+		log.Exitf("Only one of otlp_endpoint and otlp_file can be set")
 	}
-	if *failEarlyMinActionCount > 0 && *failEarlyMinFallbackRatio == 0 {
-		log.Exitf("fail_early_min_action_count is set to %v while fail_early_min_fallback_ratio is disabled", *failEarlyMinActionCount)
+	if *breakerProbes < 1 {
+		log.Exitf("Invalid circuit_breaker_probes: %v, want >0", *breakerProbes)
 	}
 	os.Setenv("RBE_clang_depscan_ignored_plugins", *clangDepScanIgnoredPlugins)
 }
 
 func main() {
+	// Must run before anything else, since reproxy re-executes itself to set up sandboxes for
+	// sandboxed local execution and to trace the files accessed by local commands.
+	sandbox.Init()
+	filetrace.Init()
 	flag.Var((*moreflag.StringListValue)(&proxyLogDir), "proxy_log_dir", "If provided, the directory path to a proxy log file of executed records.")
 	flag.StringVar(&filemetadata.XattrDigestName, "xattr_digest", "", "Extended file attribute to obtain the digest from, if available, formatted as hash/size. If the value contains the hash only, the file size as reported by stat is used.")
 	flag.Var((*moreflag.StringMapValue)(&labels), "metrics_labels", "Comma-separated key value pairs in the form key=value. This is used to add arbitrary labels to exported metrics.")
+	flag.Var((*moreflag.StringMapValue)(&otlpResourceAttributes), "otlp_resource_attributes", "Comma-separated key value pairs in the form key=value. These are added to the attributes of the resource of the traces exported with otlp_endpoint or otlp_file, e.g. to name the machine or the build.")
 	rbeflag.Parse()
 	rbeflag.LogAllFlags(0)
 	defer log.Flush()
@@ -273,14 +302,20 @@ func main() {
 		defer c.SaveToDisk()
 	}
 	var e *monitoring.Exporter
-	if *metricsProject != "" {
+	if *metricsProject != "" || *metricsListenAddress != "" {
 		e, err = newExporter(c)
 		if err != nil {
-			log.Warningf("Failed to initialize cloud monitoring: %v", err)
+			log.Warningf("Failed to initialize metrics: %v", err)
 		} else {
 			defer e.Close()
 		}
 	}
+	traceExporter, err := newTraceExporter(ctx)
+	if err != nil {
+		log.Warningf("Failed to initialize OpenTelemetry trace export: %v", err)
+	} else {
+		defer traceExporter.Close()
+	}
 	mi, err := ignoremismatch.New(*mismatchIgnoreConfigPath)
 	if err != nil {
 		log.Errorf("Failed to create mismatch ignorer: %v", err)
@@ -294,32 +329,108 @@ func main() {
 
 	exec := &subprocess.SystemExecutor{}
 	resMgr := localresources.NewFractionalDefaultManager(*localResourceFraction)
+	if *dynamicLocalResources {
+		go resMgr.AdjustToSystemLoad(ctx, localresources.DefaultSampleInterval)
+	}
 
 	dTmp := *racingTmp
 	if *downloadTmp != "" {
 		dTmp = *downloadTmp
 	}
 
+	var localCache *actioncache.Cache
+	if *enableLocalCache {
+		if *cacheDir == "" {
+			log.Warningf("--enable_local_action_cache requires --cache_dir to be set, local action cache is disabled")
+		} else if localCache, err = actioncache.New(filepath.Join(*cacheDir, "actioncache"), int64(*localCacheMaxMb)*1024*1024); err != nil {
+			log.Errorf("Failed to initialize local action cache: %v", err)
+			localCache = nil
+		}
+	}
+
+	var diskCAS *diskcas.CAS
+	if *enableDiskCAS && !*remoteDisabled {
+		if *cacheDir == "" {
+			log.Warningf("--enable_disk_cas requires --cache_dir to be set, disk CAS is disabled")
+		} else if diskCAS, err = diskcas.New(filepath.Join(*cacheDir, "cas"), int64(*diskCASMaxMb)*1024*1024); err != nil {
+			log.Errorf("Failed to initialize disk CAS: %v", err)
+			diskCAS = nil
+		} else {
+			diskCAS.UseHardlinks = *diskCASUseHardlinks
+		}
+	}
+
+	localPool := reproxy.NewLocalPool(exec, resMgr)
+	if *localMemoryLimits {
+		cg, err := cgroups.New(*localCgroupPath)
+		if err != nil {
+			log.Exitf("Failed to set up cgroups for local memory limits: %v", err)
+		}
+		localPool.SetCgroups(cg)
+	}
+	if *traceLocalInputs {
+		localPool.SetInputTracing()
+	}
+	if *localResourcesConfig != "" {
+		conf, err := reproxy.ReadLocalResourcesConfig(*localResourcesConfig)
+		if err != nil {
+			log.Exitf("Failed to read local resources config: %v", err)
+		}
+		if err := localPool.SetResourcesConfig(conf); err != nil {
+			log.Exitf("Invalid local resources config: %v", err)
+		}
+	}
+
+	var retryPolicy *pb.RemoteRetryPolicy
+	if *remoteRetryPolicy != "" {
+		p, err := reproxy.ReadRemoteRetryPolicy(*remoteRetryPolicy)
+		if err != nil {
+			log.Exitf("Failed to read remote retry policy: %v", err)
+		}
+		retryPolicy = p
+	}
+
+	forecast := &reproxy.Forecast{}
+	if *cacheDir != "" {
+		forecast.LoadFromDir(*cacheDir)
+	}
+
 	initCtx, cancelInit := context.WithCancel(ctx)
 	server := &reproxy.Server{
 		FileMetadataStore:         st,
-		LocalPool:                 reproxy.NewLocalPool(exec, resMgr),
+		LocalPool:                 localPool,
+		LocalCache:                localCache,
+		DiskCAS:                   diskCAS,
+		TraceExporter:             traceExporter,
 		KeepLastRecords:           *keepRecords,
 		CacheSilo:                 *cacheSilo,
 		VersionCacheSilo:          *versionCacheSilo,
 		RemoteDisabled:            *remoteDisabled,
 		DumpInputTree:             *dumpInputTree,
-		Forecast:                  &reproxy.Forecast{},
+		Forecast:                  forecast,
 		StartTime:                 start,
 		FailEarlyMinActionCount:   *failEarlyMinActionCount,
 		FailEarlyMinFallbackRatio: *failEarlyMinFallbackRatio,
 		FailEarlyWindow:           *failEarlyWindow,
+		BreakerErrorRatio:         *breakerErrorRatio,
+		BreakerMinActions:         *breakerMinActions,
+		BreakerWindow:             *breakerWindow,
+		BreakerSlowThreshold:      *breakerSlowThreshold,
+		BreakerOpenDuration:       *breakerOpenDuration,
+		BreakerProbes:             *breakerProbes,
+		RetryPolicy:               retryPolicy,
 		RacingBias:                *racingBias,
 		DownloadTmp:               dTmp,
 		MaxHoldoff:                time.Minute,
+		ValidateOutputs:           *validateOutputs,
+		DetectUndeclaredOutputs:   *detectUndeclared,
+		FailOnInvalidOutputs:      *failOnInvalidOutputs,
+		LogInputManifest:          *logInputManifest,
 		Logger:                    l,
 		StartupCancelFn:           cancelInit,
 	}
+	reloader := &configReloader{ctx: ctx, server: server, resMgr: resMgr, logger: l}
+	server.ReloadConfigFn = reloader.reload
 	server.Init()
 
 	ipOpts := &inputprocessor.Options{
@@ -384,6 +495,10 @@ func main() {
 	}
 	go server.Forecast.Run(ctx)
 	go server.MonitorFailBuildConditions(ctx)
+	go reloader.reloadOnSIGHUP(ctx)
+	if *metricsListenAddress != "" {
+		go serveMetrics(*metricsListenAddress, server, l)
+	}
 	go reproxy.IdleTimeout(ctx, *idleTimeout)
 	// Log all reproxy flags.
 	if server.Logger != nil {
@@ -427,6 +542,9 @@ func main() {
 		}
 		grpcServer.GracefulStop()
 		<-server.WaitForCleanupDone()
+		if *cacheDir != "" {
+			server.Forecast.WriteToDisk(*cacheDir)
+		}
 		log.Infof("Finished shutting down and wrote log records...")
 		log.Flush()
 		wg.Done()
@@ -522,7 +640,52 @@ func newExporter(creds *auth.Credentials) (*monitoring.Exporter, error) {
 	if err := monitoring.SetupViews(labels); err != nil {
 		return nil, err
 	}
-	return monitoring.NewExporter(context.Background(), *metricsProject, *metricsPrefix, *metricsNamespace, creds.TokenSource())
+	if *metricsProject == "" {
+		return monitoring.NewLocalExporter(), nil
+	}
+	e, err := monitoring.NewExporter(context.Background(), *metricsProject, *metricsPrefix, *metricsNamespace, creds.TokenSource())
+	if err != nil && *metricsListenAddress != "" {
+		log.Warningf("Failed to initialize cloud monitoring, metrics are only served at %v: %v", *metricsListenAddress, err)
+		return monitoring.NewLocalExporter(), nil
+	}
+	return e, err
+}
+
+// newTraceExporter returns the exporter of the actions as OpenTelemetry traces set up by the otlp_*
+// flags, or nil if trace export is disabled.
+func newTraceExporter(ctx context.Context) (*otlptrace.Exporter, error) {
+	switch {
+	case *otlpEndpoint != "":
+		return otlptrace.NewGRPCExporter(ctx, *otlpEndpoint, *otlpInsecure, otlpResourceAttributes)
+	case *otlpFile != "":
+		return otlptrace.NewFileExporter(*otlpFile, otlpResourceAttributes)
+	}
+	return nil, nil
+}
+
+// serveMetrics serves the metrics of reproxy at /metrics on the given address.
+func serveMetrics(addr string, server *reproxy.Server, l *logger.Logger) {
+	h := monitoring.NewMetricsHandler()
+	h.AddGauge("rbe/proxy/running_actions", "Number of actions being processed by reproxy", func() float64 {
+		return float64(server.NumActiveActions())
+	})
+	h.AddGauge("rbe/proxy/local_queue_length", "Number of actions waiting for local resources to execute locally", func() float64 {
+		return float64(server.LocalPool.QueueLength())
+	})
+	h.AddLabeledGauge("rbe/proxy/resource_usage", "Latest sample of the resource usage of reproxy", "resource", func() map[string]float64 {
+		u := l.ResourceUsage()
+		vals := make(map[string]float64, len(u))
+		for k, v := range u {
+			vals[k] = float64(v)
+		}
+		return vals
+	})
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", h)
+	log.Infof("Serving metrics at http://%v/metrics", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Errorf("Failed to serve metrics at %v: %v", addr, err)
+	}
 }
 
 func getLogDir() string {
@@ -0,0 +1,176 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/bazelbuild/reclient/internal/pkg/ignoremismatch"
+	"github.com/bazelbuild/reclient/internal/pkg/localresources"
+	"github.com/bazelbuild/reclient/internal/pkg/logger"
+	"github.com/bazelbuild/reclient/internal/pkg/rbeflag"
+	"github.com/bazelbuild/reclient/internal/pkg/reproxy"
+
+	log "github.com/golang/glog"
+)
+
+// reloadableFlags are the flags applied when the config file is reloaded while reproxy is running.
+// Changes to other flags in the config file only take effect after a restart.
+var reloadableFlags = []string{
+	"racing_bias",
+	"local_resource_fraction",
+	"fail_early_min_action_count",
+	"fail_early_min_fallback_ratio",
+	"fail_early_window",
+	"mismatch_ignore_config_path",
+}
+
+// resourceReleaseTimeout is how long lowering local_resource_fraction waits for local actions to
+// release the resources to withhold.
+const resourceReleaseTimeout = 5 * time.Minute
+
+// verifyReloadableFlags checks the values of the flags that can change while reproxy is running.
+func verifyReloadableFlags() error {
+	if *localResourceFraction < 0 || *localResourceFraction > 1 {
+		return fmt.Errorf("invalid local_resource_fraction: %v, want [0,1]", *localResourceFraction)
+	}
+	if *failEarlyMinActionCount < 0 {
+		return fmt.Errorf("invalid fail_early_min_action_acount: %v, want [0,MaxInt64]", *failEarlyMinActionCount)
+	}
+	if *failEarlyMinFallbackRatio < 0 || *failEarlyMinFallbackRatio > 1 {
+		return fmt.Errorf("invalid fail_early_min_fallback_ratio: %v, want [0,1]", *failEarlyMinFallbackRatio)
+	}
+	if *failEarlyWindow < 0 {
+		return fmt.Errorf("invalid fail_early_window: %v, want >0", *failEarlyWindow)
+	}
+	if *racingBias < 0 || *racingBias > 1 {
+		return fmt.Errorf("invalid racing_bias: %v, want [0,1]", *racingBias)
+	}
+	if *failEarlyMinActionCount == 0 && *failEarlyMinFallbackRatio > 0 {
+		return fmt.Errorf("fail_early_min_fallback_ratio is set to %v while fail_early_min_action_count is disabled", *failEarlyMinFallbackRatio)
+	}
+	if *failEarlyMinActionCount > 0 && *failEarlyMinFallbackRatio == 0 {
+		return fmt.Errorf("fail_early_min_action_count is set to %v while fail_early_min_fallback_ratio is disabled", *failEarlyMinActionCount)
+	}
+	return nil
+}
+
+// configReloader applies the changes of the config file to a running reproxy.
+type configReloader struct {
+	// ctx bounds the background work started by reloads.
+	ctx    context.Context
+	server *reproxy.Server
+	resMgr *localresources.Manager
+	logger *logger.Logger
+
+	mu sync.Mutex
+}
+
+// reload reads the config file again and applies the changes to the reloadable flags it defines.
+// Flags set in the command line or environment variables keep their values, as do flags removed
+// from the config file. Returns the flags whose values changed. If any of the new values is
+// invalid, nothing changes.
+func (r *configReloader) reload() (map[string]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cfg, err := rbeflag.ReloadConfig()
+	if err != nil {
+		return nil, err
+	}
+	prev := make(map[string]string)
+	changed := make(map[string]string)
+	for _, name := range reloadableFlags {
+		v, ok := cfg[name]
+		if !ok {
+			continue
+		}
+		old := flag.Lookup(name).Value.String()
+		if err := flag.Set(name, v); err != nil {
+			r.restore(prev)
+			return nil, fmt.Errorf("invalid value %q for flag %v: %w", v, name, err)
+		}
+		if v := flag.Lookup(name).Value.String(); v != old {
+			prev[name] = old
+			changed[name] = v
+		}
+	}
+	if err := verifyReloadableFlags(); err != nil {
+		r.restore(prev)
+		return nil, err
+	}
+	var mi *ignoremismatch.MismatchIgnorer
+	if _, ok := changed["mismatch_ignore_config_path"]; ok {
+		if mi, err = ignoremismatch.New(*mismatchIgnoreConfigPath); err != nil {
+			r.restore(prev)
+			return nil, err
+		}
+	}
+	if len(changed) == 0 {
+		log.Infof("Reloaded config file, no reloadable flag changed")
+		return changed, nil
+	}
+	for name, v := range changed {
+		log.Infof("Reloaded config file: %v changed from %q to %q", name, prev[name], v)
+		switch name {
+		case "racing_bias":
+			r.server.SetRacingBias(*racingBias)
+		case "local_resource_fraction":
+			go r.resMgr.SetFraction(r.ctx, *localResourceFraction, resourceReleaseTimeout)
+		case "mismatch_ignore_config_path":
+			r.logger.SetMismatchIgnorer(mi)
+		}
+	}
+	for _, name := range []string{"fail_early_min_action_count", "fail_early_min_fallback_ratio", "fail_early_window"} {
+		if _, ok := changed[name]; ok {
+			r.server.SetFailEarly(*failEarlyMinActionCount, *failEarlyMinFallbackRatio, *failEarlyWindow)
+			break
+		}
+	}
+	r.logger.AddReloadedFlagsToProxyInfo(changed, time.Now())
+	return changed, nil
+}
+
+// restore sets flags back to the given values.
+func (r *configReloader) restore(prev map[string]string) {
+	for name, v := range prev {
+		if err := flag.Set(name, v); err != nil {
+			log.Errorf("Failed to restore flag %v to %q: %v", name, v, err)
+		}
+	}
+}
+
+// reloadOnSIGHUP reloads the config file whenever reproxy receives SIGHUP, until ctx is done.
+func (r *configReloader) reloadOnSIGHUP(ctx context.Context) {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGHUP)
+	defer signal.Stop(sigs)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigs:
+			if _, err := r.reload(); err != nil {
+				log.Errorf("Failed to reload config file: %v", err)
+			}
+		}
+	}
+}
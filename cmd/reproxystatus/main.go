@@ -24,12 +24,18 @@ import (
 	"strings"
 	"time"
 
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/moreflag"
 	"github.com/fatih/color"
 	"github.com/gosuri/uilive"
+	dpb "google.golang.org/protobuf/types/known/durationpb"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/bazelbuild/reclient/internal/pkg/ipc"
 	"github.com/bazelbuild/reclient/internal/pkg/printer"
 	"github.com/bazelbuild/reclient/internal/pkg/rbeflag"
 	"github.com/bazelbuild/reclient/internal/pkg/reproxystatus"
+
+	ppb "github.com/bazelbuild/reclient/api/proxy"
 )
 
 var (
@@ -38,6 +44,22 @@ var (
 			"If empty (default) then all reproxy instances will be dialed")
 	colorize = flag.String("color", "auto", "Control the output color mode; one of (off, on, auto)")
 	watch    = flag.Duration("watch", 0, "If greater than 0, every interval the ternimal will be cleared and the output will be printed.")
+	follow   = flag.Bool("follow", false, "If true, print the start, phase changes and completion of each action of reproxy as they happen. "+
+		"Requires --server_address if several reproxy instances are running.")
+	records = flag.Int("records", 0, "If greater than 0, print up to this number of the most recent action records kept by reproxy "+
+		"that match the --records_* filters, most recent first. reproxy only keeps the number of records given by its --num_records_to_keep flag. "+
+		"Requires --server_address if several reproxy instances are running.")
+	recordsStatus       = flag.String("records_status", "", "Comma-separated completion statuses of the records to print, e.g. remote_failure,local_fallback.")
+	recordsLabels       = map[string]string{}
+	recordsInvocationID = flag.String("records_invocation_id", "", "If set, only print the records of the actions of the invocation with this ID.")
+	recordsSince        = flag.Duration("records_since", 0, "If greater than 0, only print the records of the actions that completed within this duration.")
+	recordsMinDuration  = flag.Duration("records_min_duration", 0, "If greater than 0, only print the records of the actions that took at least this long in reproxy.")
+)
+
+var (
+	runningActions = flag.Int("running_actions", 20, "With --watch, the number of the oldest running actions of each reproxy instance to print along with "+
+		"the length of its local execution queue. If 0, running actions are not printed.")
+	sortRunningBy = flag.String("sort_running_by", "elapsed", fmt.Sprintf("With --watch, the column the running actions are sorted by; one of %v.", reproxystatus.RunningActionsSortKeys()))
 )
 
 var (
@@ -45,6 +67,7 @@ var (
 )
 
 func main() {
+	flag.Var((*moreflag.StringMapValue)(&recordsLabels), "records_labels", "Comma-separated key value pairs in the form key=value. If set, only print the records of the actions with all of these labels.")
 	rbeflag.Parse()
 	switch strings.ToLower(*colorize) {
 	case "off", "false":
@@ -60,11 +83,35 @@ func main() {
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	if *records < 0 {
+		printer.Fatal("ERROR: --records needs to be >= 0")
+	}
+	if *runningActions < 0 {
+		printer.Fatal("ERROR: --running_actions needs to be >= 0")
+	}
+	if err := reproxystatus.SortRunningActions(nil, *sortRunningBy); err != nil {
+		printer.Fatal(fmt.Sprintf("ERROR: invalid --sort_running_by: %v", err))
+	}
+	if *follow {
+		if err := reproxystatus.FollowActions(ctx, color.Output, singleServerAddress(ctx)); err != nil {
+			printer.Fatal(fmt.Sprintf("ERROR: failed to follow the actions of reproxy: %v", err))
+		}
+		return
+	}
+	if *records > 0 {
+		printRecords(ctx)
+		return
+	}
+	// Running actions are only printed in --watch mode.
+	running := 0
+	if *watch > 0 {
+		running = *runningActions
+	}
 	var tracker reproxystatus.ReproxyTracker
 	if *serverAddr != "" {
-		tracker = &reproxystatus.SingleReproxyTracker{ServerAddress: *serverAddr}
+		tracker = &reproxystatus.SingleReproxyTracker{ServerAddress: *serverAddr, RunningActions: running}
 	} else {
-		tracker = &reproxystatus.SocketReproxyTracker{}
+		tracker = &reproxystatus.SocketReproxyTracker{RunningActions: running}
 	}
 	if *watch == 0 {
 		tctx, cancel := context.WithTimeout(ctx, dialTimeout)
@@ -76,9 +123,58 @@ func main() {
 		ticker := time.NewTicker(*watch)
 		for ; true; <-ticker.C {
 			tctx, cancel := context.WithTimeout(ctx, dialTimeout)
-			reproxystatus.PrintSummaries(tctx, writer, tracker)
+			err := reproxystatus.PrintSummariesAndRunningActions(tctx, writer, tracker, *sortRunningBy)
 			cancel()
+			if err != nil {
+				printer.Fatal(fmt.Sprintf("ERROR: invalid --sort_running_by: %v", err))
+			}
 			writer.Flush()
 		}
 	}
 }
+
+// singleServerAddress returns --server_address, or the address of the only running reproxy instance
+// if it is not set.
+func singleServerAddress(ctx context.Context) string {
+	if *serverAddr != "" {
+		return *serverAddr
+	}
+	addrs, err := ipc.GetAllReproxySockets(ctx)
+	if err != nil {
+		printer.Fatal(fmt.Sprintf("ERROR: failed to find reproxy sockets: %v", err))
+	}
+	if len(addrs) != 1 {
+		printer.Fatal(fmt.Sprintf("ERROR: --server_address is required when %d reproxy instances are running", len(addrs)))
+	}
+	return addrs[0]
+}
+
+func printRecords(ctx context.Context) {
+	statuses, err := reproxystatus.ParseCompletionStatuses(*recordsStatus)
+	if err != nil {
+		printer.Fatal(fmt.Sprintf("ERROR: invalid --records_status: %v", err))
+	}
+	req := &ppb.GetRecordsRequest{
+		CompletionStatuses: statuses,
+		Labels:             recordsLabels,
+		InvocationId:       *recordsInvocationID,
+	}
+	if *recordsSince > 0 {
+		req.CompletedAfter = tspb.New(time.Now().Add(-*recordsSince))
+	}
+	if *recordsMinDuration > 0 {
+		req.MinDuration = dpb.New(*recordsMinDuration)
+	}
+	tctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+	recs, err := reproxystatus.FetchRecords(tctx, singleServerAddress(tctx), req, *records)
+	if err != nil {
+		printer.Fatal(fmt.Sprintf("ERROR: failed to get the records of reproxy: %v", err))
+	}
+	if len(recs) == 0 {
+		fmt.Fprintln(color.Output, "No matching records, reproxy only keeps records if --num_records_to_keep is set")
+	}
+	for _, rec := range recs {
+		fmt.Fprintln(color.Output, reproxystatus.FormatRecord(rec))
+	}
+}
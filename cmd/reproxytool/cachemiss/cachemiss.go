@@ -0,0 +1,224 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cachemiss explains cache misses between two builds, by diffing the actions of both
+// builds that produce the same outputs.
+//
+// Arguments and environment variables are only diffed in detail when the records contain the full
+// command, i.e. were logged in the text format. Input files are only diffed by digest when reproxy
+// logged input manifests (--log_input_manifest), otherwise only added and removed inputs are found.
+package cachemiss
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	cpb "github.com/bazelbuild/remote-apis-sdks/go/api/command"
+
+	lpb "github.com/bazelbuild/reclient/api/log"
+)
+
+// ActionDiff describes how an action producing the same outputs differs between two builds.
+type ActionDiff struct {
+	// Output is the first output of the action, relative to the exec root, which identifies it
+	// across builds.
+	Output string
+	// OldDigest and NewDigest are the digests of the action in the old and new builds. They are
+	// empty if the action was not digested, e.g. when it was only executed locally.
+	OldDigest, NewDigest string
+	// Differences are human readable descriptions of what differs between the two actions.
+	Differences []string
+}
+
+// Explain matches the records of two builds by output path, and returns how the actions whose
+// digest changed differ, sorted by output. Actions without digests are returned if they differ.
+func Explain(oldRecs, newRecs []*lpb.LogRecord) []*ActionDiff {
+	byOutput := make(map[string]*lpb.LogRecord)
+	for _, r := range oldRecs {
+		for _, o := range outputs(r.GetCommand()) {
+			byOutput[o] = r
+		}
+	}
+	var diffs []*ActionDiff
+	for _, r := range newRecs {
+		var old *lpb.LogRecord
+		var out string
+		for _, o := range outputs(r.GetCommand()) {
+			if old = byOutput[o]; old != nil {
+				out = o
+				break
+			}
+		}
+		if old == nil {
+			continue
+		}
+		d := &ActionDiff{
+			Output:    out,
+			OldDigest: old.GetRemoteMetadata().GetActionDigest(),
+			NewDigest: r.GetRemoteMetadata().GetActionDigest(),
+		}
+		digested := d.OldDigest != "" && d.NewDigest != ""
+		if digested && d.OldDigest == d.NewDigest {
+			continue
+		}
+		d.Differences = diffRecords(old, r)
+		if len(d.Differences) == 0 {
+			if !digested {
+				continue
+			}
+			d.Differences = []string{"no difference found in the logged command and inputs"}
+		}
+		diffs = append(diffs, d)
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Output < diffs[j].Output })
+	return diffs
+}
+
+// Write writes a report of the given differences to w.
+func Write(w io.Writer, diffs []*ActionDiff) error {
+	for _, d := range diffs {
+		if _, err := fmt.Fprintf(w, "%v: action digest %v -> %v\n", d.Output, orNone(d.OldDigest), orNone(d.NewDigest)); err != nil {
+			return err
+		}
+		for _, l := range d.Differences {
+			if _, err := fmt.Fprintf(w, "  %v\n", l); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := fmt.Fprintf(w, "%v changed actions\n", len(diffs))
+	return err
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+// outputs returns the outputs of a command relative to the exec root.
+func outputs(cmd *cpb.Command) []string {
+	var outs []string
+	for _, paths := range [][]string{cmd.GetOutput().GetOutputFiles(), cmd.GetOutput().GetOutputDirectories()} {
+		for _, o := range paths {
+			outs = append(outs, filepath.Clean(filepath.Join(cmd.GetWorkingDirectory(), o)))
+		}
+	}
+	return outs
+}
+
+// diffRecords returns the differences between the commands and inputs of two records.
+func diffRecords(before, after *lpb.LogRecord) []string {
+	oc, nc := before.GetCommand(), after.GetCommand()
+	om, nm := before.GetInputManifest(), after.GetInputManifest()
+	var diffs []string
+	switch {
+	case len(oc.GetArgs()) > 0 && len(nc.GetArgs()) > 0:
+		diffs = append(diffs, diffLists("arg", oc.GetArgs(), nc.GetArgs(), true)...)
+	case om != nil && nm != nil && om.GetArgsDigest() != nm.GetArgsDigest():
+		diffs = append(diffs, "args changed")
+	}
+	switch {
+	case oc.GetInput() != nil && nc.GetInput() != nil:
+		diffs = append(diffs, diffMaps("env", oc.GetInput().GetEnvironmentVariables(), nc.GetInput().GetEnvironmentVariables())...)
+	case om != nil && nm != nil && om.GetEnvironmentDigest() != nm.GetEnvironmentDigest():
+		diffs = append(diffs, "environment variables changed")
+	}
+	diffs = append(diffs, diffMaps("platform", oc.GetPlatform(), nc.GetPlatform())...)
+	if o, n := oc.GetWorkingDirectory(), nc.GetWorkingDirectory(); o != n {
+		diffs = append(diffs, fmt.Sprintf("working directory: %q -> %q", o, n))
+	}
+	if o, n := oc.GetRemoteWorkingDirectory(), nc.GetRemoteWorkingDirectory(); o != n {
+		diffs = append(diffs, fmt.Sprintf("remote working directory: %q -> %q", o, n))
+	}
+	if o, n := oc.GetExecutionTimeout(), nc.GetExecutionTimeout(); o != n {
+		diffs = append(diffs, fmt.Sprintf("execution timeout: %vs -> %vs", o, n))
+	}
+	diffs = append(diffs, diffLists("output", outputs(oc), outputs(nc), false)...)
+	switch {
+	case om != nil && nm != nil:
+		diffs = append(diffs, diffMaps("input", om.GetFileDigests(), nm.GetFileDigests())...)
+	case oc.GetInput() != nil && nc.GetInput() != nil:
+		diffs = append(diffs, diffLists("input", oc.GetInput().GetInputs(), nc.GetInput().GetInputs(), false)...)
+	}
+	return diffs
+}
+
+// diffMaps describes the keys added, removed and changed between two maps, in key order.
+func diffMaps(kind string, before, after map[string]string) []string {
+	keys := make(map[string]bool)
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+	var diffs []string
+	for _, k := range sorted {
+		o, inBefore := before[k]
+		n, inAfter := after[k]
+		switch {
+		case !inBefore:
+			diffs = append(diffs, fmt.Sprintf("%v %v: added %q", kind, k, n))
+		case !inAfter:
+			diffs = append(diffs, fmt.Sprintf("%v %v: removed %q", kind, k, o))
+		case o != n:
+			diffs = append(diffs, fmt.Sprintf("%v %v: %q -> %q", kind, k, o, n))
+		}
+	}
+	return diffs
+}
+
+// diffLists describes the elements added to and removed from a list. If ordered is set, a change of
+// order is also reported.
+func diffLists(kind string, before, after []string, ordered bool) []string {
+	counts := make(map[string]int)
+	for _, o := range before {
+		counts[o]++
+	}
+	for _, n := range after {
+		counts[n]--
+	}
+	var diffs []string
+	// Iterate over the lists rather than the map to report elements in a stable order.
+	for _, o := range before {
+		if counts[o] > 0 {
+			diffs = append(diffs, fmt.Sprintf("%v removed: %q", kind, o))
+			counts[o]--
+		}
+	}
+	for _, n := range after {
+		if counts[n] < 0 {
+			diffs = append(diffs, fmt.Sprintf("%v added: %q", kind, n))
+			counts[n]++
+		}
+	}
+	if len(diffs) > 0 || !ordered {
+		return diffs
+	}
+	for i := range before {
+		if before[i] != after[i] {
+			return []string{fmt.Sprintf("%vs reordered from position %v", kind, i)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cachemiss
+
+import (
+	"strings"
+	"testing"
+
+	cpb "github.com/bazelbuild/remote-apis-sdks/go/api/command"
+	"github.com/google/go-cmp/cmp"
+
+	lpb "github.com/bazelbuild/reclient/api/log"
+)
+
+func record(out, dg string, args []string, env, platform, inputs map[string]string) *lpb.LogRecord {
+	return &lpb.LogRecord{
+		Command: &cpb.Command{
+			Args:             args,
+			WorkingDirectory: "out",
+			Platform:         platform,
+			Input:            &cpb.InputSpec{EnvironmentVariables: env},
+			Output:           &cpb.OutputSpec{OutputFiles: []string{out}},
+		},
+		RemoteMetadata: &lpb.RemoteMetadata{ActionDigest: dg},
+		InputManifest:  &lpb.InputManifest{FileDigests: inputs},
+	}
+}
+
+func TestExplain(t *testing.T) {
+	args := []string{"clang", "-c", "foo.cc", "-o", "foo.o"}
+	env := map[string]string{"PWD": "/proc/self/cwd"}
+	platform := map[string]string{"container-image": "docker://image@sha256:1"}
+	inputs := map[string]string{"foo.cc": "a/1", "foo.h": "b/1", "bar.h": "c/1"}
+	oldRecs := []*lpb.LogRecord{
+		record("foo.o", "aaa/1", args, env, platform, inputs),
+		record("bar.o", "bbb/1", args, env, platform, inputs),
+		record("same.o", "ccc/1", args, env, platform, inputs),
+		record("reordered.o", "ddd/1", args, env, platform, inputs),
+		record("removed.o", "eee/1", args, env, platform, inputs),
+	}
+	newRecs := []*lpb.LogRecord{
+		record("foo.o", "aaa/2", []string{"clang", "-c", "foo.cc", "-o", "foo.o", "-O2"}, env,
+			map[string]string{"container-image": "docker://image@sha256:2", "Pool": "large"},
+			map[string]string{"foo.cc": "a/1", "foo.h": "b/2", "baz.h": "d/1"}),
+		record("bar.o", "bbb/2", args, map[string]string{"PWD": "/"}, platform, inputs),
+		record("same.o", "ccc/1", []string{"different"}, env, platform, inputs),
+		record("reordered.o", "ddd/2", []string{"clang", "-c", "foo.o", "-o", "foo.cc"}, env, platform, inputs),
+		record("added.o", "fff/1", args, env, platform, inputs),
+	}
+	want := []*ActionDiff{
+		{
+			Output:      "out/bar.o",
+			OldDigest:   "bbb/1",
+			NewDigest:   "bbb/2",
+			Differences: []string{`env PWD: "/proc/self/cwd" -> "/"`},
+		},
+		{
+			Output:    "out/foo.o",
+			OldDigest: "aaa/1",
+			NewDigest: "aaa/2",
+			Differences: []string{
+				`arg added: "-O2"`,
+				`platform Pool: added "large"`,
+				`platform container-image: "docker://image@sha256:1" -> "docker://image@sha256:2"`,
+				`input bar.h: removed "c/1"`,
+				`input baz.h: added "d/1"`,
+				`input foo.h: "b/1" -> "b/2"`,
+			},
+		},
+		{
+			Output:      "out/reordered.o",
+			OldDigest:   "ddd/1",
+			NewDigest:   "ddd/2",
+			Differences: []string{"args reordered from position 2"},
+		},
+	}
+	got := Explain(oldRecs, newRecs)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Explain() returned diff: (-want +got)\n%s", diff)
+	}
+}
+
+func TestExplainReducedRecords(t *testing.T) {
+	reduced := func(dg, argsDg, envDg string, inputs []string) *lpb.LogRecord {
+		r := &lpb.LogRecord{
+			Command: &cpb.Command{
+				Output: &cpb.OutputSpec{OutputFiles: []string{"foo.o"}},
+			},
+			RemoteMetadata: &lpb.RemoteMetadata{ActionDigest: dg},
+		}
+		if argsDg != "" {
+			r.InputManifest = &lpb.InputManifest{ArgsDigest: argsDg, EnvironmentDigest: envDg}
+		}
+		if inputs != nil {
+			r.Command.Input = &cpb.InputSpec{Inputs: inputs}
+		}
+		return r
+	}
+	tests := []struct {
+		name     string
+		old, new *lpb.LogRecord
+		want     []string
+	}{
+		{
+			name: "ManifestDigests",
+			old:  reduced("aaa/1", "args/1", "env/1", nil),
+			new:  reduced("aaa/2", "args/2", "env/2", nil),
+			want: []string{"args changed", "environment variables changed"},
+		},
+		{
+			name: "InputPaths",
+			old:  reduced("aaa/1", "", "", []string{"foo.cc", "foo.h"}),
+			new:  reduced("aaa/2", "", "", []string{"foo.cc", "bar.h"}),
+			want: []string{`input removed: "foo.h"`, `input added: "bar.h"`},
+		},
+		{
+			name: "NothingLogged",
+			old:  reduced("aaa/1", "", "", nil),
+			new:  reduced("aaa/2", "", "", nil),
+			want: []string{"no difference found in the logged command and inputs"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Explain([]*lpb.LogRecord{tc.old}, []*lpb.LogRecord{tc.new})
+			if len(got) != 1 {
+				t.Fatalf("Explain() returned %v diffs, want 1", len(got))
+			}
+			if diff := cmp.Diff(tc.want, got[0].Differences); diff != "" {
+				t.Errorf("Explain() returned diff in differences: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	diffs := []*ActionDiff{
+		{
+			Output:      "out/foo.o",
+			OldDigest:   "aaa/1",
+			Differences: []string{`arg added: "-O2"`, `input foo.h: "b/1" -> "b/2"`},
+		},
+	}
+	var sb strings.Builder
+	if err := Write(&sb, diffs); err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+	want := "out/foo.o: action digest aaa/1 -> <none>\n" +
+		"  arg added: \"-O2\"\n" +
+		"  input foo.h: \"b/1\" -> \"b/2\"\n" +
+		"1 changed actions\n"
+	if got := sb.String(); got != want {
+		t.Errorf("Write() wrote %q, want %q", got, want)
+	}
+}
@@ -24,15 +24,23 @@
 // bazelisk run //cmd/reproxytool:reproxytool -- \
 // --operation=usage_to_csv --log_path=/tmp/reproxy.INFO \
 // --alsologtostderr
+//
+// Explain why actions missed the cache in a build compared to a previous build:
+// bazelisk run //cmd/reproxytool:reproxytool -- \
+// --operation=explain_cache_misses --old_log_dir=/tmp/build1 \
+// --new_log_dir=/tmp/build2 --alsologtostderr
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"path"
 
+	"github.com/bazelbuild/reclient/cmd/reproxytool/cachemiss"
 	csv "github.com/bazelbuild/reclient/cmd/reproxytool/usage2csv"
+	"github.com/bazelbuild/reclient/internal/pkg/logger"
 	log "github.com/golang/glog"
 )
 
@@ -40,16 +48,21 @@ import (
 type OpType string
 
 const (
-	usage2CSV OpType = "usage_to_csv"
+	usage2CSV          OpType = "usage_to_csv"
+	explainCacheMisses OpType = "explain_cache_misses"
 )
 
 var supportedOps = []OpType{
 	usage2CSV,
+	explainCacheMisses,
 }
 
 var (
 	operation = flag.String("operation", "", fmt.Sprintf("Specifies the operation to perform. Supported values: %v", supportedOps))
 	logPath   = flag.String("log_path", "", "Path to log file. E.g., /tmp/reproxy.INFO")
+	oldLogDir = flag.String("old_log_dir", "", "Directory of the reproxy logs of the build to compare against, for explain_cache_misses.")
+	newLogDir = flag.String("new_log_dir", "", "Directory of the reproxy logs of the build with cache misses, for explain_cache_misses.")
+	logFormat = flag.String("log_format", "reducedtext", "Format of the reproxy logs, for explain_cache_misses. Arguments and environment variables are only diffed in detail in the text format.")
 )
 
 func main() {
@@ -66,6 +79,10 @@ func main() {
 		if err := csv.Usage2CSV(getLogPathFlag()); err != nil {
 			log.Exitf("Error parsing usage data from reproxy.INFO, %v,to CSV file %v", logPath, err)
 		}
+	case explainCacheMisses:
+		if err := explain(); err != nil {
+			log.Exitf("Error explaining cache misses: %v", err)
+		}
 	default:
 		log.Exitf("Unsupported operation %v. Supported operations:\n%v", *operation, supportedOps)
 	}
@@ -77,3 +94,22 @@ func getLogPathFlag() string {
 	}
 	return *logPath
 }
+
+func explain() error {
+	if *oldLogDir == "" || *newLogDir == "" {
+		return errors.New("--old_log_dir and --new_log_dir must be specified")
+	}
+	format, err := logger.ParseFormat(*logFormat)
+	if err != nil {
+		return err
+	}
+	oldRecs, _, err := logger.ParseFromLogDirs(format, []string{*oldLogDir})
+	if err != nil {
+		return fmt.Errorf("failed to parse the logs in %v: %w", *oldLogDir, err)
+	}
+	newRecs, _, err := logger.ParseFromLogDirs(format, []string{*newLogDir})
+	if err != nil {
+		return fmt.Errorf("failed to parse the logs in %v: %w", *newLogDir, err)
+	}
+	return cachemiss.Write(os.Stdout, cachemiss.Explain(oldRecs, newRecs))
+}
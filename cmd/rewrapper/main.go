@@ -31,9 +31,11 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"path"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/bazelbuild/reclient/internal/pkg/ipc"
@@ -88,6 +90,10 @@ func initFlags() {
 	flag.BoolVar(&cOpts.LogEnvironment, "log_env", false, "Boolean indicating whether to pass the entire environment of the rewrapper to the reproxy for logging. Default is false.")
 	flag.BoolVar(&cOpts.PreserveUnchangedOutputMtime, "preserve_unchanged_output_mtime", false, "Boolean indicating whether or not to preserve mtimes of unchanged outputs when they are downloaded. Default is false.")
 	flag.StringVar(&cOpts.LocalWrapper, "local_wrapper", "", "Wrapper path to execute locally only. Relative to the current working directory of rewrapper.")
+	flag.BoolVar(&cOpts.LocalSandbox, "local_sandbox", false, "Boolean indicating whether to run the command in a Linux user and mount namespace sandbox in which only its inputs and outputs are visible under the exec root when it is executed locally. Default is false.")
+	flag.IntVar(&cOpts.LocalCPUs, "local_cpus", 0, "Number of CPUs reserved for the command when it is executed locally. Defaults to a value determined by reproxy from the labels of the command.")
+	flag.IntVar(&cOpts.LocalRAMMBs, "local_ram_mb", 0, "Amount of RAM in megabytes reserved for the command when it is executed locally. Defaults to a value determined by reproxy from the labels of the command.")
+	flag.DurationVar(&cOpts.LocalExecTimeout, "local_exec_timeout", 0, "Timeout for the command when it is executed locally, excluding the time spent waiting for local resources. When it expires, the processes of the command are killed. Default is no timeout.")
 	flag.StringVar(&cOpts.RemoteWrapper, "remote_wrapper", "", "Wrapper path to execute on remote worker. Relative to the current working directory of rewrapper.")
 	dialTimeout = flag.Duration("dial_timeout", 3*time.Minute, "Timeout for dialing reproxy. Default is 3 minutes.")
 	flag.BoolVar(&cOpts.PreserveSymlink, "preserve_symlink", false, "Boolean indicating whether to preserve symlinks in input tree. Default is false.")
@@ -173,7 +179,10 @@ func main() {
 
 	// TODO (b/296409009): Add support for preserve true and download outputs false for downloading stubs.
 
-	resp, err := rewrapper.RunCommand(ctx, *dialTimeout, proxy, cmd, cOpts)
+	// Cancel the command in reproxy if rewrapper is interrupted, e.g. when the build is aborted.
+	sigCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	resp, err := rewrapper.RunCommandStream(sigCtx, *dialTimeout, proxy, cmd, cOpts, os.Stdout, os.Stderr)
 	if err != nil {
 		// Don't use log.Fatalf to avoid printing a stack trace.
 		log.Exitf("Command failed: %v", err)
@@ -183,8 +192,6 @@ func main() {
 			log.Errorf("Failed to write reproxy action log %v", cOpts.ActionLog)
 		}
 	}
-	os.Stdout.Write(resp.GetStdout())
-	os.Stderr.Write(resp.GetStderr())
 	log.Flush()
 	os.Exit(int(resp.GetResult().GetExitCode()))
 }
@@ -0,0 +1,351 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	evt "github.com/bazelbuild/reclient/internal/pkg/event"
+
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	lpb "github.com/bazelbuild/reclient/api/log"
+	ppb "github.com/bazelbuild/reclient/api/perfetto"
+)
+
+const (
+	// tracePacketField is the field number of the packets of a Perfetto trace. A trace is written
+	// one packet at a time, as a sequence of packet fields.
+	tracePacketField = 1
+	// sequenceID is the ID of the only sequence of packets of the trace.
+	sequenceID = 1
+	// counterTrack is set in the UUIDs of counter tracks, to keep them apart from slot tracks.
+	counterTrack = 1 << 63
+)
+
+// threadNames are the names of the tracks of the tids of the events of a slot.
+var threadNames = map[int]string{
+	0:         "Command Request",
+	localTID:  "Local",
+	remoteTID: "Remote",
+	workerTID: "Worker",
+}
+
+// resourceUnits are the units of the resource usage values that are not a percentage or a size.
+var resourceUnits = map[string]ppb.CounterDescriptor_Unit{
+	"PEAK_NUM_ACTIONS": ppb.CounterDescriptor_UNIT_COUNT,
+}
+
+// counterValue is the value of a counter from a time, in nano seconds.
+type counterValue struct {
+	ts    int64
+	value int64
+}
+
+// counter is a counter track of the trace.
+type counter struct {
+	name   string
+	unit   ppb.CounterDescriptor_Unit
+	values []counterValue
+}
+
+// trackEvent is an event of a Perfetto trace: the begin or the end of the slice of an event, or
+// the value of a counter.
+type trackEvent struct {
+	ts    int64 // nano seconds
+	typ   ppb.TrackEvent_Type
+	track uint64
+	ev    *event // set for slice begins
+	value int64  // set for counter values
+}
+
+// slotTrack returns the UUID of the track of a slot, i.e. of a pid of the events.
+func slotTrack(pid int) uint64 {
+	return uint64(pid) << 3
+}
+
+// threadTrack returns the UUID of the track of a tid of the events of a slot, which is nested
+// under the track of the slot.
+func threadTrack(pid, tid int) uint64 {
+	return slotTrack(pid) | uint64(tid+1)
+}
+
+// convertPerfetto writes the events of the log records as a Perfetto trace, along with counter
+// tracks of the occupancy of the local execution pool and of the resource usage samples of the
+// proxy infos. The slices of the local and remote executions of an action that was raced are
+// linked by a flow.
+func convertPerfetto(ctx context.Context, logs []*lpb.LogRecord, infos []*lpb.ProxyInfo, level int, fname string) ([]event, error) {
+	events := convertLogRecords(ctx, logs, level)
+	counters := []counter{
+		{name: "Local pool: running actions", unit: ppb.CounterDescriptor_UNIT_COUNT, values: occupancy(logs, evt.LocalCommandExecution)},
+		{name: "Local pool: queued actions", unit: ppb.CounterDescriptor_UNIT_COUNT, values: occupancy(logs, evt.LocalCommandQueued)},
+	}
+	counters = append(counters, resourceCounters(infos)...)
+	f, err := os.Create(fname)
+	if err != nil {
+		return events, err
+	}
+	w := bufio.NewWriter(f)
+	if err := writePerfetto(w, events, counters); err != nil {
+		f.Close()
+		return events, err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return events, err
+	}
+	return events, f.Close()
+}
+
+// occupancy returns the number of actions in the local event with the given name over time.
+func occupancy(logs []*lpb.LogRecord, name string) []counterValue {
+	type change struct {
+		ts    int64
+		delta int64
+	}
+	var changes []change
+	for _, rec := range logs {
+		et := rec.GetLocalMetadata().GetEventTimes()[name]
+		if et.GetFrom() == nil || et.GetTo() == nil {
+			continue
+		}
+		changes = append(changes,
+			change{ts: et.GetFrom().AsTime().UnixNano(), delta: 1},
+			change{ts: et.GetTo().AsTime().UnixNano(), delta: -1})
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].ts < changes[j].ts
+	})
+	var values []counterValue
+	var n int64
+	for i, c := range changes {
+		n += c.delta
+		if i+1 < len(changes) && changes[i+1].ts == c.ts {
+			continue
+		}
+		values = append(values, counterValue{ts: c.ts, value: n})
+	}
+	return values
+}
+
+// resourceCounters returns a counter for each resource usage value sampled by the reproxy of each
+// of the proxy infos.
+func resourceCounters(infos []*lpb.ProxyInfo) []counter {
+	var counters []counter
+	for i, info := range infos {
+		byName := make(map[string]*counter)
+		var names []string
+		for _, s := range info.GetResourceUsageSamples() {
+			ts := s.GetTime().AsTime().UnixNano()
+			for name, v := range s.GetValues() {
+				c, ok := byName[name]
+				if !ok {
+					c = &counter{name: name, unit: resourceUnits[name]}
+					if len(infos) > 1 {
+						c.name = fmt.Sprintf("%s (reproxy %d)", name, i+1)
+					}
+					byName[name] = c
+					names = append(names, name)
+				}
+				c.values = append(c.values, counterValue{ts: ts, value: v})
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			counters = append(counters, *byName[name])
+		}
+	}
+	return counters
+}
+
+// sliceEvents returns the begins and ends of the slices of the events, in the order of their
+// time on each track.
+func sliceEvents(events []event) []trackEvent {
+	byTrack := make(map[uint64][]*event)
+	var tracks []uint64
+	for i := range events {
+		e := &events[i]
+		t := threadTrack(e.Pid, e.Tid)
+		if _, ok := byTrack[t]; !ok {
+			tracks = append(tracks, t)
+		}
+		byTrack[t] = append(byTrack[t], e)
+	}
+	var tes []trackEvent
+	for _, t := range tracks {
+		evs := byTrack[t]
+		sort.SliceStable(evs, func(i, j int) bool {
+			if evs[i].Timestamp != evs[j].Timestamp {
+				return evs[i].Timestamp < evs[j].Timestamp
+			}
+			return evs[i].Dur > evs[j].Dur
+		})
+		// ends are the end times of the open slices, from the outermost to the innermost.
+		var ends []int64
+		for _, e := range evs {
+			start := e.Timestamp * 1e3
+			end := (e.Timestamp + e.Dur) * 1e3
+			for len(ends) > 0 && ends[len(ends)-1] <= start {
+				tes = append(tes, trackEvent{ts: ends[len(ends)-1], typ: ppb.TrackEvent_TYPE_SLICE_END, track: t})
+				ends = ends[:len(ends)-1]
+			}
+			// Slices of a track must nest, so a slice overlapping the end of the slice it starts in
+			// is cut at that end.
+			if len(ends) > 0 && end > ends[len(ends)-1] {
+				end = ends[len(ends)-1]
+			}
+			tes = append(tes, trackEvent{ts: start, typ: ppb.TrackEvent_TYPE_SLICE_BEGIN, track: t, ev: e})
+			ends = append(ends, end)
+		}
+		for i := len(ends) - 1; i >= 0; i-- {
+			tes = append(tes, trackEvent{ts: ends[i], typ: ppb.TrackEvent_TYPE_SLICE_END, track: t})
+		}
+	}
+	return tes
+}
+
+// writePerfetto writes the descriptors of the tracks of the events and counters, then their
+// track events in the order of their time.
+func writePerfetto(w io.Writer, events []event, counters []counter) error {
+	var buf []byte
+	write := func(p *ppb.TracePacket) error {
+		p.OptionalTrustedPacketSequenceId = &ppb.TracePacket_TrustedPacketSequenceId{TrustedPacketSequenceId: sequenceID}
+		b, err := proto.Marshal(p)
+		if err != nil {
+			return err
+		}
+		buf = protowire.AppendTag(buf[:0], tracePacketField, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, b)
+		_, err = w.Write(buf)
+		return err
+	}
+
+	var descs []*ppb.TrackDescriptor
+	slots := make(map[int]bool)
+	threads := make(map[uint64]bool)
+	for _, e := range events {
+		if !slots[e.Pid] {
+			slots[e.Pid] = true
+			descs = append(descs, &ppb.TrackDescriptor{
+				Uuid: proto.Uint64(slotTrack(e.Pid)),
+				Name: proto.String(fmt.Sprintf("Slot %d", e.Pid)),
+			})
+		}
+		if t := threadTrack(e.Pid, e.Tid); !threads[t] {
+			threads[t] = true
+			descs = append(descs, &ppb.TrackDescriptor{
+				Uuid:       proto.Uint64(t),
+				ParentUuid: proto.Uint64(slotTrack(e.Pid)),
+				Name:       proto.String(threadNames[e.Tid]),
+			})
+		}
+	}
+	sort.SliceStable(descs, func(i, j int) bool {
+		return descs[i].GetUuid() < descs[j].GetUuid()
+	})
+	tes := sliceEvents(events)
+	for i, c := range counters {
+		t := counterTrack | uint64(i)
+		descs = append(descs, &ppb.TrackDescriptor{
+			Uuid:    proto.Uint64(t),
+			Name:    proto.String(c.name),
+			Counter: &ppb.CounterDescriptor{Unit: c.unit.Enum()},
+		})
+		for _, v := range c.values {
+			tes = append(tes, trackEvent{ts: v.ts, typ: ppb.TrackEvent_TYPE_COUNTER, track: t, value: v.value})
+		}
+	}
+	for i, d := range descs {
+		p := &ppb.TracePacket{Data: &ppb.TracePacket_TrackDescriptor{TrackDescriptor: d}}
+		if i == 0 {
+			p.SequenceFlags = proto.Uint32(uint32(ppb.TracePacket_SEQ_INCREMENTAL_STATE_CLEARED))
+		}
+		if err := write(p); err != nil {
+			return err
+		}
+	}
+
+	// The track events of each track are already in the order of their time, which a stable sort
+	// keeps for the begins and ends of slices at the same time.
+	sort.SliceStable(tes, func(i, j int) bool {
+		return tes[i].ts < tes[j].ts
+	})
+	for _, te := range tes {
+		if err := write(&ppb.TracePacket{
+			Timestamp: proto.Uint64(uint64(te.ts)),
+			Data:      &ppb.TracePacket_TrackEvent{TrackEvent: trackEventProto(te)},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func trackEventProto(te trackEvent) *ppb.TrackEvent {
+	pte := &ppb.TrackEvent{
+		Type:      te.typ.Enum(),
+		TrackUuid: proto.Uint64(te.track),
+	}
+	switch te.typ {
+	case ppb.TrackEvent_TYPE_COUNTER:
+		pte.CounterValueField = &ppb.TrackEvent_CounterValue{CounterValue: te.value}
+	case ppb.TrackEvent_TYPE_SLICE_BEGIN:
+		pte.NameField = &ppb.TrackEvent_Name{Name: te.ev.Name}
+		pte.Categories = []string{te.ev.Cat}
+		pte.DebugAnnotations = debugAnnotations(te.ev.Args)
+		if te.ev.flow != 0 {
+			pte.FlowIds = []uint64{te.ev.flow}
+		}
+	}
+	return pte
+}
+
+// debugAnnotations returns the non-empty args of an event as debug annotations, with protos in the
+// text format.
+func debugAnnotations(args map[string]interface{}) []*ppb.DebugAnnotation {
+	keys := make([]string, 0, len(args))
+	for k := range args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var das []*ppb.DebugAnnotation
+	for _, k := range keys {
+		var v string
+		switch a := args[k].(type) {
+		case proto.Message:
+			if !a.ProtoReflect().IsValid() {
+				continue
+			}
+			v = prototext.MarshalOptions{}.Format(a)
+		default:
+			v = fmt.Sprint(a)
+		}
+		if v == "" {
+			continue
+		}
+		das = append(das, &ppb.DebugAnnotation{
+			NameField: &ppb.DebugAnnotation_Name{Name: k},
+			Value:     &ppb.DebugAnnotation_StringValue{StringValue: v},
+		})
+	}
+	return das
+}
@@ -0,0 +1,171 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	evt "github.com/bazelbuild/reclient/internal/pkg/event"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+
+	lpb "github.com/bazelbuild/reclient/api/log"
+	ppb "github.com/bazelbuild/reclient/api/perfetto"
+
+	cpb "github.com/bazelbuild/remote-apis-sdks/go/api/command"
+)
+
+var testStart = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func at(ms int) time.Time {
+	return testStart.Add(time.Duration(ms) * time.Millisecond)
+}
+
+func interval(from, to int) *cpb.TimeInterval {
+	return &cpb.TimeInterval{From: tspb.New(at(from)), To: tspb.New(at(to))}
+}
+
+func TestConvertPerfetto(t *testing.T) {
+	logs := []*lpb.LogRecord{
+		{
+			Command: &cpb.Command{
+				Identifiers: &cpb.Identifiers{CommandId: "raced"},
+				Output:      &cpb.OutputSpec{OutputFiles: []string{"raced.o"}},
+			},
+			CompletionStatus: lpb.CompletionStatus_STATUS_RACING_REMOTE,
+			LocalMetadata: &lpb.LocalMetadata{
+				EventTimes: map[string]*cpb.TimeInterval{
+					evt.LocalCommandQueued:    interval(0, 10),
+					evt.LocalCommandExecution: interval(10, 50),
+				},
+			},
+			RemoteMetadata: &lpb.RemoteMetadata{
+				EventTimes: map[string]*cpb.TimeInterval{
+					command.EventExecuteRemotely: interval(5, 40),
+					command.EventServerWorker:    interval(10, 30),
+				},
+			},
+		},
+		{
+			Command: &cpb.Command{
+				Identifiers: &cpb.Identifiers{CommandId: "local"},
+				Output:      &cpb.OutputSpec{OutputFiles: []string{"local.o"}},
+			},
+			CompletionStatus: lpb.CompletionStatus_STATUS_LOCAL_EXECUTION,
+			LocalMetadata: &lpb.LocalMetadata{
+				EventTimes: map[string]*cpb.TimeInterval{
+					evt.LocalCommandExecution: interval(20, 30),
+				},
+			},
+		},
+	}
+	infos := []*lpb.ProxyInfo{{
+		ResourceUsageSamples: []*lpb.ResourceUsageSample{
+			{Time: tspb.New(at(0)), Values: map[string]int64{"CPU_pct": 10, "PEAK_NUM_ACTIONS": 1}},
+			{Time: tspb.New(at(3000)), Values: map[string]int64{"CPU_pct": 90, "PEAK_NUM_ACTIONS": 2}},
+		},
+	}}
+	fname := filepath.Join(t.TempDir(), "trace.perfetto-trace")
+	if _, err := convertPerfetto(context.Background(), logs, infos, workerTID, fname); err != nil {
+		t.Fatalf("convertPerfetto() returned error: %v", err)
+	}
+	b, err := os.ReadFile(fname)
+	if err != nil {
+		t.Fatalf("Failed to read %v: %v", fname, err)
+	}
+	trace := &ppb.Trace{}
+	if err := proto.Unmarshal(b, trace); err != nil {
+		t.Fatalf("convertPerfetto() wrote an invalid trace: %v", err)
+	}
+
+	tracks := make(map[uint64]*ppb.TrackDescriptor)
+	open := make(map[uint64]int)
+	counters := make(map[string][]int64)
+	flows := make(map[uint64][]string)
+	var last uint64
+	for _, p := range trace.GetPacket() {
+		if p.GetTrustedPacketSequenceId() != sequenceID {
+			t.Errorf("convertPerfetto() wrote packet %v without sequence ID %d", p, sequenceID)
+		}
+		if d := p.GetTrackDescriptor(); d != nil {
+			tracks[d.GetUuid()] = d
+			continue
+		}
+		te := p.GetTrackEvent()
+		if _, ok := tracks[te.GetTrackUuid()]; !ok {
+			t.Fatalf("convertPerfetto() wrote event %v on undescribed track", te)
+		}
+		if p.GetTimestamp() < last {
+			t.Errorf("convertPerfetto() wrote event %v at %d after an event at %d", te, p.GetTimestamp(), last)
+		}
+		last = p.GetTimestamp()
+		switch te.GetType() {
+		case ppb.TrackEvent_TYPE_SLICE_BEGIN:
+			open[te.GetTrackUuid()]++
+			for _, id := range te.GetFlowIds() {
+				flows[id] = append(flows[id], te.GetName())
+			}
+		case ppb.TrackEvent_TYPE_SLICE_END:
+			if open[te.GetTrackUuid()] == 0 {
+				t.Errorf("convertPerfetto() wrote end of slice at %d without a begin", p.GetTimestamp())
+			}
+			open[te.GetTrackUuid()]--
+		case ppb.TrackEvent_TYPE_COUNTER:
+			name := tracks[te.GetTrackUuid()].GetName()
+			counters[name] = append(counters[name], te.GetCounterValue())
+		}
+	}
+	for uuid, n := range open {
+		if n != 0 {
+			t.Errorf("convertPerfetto() left %d slices open on track %q", n, tracks[uuid].GetName())
+		}
+	}
+	wantCounters := map[string][]int64{
+		"Local pool: running actions": {1, 2, 1, 0},
+		"Local pool: queued actions":  {1, 0},
+		"CPU_pct":                     {10, 90},
+		"PEAK_NUM_ACTIONS":            {1, 2},
+	}
+	if diff := cmp.Diff(wantCounters, counters); diff != "" {
+		t.Errorf("convertPerfetto() wrote counters with diff: (-want +got)\n%s", diff)
+	}
+	if len(flows) != 1 {
+		t.Fatalf("convertPerfetto() wrote flows %v, want one linking the raced executions", flows)
+	}
+	for _, names := range flows {
+		if diff := cmp.Diff([]string{"raced.o - Local", "raced.o - Remote"}, names); diff != "" {
+			t.Errorf("convertPerfetto() wrote flow with diff in slices: (-want +got)\n%s", diff)
+		}
+	}
+	var threads []string
+	for _, d := range tracks {
+		if d.GetParentUuid() != 0 {
+			threads = append(threads, tracks[d.GetParentUuid()].GetName()+"/"+d.GetName())
+		}
+	}
+	sort.Strings(threads)
+	if got, want := strings.Join(threads, ","), "Slot 1/Local,Slot 1/Remote,Slot 1/Worker,Slot 2/Local"; got != want {
+		t.Errorf("convertPerfetto() wrote slot tracks %v, want %v", got, want)
+	}
+}
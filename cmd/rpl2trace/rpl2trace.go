@@ -17,6 +17,15 @@
 //
 //	$ rpl2trace --log_path /tmp/reproxy_log.rpl --output trace.json
 //
+// With --output_format=perfetto, it writes a Perfetto protobuf trace instead,
+// which is much smaller than the json file for large builds and can only be
+// loaded in https://ui.perfetto.dev. The Perfetto trace also has counter tracks
+// of the occupancy of the local execution pool and, when reading from
+// --proxy_log_dir, of the resource usage of reproxy, and links the local and
+// remote executions of raced actions with flow arrows.
+//
+//	$ rpl2trace --proxy_log_dir /tmp --output_format perfetto --output trace.perfetto-trace
+//
 // This binary also works with *.rrpl file.
 //
 //	$ bazelisk run //cmd/rpl2trace --config=remotelinux -- \
@@ -49,6 +58,7 @@ var (
 	logFormat      = flag.String("log_format", "text", "Format of proxy log. Currently only text is supported.")
 	outputFilename = flag.String("output", "trace.json", "output filename")
 	traceLevel     = flag.Int("trace_level", 3, "trace level. 0=cmd req. 1=local only. 2=local+remote, 3=local+remote+worker")
+	outputFormat   = flag.String("output_format", "json", "Format of the trace. json for Chrome JSON trace events, perfetto for a Perfetto protobuf trace.")
 )
 
 // https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU/preview
@@ -68,6 +78,9 @@ type event struct {
 	Pid       int                    `json:"pid"`
 	Tid       int                    `json:"tid"`
 	Args      map[string]interface{} `json:"args"`
+
+	// flow is the ID of the flow linking the slice to the other executions of a raced action, or 0.
+	flow uint64
 }
 
 func convertEventTime(cat string, pid, tid int, ets map[string]*cpb.TimeInterval, args map[string]interface{}, level int) (events []event, start, end time.Time) {
@@ -186,8 +199,15 @@ func convertLogRecord(ctx context.Context, log *lpb.LogRecord, level int) ([]eve
 func convertLogRecords(ctx context.Context, logs []*lpb.LogRecord, level int) []event {
 	var events []event
 	var running []time.Time
-	for _, log := range logs {
+	for n, log := range logs {
 		evs, from, to := convertLogRecord(ctx, log, level)
+		if cs := log.GetCompletionStatus(); cs == lpb.CompletionStatus_STATUS_RACING_LOCAL || cs == lpb.CompletionStatus_STATUS_RACING_REMOTE {
+			for i := range evs {
+				if evs[i].Cat == "Local" || evs[i].Cat == "Remote" {
+					evs[i].flow = uint64(n + 1)
+				}
+			}
+		}
 		pid := 0
 		for i, r := range running {
 			if r.Before(from) {
@@ -229,12 +249,16 @@ func main() {
 	flag.Var((*moreflag.StringListValue)(&proxyLogDir), "proxy_log_dir", "If provided, the directory path to a proxy log file of executed records.")
 	rbeflag.Parse()
 	var logRecords []*lpb.LogRecord
+	var proxyInfos []*lpb.ProxyInfo
 	var err error
 
 	format, err := logger.ParseFormat(*logFormat)
 	if err != nil {
 		log.Fatal(err)
 	}
+	if *outputFormat != "json" && *outputFormat != "perfetto" {
+		log.Fatalf("Unknown output format %q, must be json or perfetto.", *outputFormat)
+	}
 
 	switch {
 	case *logPath != "":
@@ -247,7 +271,7 @@ func main() {
 	case len(proxyLogDir) > 0:
 		fmt.Printf("Loading log from %v %q...\n", format, proxyLogDir)
 		log.Infof("Loading log from %v %q...", format, proxyLogDir)
-		logRecords, _, err = logger.ParseFromLogDirs(format, proxyLogDir)
+		logRecords, proxyInfos, err = logger.ParseFromLogDirs(format, proxyLogDir)
 		if err != nil {
 			log.Fatalf("Failed reading proxy log: %v", err)
 		}
@@ -260,9 +284,14 @@ func main() {
 		log.Fatalf("Abs path for %q: %v", *outputFilename, err)
 	}
 	log.Infof("Writing to %s...", absOutput)
-	events, err := convert(ctx, logRecords, *traceLevel, *outputFilename)
+	var events []event
+	if *outputFormat == "perfetto" {
+		events, err = convertPerfetto(ctx, logRecords, proxyInfos, *traceLevel, *outputFilename)
+	} else {
+		events, err = convert(ctx, logRecords, *traceLevel, *outputFilename)
+	}
 	if err != nil {
-		log.Fatalf("Unable to convert into trace json: %v", err)
+		log.Fatalf("Unable to convert into trace %v: %v", *outputFormat, err)
 	}
 	log.Infof("%d requests %d events", len(logRecords), len(events))
 	fmt.Printf("%d requests %d events\n", len(logRecords), len(events))
@@ -0,0 +1,451 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package actioncache implements a persistent local action cache.
+//
+// The cache is stored in a directory with two subdirectories: "ac" holds the serialized
+// ActionResult of each cached action, named after the action digest, and "cas" holds the output
+// blobs referenced by these results, named after their digest. The total size of the cache is
+// bounded by evicting the least recently used action results along with the blobs that are not
+// referenced by any other result. The recency of results is persisted through the modification
+// time of their files, so it survives restarts.
+package actioncache
+
+import (
+	"container/list"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/digest"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/uploadinfo"
+	"google.golang.org/protobuf/proto"
+
+	repb "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	log "github.com/golang/glog"
+)
+
+const (
+	acDir  = "ac"
+	casDir = "cas"
+)
+
+// Cache is a persistent action cache stored in a local directory.
+type Cache struct {
+	dir      string
+	maxBytes int64
+
+	mu sync.Mutex
+	// size is the total size of the action results and the blobs in the cache.
+	size int64
+	// lru holds the cached actions, most recently used first.
+	lru     *list.List
+	entries map[digest.Digest]*list.Element
+	// refs is the number of cached actions referencing each blob.
+	refs map[digest.Digest]int
+}
+
+// entry is a cached action.
+type entry struct {
+	dg    digest.Digest
+	size  int64
+	blobs []digest.Digest
+}
+
+// New loads the cache stored in dir, creating it if it does not exist yet. The size of the cache is
+// bounded by maxBytes.
+func New(dir string, maxBytes int64) (*Cache, error) {
+	for _, d := range []string{acDir, casDir} {
+		if err := os.MkdirAll(filepath.Join(dir, d), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create action cache directory: %w", err)
+		}
+	}
+	c := &Cache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		lru:      list.New(),
+		entries:  make(map[digest.Digest]*list.Element),
+		refs:     make(map[digest.Digest]int),
+	}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// load rebuilds the in-memory index from the contents of the cache directory. Results referencing
+// missing blobs and blobs not referenced by any result are removed.
+func (c *Cache) load() error {
+	acs, err := os.ReadDir(filepath.Join(c.dir, acDir))
+	if err != nil {
+		return err
+	}
+	type loaded struct {
+		e     *entry
+		mtime time.Time
+	}
+	var all []loaded
+	for _, f := range acs {
+		dg, ok := parseName(f.Name())
+		if !ok {
+			continue
+		}
+		path := c.acPath(dg)
+		info, err := f.Info()
+		if err != nil {
+			continue
+		}
+		e, err := c.loadEntry(dg)
+		if err != nil {
+			log.Warningf("Removing invalid action cache entry %v: %v", dg, err)
+			os.Remove(path)
+			continue
+		}
+		all = append(all, loaded{e: e, mtime: info.ModTime()})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].mtime.After(all[j].mtime) })
+	for _, l := range all {
+		c.entries[l.e.dg] = c.lru.PushBack(l.e)
+		c.size += l.e.size
+		for _, b := range l.e.blobs {
+			if c.refs[b] == 0 {
+				c.size += b.Size
+			}
+			c.refs[b]++
+		}
+	}
+	blobs, err := os.ReadDir(filepath.Join(c.dir, casDir))
+	if err != nil {
+		return err
+	}
+	for _, f := range blobs {
+		if dg, ok := parseName(f.Name()); !ok || c.refs[dg] == 0 {
+			os.Remove(filepath.Join(c.dir, casDir, f.Name()))
+		}
+	}
+	c.evict()
+	log.Infof("Loaded %d entries (%d bytes) from the action cache at %v", len(c.entries), c.size, c.dir)
+	return nil
+}
+
+// loadEntry reads the result of the given action and checks that all the blobs it references exist.
+func (c *Cache) loadEntry(dg digest.Digest) (*entry, error) {
+	ar, size, err := c.readResult(dg)
+	if err != nil {
+		return nil, err
+	}
+	blobs, err := referencedBlobs(ar, c.ReadBlob)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range blobs {
+		info, err := os.Stat(c.casPath(b))
+		if err != nil {
+			return nil, err
+		}
+		if info.Size() != b.Size {
+			return nil, fmt.Errorf("blob %v has unexpected size %d", b, info.Size())
+		}
+	}
+	return &entry{dg: dg, size: size, blobs: blobs}, nil
+}
+
+// Get returns the cached result of the given action, if any.
+func (c *Cache) Get(dg digest.Digest) (*repb.ActionResult, bool) {
+	c.mu.Lock()
+	el, ok := c.entries[dg]
+	if ok {
+		c.lru.MoveToFront(el)
+	}
+	c.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+	ar, _, err := c.readResult(dg)
+	if err != nil {
+		log.Warningf("Failed to read action cache entry %v: %v", dg, err)
+		return nil, false
+	}
+	now := time.Now()
+	if err := os.Chtimes(c.acPath(dg), now, now); err != nil {
+		log.Warningf("Failed to update last use time of action cache entry %v: %v", dg, err)
+	}
+	return ar, true
+}
+
+// ReadBlob returns the contents of the given blob.
+func (c *Cache) ReadBlob(dg digest.Digest) ([]byte, error) {
+	if dg.IsEmpty() {
+		return nil, nil
+	}
+	return os.ReadFile(c.casPath(dg))
+}
+
+// Put stores the result of the given action. blobs must contain all the blobs referenced by the
+// result, extra blobs are ignored.
+func (c *Cache) Put(dg digest.Digest, ar *repb.ActionResult, blobs map[digest.Digest]*uploadinfo.Entry) error {
+	arBlob, err := proto.Marshal(ar)
+	if err != nil {
+		return err
+	}
+	refs, err := referencedBlobs(ar, func(b digest.Digest) ([]byte, error) {
+		ue, ok := blobs[b]
+		if !ok {
+			return nil, fmt.Errorf("blob %v is missing", b)
+		}
+		if ue.IsBlob() {
+			return ue.Contents, nil
+		}
+		return os.ReadFile(ue.Path)
+	})
+	if err != nil {
+		return err
+	}
+	for _, b := range refs {
+		ue, ok := blobs[b]
+		if !ok {
+			return fmt.Errorf("blob %v is missing", b)
+		}
+		if err := c.writeBlob(ue); err != nil {
+			return fmt.Errorf("failed to store blob %v: %w", b, err)
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[dg]; ok {
+		c.remove(el)
+	}
+	for _, b := range refs {
+		if c.refs[b] > 0 {
+			continue
+		}
+		// Blobs that already existed might have been evicted in the meantime.
+		if err := c.writeBlob(blobs[b]); err != nil {
+			return fmt.Errorf("failed to store blob %v: %w", b, err)
+		}
+	}
+	if err := writeAtomically(c.acPath(dg), func(f *os.File) error {
+		_, err := f.Write(arBlob)
+		return err
+	}); err != nil {
+		return err
+	}
+	e := &entry{dg: dg, size: int64(len(arBlob)), blobs: refs}
+	c.entries[dg] = c.lru.PushFront(e)
+	c.size += e.size
+	for _, b := range refs {
+		if c.refs[b] == 0 {
+			c.size += b.Size
+		}
+		c.refs[b]++
+	}
+	c.evict()
+	return nil
+}
+
+// Size returns the total size in bytes of the cache contents.
+func (c *Cache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+// evict removes the least recently used actions until the cache fits in its size limit. Must be
+// called with c.mu held.
+func (c *Cache) evict() {
+	for c.size > c.maxBytes && c.lru.Len() > 0 {
+		el := c.lru.Back()
+		log.V(2).Infof("Evicting %v from the action cache", el.Value.(*entry).dg)
+		c.remove(el)
+	}
+}
+
+// remove deletes the given action and the blobs only it references. Must be called with c.mu held.
+func (c *Cache) remove(el *list.Element) {
+	e := c.lru.Remove(el).(*entry)
+	delete(c.entries, e.dg)
+	c.size -= e.size
+	if err := os.Remove(c.acPath(e.dg)); err != nil && !os.IsNotExist(err) {
+		log.Warningf("Failed to remove action cache entry %v: %v", e.dg, err)
+	}
+	for _, b := range e.blobs {
+		c.refs[b]--
+		if c.refs[b] > 0 {
+			continue
+		}
+		delete(c.refs, b)
+		c.size -= b.Size
+		if err := os.Remove(c.casPath(b)); err != nil && !os.IsNotExist(err) {
+			log.Warningf("Failed to remove action cache blob %v: %v", b, err)
+		}
+	}
+}
+
+func (c *Cache) readResult(dg digest.Digest) (*repb.ActionResult, int64, error) {
+	blob, err := os.ReadFile(c.acPath(dg))
+	if err != nil {
+		return nil, 0, err
+	}
+	ar := &repb.ActionResult{}
+	if err := proto.Unmarshal(blob, ar); err != nil {
+		return nil, 0, err
+	}
+	return ar, int64(len(blob)), nil
+}
+
+// writeBlob stores the given blob in the CAS if it is not there yet. The contents of files are
+// verified against their digest since they might have been modified after being digested.
+func (c *Cache) writeBlob(ue *uploadinfo.Entry) error {
+	path := c.casPath(ue.Digest)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	return writeAtomically(path, func(f *os.File) error {
+		if ue.IsBlob() {
+			_, err := f.Write(ue.Contents)
+			return err
+		}
+		src, err := os.Open(ue.Path)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		dg, err := digest.NewFromReader(io.TeeReader(src, f))
+		if err != nil {
+			return err
+		}
+		if dg != ue.Digest {
+			return fmt.Errorf("%v was modified, got digest %v, want %v", ue.Path, dg, ue.Digest)
+		}
+		return nil
+	})
+}
+
+func (c *Cache) acPath(dg digest.Digest) string {
+	return filepath.Join(c.dir, acDir, blobName(dg))
+}
+
+func (c *Cache) casPath(dg digest.Digest) string {
+	return filepath.Join(c.dir, casDir, blobName(dg))
+}
+
+func blobName(dg digest.Digest) string {
+	return fmt.Sprintf("%s_%d", dg.Hash, dg.Size)
+}
+
+func parseName(name string) (digest.Digest, bool) {
+	hash, size, ok := strings.Cut(name, "_")
+	if !ok {
+		return digest.Digest{}, false
+	}
+	sz, err := strconv.ParseInt(size, 10, 64)
+	if err != nil {
+		return digest.Digest{}, false
+	}
+	dg, err := digest.New(hash, sz)
+	if err != nil {
+		return digest.Digest{}, false
+	}
+	return dg, true
+}
+
+// writeAtomically writes a file through a temporary file that is renamed once fully written, so
+// that readers never observe partially written files.
+func writeAtomically(path string, write func(f *os.File) error) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
+
+// referencedBlobs returns the digests of the non-empty blobs referenced by the given result,
+// including output directory trees and the files they contain.
+func referencedBlobs(ar *repb.ActionResult, readBlob func(digest.Digest) ([]byte, error)) ([]digest.Digest, error) {
+	seen := make(map[digest.Digest]bool)
+	var blobs []digest.Digest
+	add := func(pb *repb.Digest) (digest.Digest, error) {
+		dg, err := digest.NewFromProto(pb)
+		if err != nil {
+			return dg, err
+		}
+		if !dg.IsEmpty() && !seen[dg] {
+			seen[dg] = true
+			blobs = append(blobs, dg)
+		}
+		return dg, nil
+	}
+	for _, f := range ar.GetOutputFiles() {
+		if _, err := add(f.GetDigest()); err != nil {
+			return nil, err
+		}
+	}
+	for _, d := range ar.GetOutputDirectories() {
+		dg, err := add(d.GetTreeDigest())
+		if err != nil {
+			return nil, err
+		}
+		tree, err := readTree(dg, readBlob)
+		if err != nil {
+			return nil, err
+		}
+		for _, dir := range append([]*repb.Directory{tree.GetRoot()}, tree.GetChildren()...) {
+			for _, f := range dir.GetFiles() {
+				if _, err := add(f.GetDigest()); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	for _, pb := range []*repb.Digest{ar.GetStdoutDigest(), ar.GetStderrDigest()} {
+		if pb == nil {
+			continue
+		}
+		if _, err := add(pb); err != nil {
+			return nil, err
+		}
+	}
+	return blobs, nil
+}
+
+func readTree(dg digest.Digest, readBlob func(digest.Digest) ([]byte, error)) (*repb.Tree, error) {
+	blob, err := readBlob(dg)
+	if err != nil {
+		return nil, err
+	}
+	tree := &repb.Tree{}
+	if err := proto.Unmarshal(blob, tree); err != nil {
+		return nil, fmt.Errorf("failed to parse tree %v: %w", dg, err)
+	}
+	return tree, nil
+}
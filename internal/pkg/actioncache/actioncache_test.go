@@ -0,0 +1,176 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actioncache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/client"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/digest"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/filemetadata"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/uploadinfo"
+
+	repb "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for f, c := range files {
+		p := filepath.Join(root, f)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("MkdirAll(%v) failed: %v", filepath.Dir(p), err)
+		}
+		if err := os.WriteFile(p, []byte(c), 0644); err != nil {
+			t.Fatalf("WriteFile(%v) failed: %v", p, err)
+		}
+	}
+}
+
+func checkFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for f, want := range files {
+		got, err := os.ReadFile(filepath.Join(root, f))
+		if err != nil {
+			t.Errorf("ReadFile(%v) failed: %v", f, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("ReadFile(%v) = %q, want %q", f, got, want)
+		}
+	}
+}
+
+// computeOutputs returns the result and blobs of an action producing the given outputs.
+func computeOutputs(t *testing.T, execRoot string, paths []string, stdout string) (*repb.ActionResult, map[digest.Digest]*uploadinfo.Entry) {
+	t.Helper()
+	blobs, ar, err := (&client.Client{}).ComputeOutputsToUpload(execRoot, "", paths, filemetadata.NewNoopCache(), command.UnspecifiedSymlinkBehavior, nil)
+	if err != nil {
+		t.Fatalf("ComputeOutputsToUpload() failed: %v", err)
+	}
+	if stdout != "" {
+		ue := uploadinfo.EntryFromBlob([]byte(stdout))
+		blobs[ue.Digest] = ue
+		ar.StdoutDigest = ue.Digest.ToProto()
+	}
+	return ar, blobs
+}
+
+func TestPutGetMaterialize(t *testing.T) {
+	execRoot := t.TempDir()
+	writeFiles(t, execRoot, map[string]string{
+		"out/a.o":     "a",
+		"out/empty":   "",
+		"gen/b.h":     "b",
+		"gen/sub/c.h": "c",
+	})
+	ar, blobs := computeOutputs(t, execRoot, []string{"out/a.o", "out/empty", "gen"}, "stdout")
+	dir := t.TempDir()
+	c, err := New(dir, 1<<20)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	dg := digest.NewFromBlob([]byte("action"))
+	if _, ok := c.Get(dg); ok {
+		t.Fatalf("Get(%v) found a result before Put()", dg)
+	}
+	if err := c.Put(dg, ar, blobs); err != nil {
+		t.Fatalf("Put() failed: %v", err)
+	}
+	// Reload the cache from disk to check that it persists.
+	c, err = New(dir, 1<<20)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	got, ok := c.Get(dg)
+	if !ok {
+		t.Fatalf("Get(%v) did not find a result", dg)
+	}
+	outDir := t.TempDir()
+	writeFiles(t, outDir, map[string]string{
+		"out/a.o":     "stale",
+		"gen/stale.h": "stale",
+	})
+	if err := c.Materialize(got, outDir); err != nil {
+		t.Fatalf("Materialize() failed: %v", err)
+	}
+	checkFiles(t, outDir, map[string]string{
+		"out/a.o":     "a",
+		"out/empty":   "",
+		"gen/b.h":     "b",
+		"gen/sub/c.h": "c",
+	})
+	if _, err := os.Stat(filepath.Join(outDir, "gen/stale.h")); !os.IsNotExist(err) {
+		t.Errorf("Stat(gen/stale.h) = %v, want stale output directory contents to be removed", err)
+	}
+	stdout, err := c.ReadBlob(digest.NewFromProtoUnvalidated(got.GetStdoutDigest()))
+	if err != nil {
+		t.Fatalf("ReadBlob(stdout) failed: %v", err)
+	}
+	if string(stdout) != "stdout" {
+		t.Errorf("ReadBlob(stdout) = %q, want %q", stdout, "stdout")
+	}
+}
+
+func TestEviction(t *testing.T) {
+	execRoot := t.TempDir()
+	writeFiles(t, execRoot, map[string]string{
+		"a": "aaaaaaaaaa",
+		"b": "bbbbbbbbbb",
+		"c": "cccccccccc",
+	})
+	arA, blobsA := computeOutputs(t, execRoot, []string{"a", "c"}, "")
+	arB, blobsB := computeOutputs(t, execRoot, []string{"b", "c"}, "")
+	dgA := digest.NewFromBlob([]byte("a"))
+	dgB := digest.NewFromBlob([]byte("b"))
+	dgC := digest.NewFromBlob([]byte("c"))
+	dir := t.TempDir()
+	c, err := New(dir, 1<<20)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	if err := c.Put(dgA, arA, blobsA); err != nil {
+		t.Fatalf("Put(a) failed: %v", err)
+	}
+	if err := c.Put(dgB, arB, blobsB); err != nil {
+		t.Fatalf("Put(b) failed: %v", err)
+	}
+	// Use a so that b is the least recently used action.
+	if _, ok := c.Get(dgA); !ok {
+		t.Fatalf("Get(a) did not find a result")
+	}
+	// Only allow two actions to fit in the cache.
+	c.maxBytes = c.Size() + 1
+	if err := c.Put(dgC, arA, blobsA); err != nil {
+		t.Fatalf("Put(c) failed: %v", err)
+	}
+	if _, ok := c.Get(dgB); ok {
+		t.Errorf("Get(b) found a result, want it to be evicted")
+	}
+	for _, dg := range []digest.Digest{dgA, dgC} {
+		if _, ok := c.Get(dg); !ok {
+			t.Errorf("Get(%v) did not find a result", dg)
+		}
+	}
+	for f, wantExists := range map[string]bool{"a": true, "b": false, "c": true} {
+		dg := digest.NewFromBlob([]byte(f + f + f + f + f + f + f + f + f + f))
+		_, err := os.Stat(c.casPath(dg))
+		if gotExists := err == nil; gotExists != wantExists {
+			t.Errorf("blob of %v exists = %v, want %v", f, gotExists, wantExists)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actioncache
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/digest"
+
+	repb "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+)
+
+// Materialize writes the outputs of the given cached result under outDir, which the output paths
+// of the result are relative to. Existing outputs are replaced. An error is returned if any of the
+// referenced blobs is not in the cache anymore.
+func (c *Cache) Materialize(ar *repb.ActionResult, outDir string) error {
+	for _, f := range ar.GetOutputFiles() {
+		dg, err := digest.NewFromProto(f.GetDigest())
+		if err != nil {
+			return err
+		}
+		if err := c.materializeFile(dg, filepath.Join(outDir, f.GetPath()), f.GetIsExecutable()); err != nil {
+			return fmt.Errorf("failed to materialize output file %v: %w", f.GetPath(), err)
+		}
+	}
+	for _, d := range ar.GetOutputDirectories() {
+		dg, err := digest.NewFromProto(d.GetTreeDigest())
+		if err != nil {
+			return err
+		}
+		tree, err := readTree(dg, c.ReadBlob)
+		if err != nil {
+			return err
+		}
+		children := make(map[digest.Digest]*repb.Directory)
+		for _, child := range tree.GetChildren() {
+			cdg, err := digest.NewFromMessage(child)
+			if err != nil {
+				return err
+			}
+			children[cdg] = child
+		}
+		dst := filepath.Join(outDir, d.GetPath())
+		if err := os.RemoveAll(dst); err != nil {
+			return err
+		}
+		if err := c.materializeDir(tree.GetRoot(), children, dst); err != nil {
+			return fmt.Errorf("failed to materialize output directory %v: %w", d.GetPath(), err)
+		}
+	}
+	var symlinks []*repb.OutputSymlink
+	symlinks = append(symlinks, ar.GetOutputFileSymlinks()...)
+	symlinks = append(symlinks, ar.GetOutputDirectorySymlinks()...)
+	symlinks = append(symlinks, ar.GetOutputSymlinks()...)
+	for _, s := range symlinks {
+		if err := createSymlink(s.GetTarget(), filepath.Join(outDir, s.GetPath())); err != nil {
+			return fmt.Errorf("failed to materialize output symlink %v: %w", s.GetPath(), err)
+		}
+	}
+	return nil
+}
+
+func (c *Cache) materializeDir(dir *repb.Directory, children map[digest.Digest]*repb.Directory, dst string) error {
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+	for _, f := range dir.GetFiles() {
+		dg, err := digest.NewFromProto(f.GetDigest())
+		if err != nil {
+			return err
+		}
+		if err := c.materializeFile(dg, filepath.Join(dst, f.GetName()), f.GetIsExecutable()); err != nil {
+			return err
+		}
+	}
+	for _, s := range dir.GetSymlinks() {
+		if err := createSymlink(s.GetTarget(), filepath.Join(dst, s.GetName())); err != nil {
+			return err
+		}
+	}
+	for _, d := range dir.GetDirectories() {
+		dg, err := digest.NewFromProto(d.GetDigest())
+		if err != nil {
+			return err
+		}
+		child, ok := children[dg]
+		if !ok {
+			return fmt.Errorf("directory %v is missing from the tree", dg)
+		}
+		if err := c.materializeDir(child, children, filepath.Join(dst, d.GetName())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// materializeFile copies the given blob to dst. The blob is copied rather than hard linked, since
+// the build might modify its outputs in place.
+func (c *Cache) materializeFile(dg digest.Digest, dst string, isExecutable bool) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	perm := os.FileMode(0644)
+	if isExecutable {
+		perm = 0755
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	if dg.IsEmpty() {
+		return out.Close()
+	}
+	in, err := os.Open(c.casPath(dg))
+	if err != nil {
+		out.Close()
+		return err
+	}
+	defer in.Close()
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func createSymlink(target, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+	return os.Symlink(target, dst)
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cgroups runs locally executed commands in their own cgroup v2 leaf, so that the memory
+// they use can be limited and commands that exceed their limit can be told apart from commands
+// that crashed.
+//
+// The leaves are created in a cgroup delegated to the current user, in which the memory controller
+// is enabled for children. Since cgroup v2 only allows processes in leaves, the cgroup of the
+// current process can only be used after moving the process to a child leaf of its own.
+package cgroups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ErrUnsupported is returned when cgroups v2 are not supported on the current platform.
+var ErrUnsupported = errors.New("cgroups v2 are not supported on this platform")
+
+// Manager creates cgroup leaves for commands.
+type Manager struct {
+	root string
+	next atomic.Int64
+}
+
+// Leaf is a cgroup in which a single command runs.
+type Leaf struct {
+	path string
+	dir  *os.File
+}
+
+// NewLeaf creates a leaf cgroup whose memory usage is limited to the given number of bytes. The
+// name is used as a prefix of the name of the cgroup, which is made unique.
+func (m *Manager) NewLeaf(name string, memoryMaxBytes int64) (*Leaf, error) {
+	path := filepath.Join(m.root, fmt.Sprintf("%s-%d", name, m.next.Add(1)))
+	if err := os.Mkdir(path, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cgroup: %w", err)
+	}
+	l := &Leaf{path: path}
+	if err := l.write("memory.max", strconv.FormatInt(memoryMaxBytes, 10)); err != nil {
+		l.Close()
+		return nil, err
+	}
+	// Swapping out the memory of a command over its limit would slow down the whole machine, and
+	// all processes of a command are killed together since the command can't complete without
+	// them. Both are best effort since the kernel might not support them.
+	l.write("memory.swap.max", "0")
+	l.write("memory.oom.group", "1")
+	dir, err := os.Open(path)
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+	l.dir = dir
+	return l, nil
+}
+
+// Path returns the path of the cgroup in the cgroup filesystem.
+func (l *Leaf) Path() string {
+	return l.path
+}
+
+// OOMKilled returns whether processes of the cgroup were killed for exceeding its memory limit.
+func (l *Leaf) OOMKilled() (bool, error) {
+	blob, err := os.ReadFile(filepath.Join(l.path, "memory.events"))
+	if err != nil {
+		return false, err
+	}
+	for _, line := range strings.Split(string(blob), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != "oom_kill" {
+			continue
+		}
+		n, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return false, fmt.Errorf("malformed memory.events: %w", err)
+		}
+		return n > 0, nil
+	}
+	return false, nil
+}
+
+// Close kills the processes left in the cgroup and removes it.
+func (l *Leaf) Close() error {
+	if l.dir != nil {
+		l.dir.Close()
+	}
+	// cgroup.kill is only supported since Linux 5.14.
+	l.write("cgroup.kill", "1")
+	var err error
+	// The cgroup can only be removed once its killed processes have exited.
+	for i := 0; i < 10; i++ {
+		if err = os.Remove(l.path); err == nil || os.IsNotExist(err) {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return fmt.Errorf("failed to remove cgroup: %w", err)
+}
+
+func (l *Leaf) write(file, value string) error {
+	if err := os.WriteFile(filepath.Join(l.path, file), []byte(value), 0644); err != nil {
+		return fmt.Errorf("failed to write %v of cgroup: %w", file, err)
+	}
+	return nil
+}
+
+type leafKey struct{}
+
+// NewContext returns a context carrying the leaf in which commands executed with it run.
+func NewContext(ctx context.Context, l *Leaf) context.Context {
+	return context.WithValue(ctx, leafKey{}, l)
+}
+
+// FromContext returns the leaf carried by the context, if any.
+func FromContext(ctx context.Context) *Leaf {
+	l, _ := ctx.Value(leafKey{}).(*Leaf)
+	return l
+}
+
+// SetCmdCgroup makes the command start in the leaf carried by the context, if any. Must be called
+// after the SysProcAttr of the command is set.
+func SetCmdCgroup(ctx context.Context, c *exec.Cmd) {
+	if l := FromContext(ctx); l != nil && l.dir != nil {
+		setCgroupFD(c, int(l.dir.Fd()))
+	}
+}
+
+// ownCgroup returns the path of the cgroup v2 of a process in the cgroup filesystem, given the
+// contents of its /proc/<pid>/cgroup file.
+func ownCgroup(procCgroup string) (string, error) {
+	for _, line := range strings.Split(procCgroup, "\n") {
+		if path, ok := strings.CutPrefix(line, "0::"); ok {
+			return path, nil
+		}
+	}
+	return "", errors.New("process is not in a cgroup v2 hierarchy")
+}
+
+// enableMemoryController enables the memory controller for the children of the cgroup at root.
+func enableMemoryController(root string) error {
+	blob, err := os.ReadFile(filepath.Join(root, "cgroup.controllers"))
+	if err != nil {
+		return fmt.Errorf("%v is not a cgroup v2: %w", root, err)
+	}
+	hasMemory := false
+	for _, c := range strings.Fields(string(blob)) {
+		hasMemory = hasMemory || c == "memory"
+	}
+	if !hasMemory {
+		return fmt.Errorf("memory controller is not available in cgroup %v", root)
+	}
+	if err := os.WriteFile(filepath.Join(root, "cgroup.subtree_control"), []byte("+memory"), 0644); err != nil {
+		return fmt.Errorf("failed to enable memory controller in cgroup %v: %w", root, err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroups
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+)
+
+const cgroupFS = "/sys/fs/cgroup"
+
+// New returns a Manager creating leaves in the cgroup at root, which must be writable by the
+// current user and contain no processes. If root is empty, the cgroup of the current process is
+// used, and the current process is moved to a child leaf of it.
+func New(root string) (*Manager, error) {
+	if root == "" {
+		blob, err := os.ReadFile("/proc/self/cgroup")
+		if err != nil {
+			return nil, err
+		}
+		own, err := ownCgroup(string(blob))
+		if err != nil {
+			return nil, err
+		}
+		root = filepath.Join(cgroupFS, own)
+		self := filepath.Join(root, "reproxy")
+		if err := os.MkdirAll(self, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create cgroup for the current process: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(self, "cgroup.procs"), []byte(strconv.Itoa(os.Getpid())), 0644); err != nil {
+			return nil, fmt.Errorf("failed to move the current process to cgroup %v: %w", self, err)
+		}
+	}
+	if err := enableMemoryController(root); err != nil {
+		return nil, err
+	}
+	return &Manager{root: root}, nil
+}
+
+func setCgroupFD(c *exec.Cmd, fd int) {
+	if c.SysProcAttr == nil {
+		c.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	c.SysProcAttr.UseCgroupFD = true
+	c.SysProcAttr.CgroupFD = fd
+}
@@ -0,0 +1,140 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroups
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewLeaf(t *testing.T) {
+	m := &Manager{root: t.TempDir()}
+	l, err := m.NewLeaf("action", 512*1024*1024)
+	if err != nil {
+		t.Fatalf("NewLeaf() returned error: %v", err)
+	}
+	defer l.dir.Close()
+	if !strings.HasPrefix(filepath.Base(l.Path()), "action-") {
+		t.Errorf("NewLeaf() created cgroup %v, want name prefixed with action-", l.Path())
+	}
+	for file, want := range map[string]string{
+		"memory.max":       "536870912",
+		"memory.swap.max":  "0",
+		"memory.oom.group": "1",
+	} {
+		got, err := os.ReadFile(filepath.Join(l.Path(), file))
+		if err != nil {
+			t.Fatalf("ReadFile(%v) failed: %v", file, err)
+		}
+		if string(got) != want {
+			t.Errorf("%v = %q, want %q", file, got, want)
+		}
+	}
+	l2, err := m.NewLeaf("action", 1024)
+	if err != nil {
+		t.Fatalf("NewLeaf() returned error: %v", err)
+	}
+	defer l2.dir.Close()
+	if l2.Path() == l.Path() {
+		t.Errorf("NewLeaf() created cgroup %v twice", l.Path())
+	}
+}
+
+func TestOOMKilled(t *testing.T) {
+	tests := []struct {
+		name   string
+		events string
+		want   bool
+	}{
+		{
+			name:   "NotKilled",
+			events: "low 0\nhigh 0\nmax 12\noom 0\noom_kill 0\noom_group_kill 0\n",
+			want:   false,
+		},
+		{
+			name:   "Killed",
+			events: "low 0\nhigh 0\nmax 12\noom 1\noom_kill 3\noom_group_kill 1\n",
+			want:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l := &Leaf{path: t.TempDir()}
+			if err := os.WriteFile(filepath.Join(l.path, "memory.events"), []byte(tc.events), 0644); err != nil {
+				t.Fatalf("WriteFile() failed: %v", err)
+			}
+			got, err := l.OOMKilled()
+			if err != nil {
+				t.Fatalf("OOMKilled() returned error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("OOMKilled() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOwnCgroup(t *testing.T) {
+	got, err := ownCgroup("0::/user.slice/user-1000.slice/session-2.scope\n")
+	if err != nil {
+		t.Fatalf("ownCgroup() returned error: %v", err)
+	}
+	if want := "/user.slice/user-1000.slice/session-2.scope"; got != want {
+		t.Errorf("ownCgroup() = %v, want %v", got, want)
+	}
+	if got, err := ownCgroup("12:memory:/foo\n1:name=systemd:/foo\n"); err == nil {
+		t.Errorf("ownCgroup() on cgroup v1 = %v, want error", got)
+	}
+}
+
+func TestEnableMemoryController(t *testing.T) {
+	root := t.TempDir()
+	if err := enableMemoryController(root); err == nil {
+		t.Errorf("enableMemoryController() on a directory that is not a cgroup returned nil, want error")
+	}
+	if err := os.WriteFile(filepath.Join(root, "cgroup.controllers"), []byte("cpuset cpu io pids\n"), 0644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	if err := enableMemoryController(root); err == nil {
+		t.Errorf("enableMemoryController() without memory controller returned nil, want error")
+	}
+	if err := os.WriteFile(filepath.Join(root, "cgroup.controllers"), []byte("cpuset cpu io memory pids\n"), 0644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	if err := enableMemoryController(root); err != nil {
+		t.Fatalf("enableMemoryController() returned error: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(root, "cgroup.subtree_control"))
+	if err != nil {
+		t.Fatalf("ReadFile() failed: %v", err)
+	}
+	if string(got) != "+memory" {
+		t.Errorf("cgroup.subtree_control = %q, want %q", got, "+memory")
+	}
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	if l := FromContext(ctx); l != nil {
+		t.Errorf("FromContext() = %v, want nil", l)
+	}
+	l := &Leaf{path: "foo"}
+	if got := FromContext(NewContext(ctx, l)); got != l {
+		t.Errorf("FromContext(NewContext(l)) = %v, want %v", got, l)
+	}
+}
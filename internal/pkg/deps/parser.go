@@ -72,14 +72,20 @@ func (p *Parser) WriteDepsFile(dFilePath string, rec *logger.LogRecord) error {
 // path to the one that would be generated from the provided d file and include
 // directories.
 func (p *Parser) VerifyDepsFile(dFilePath string, rec *logger.LogRecord) (bool, error) {
-	st := time.Now()
-	defer func() {
-		rec.RecordEventTime(event.LERCVerifyDeps, st)
-	}()
 	buf, err := os.ReadFile(filepath.Join(p.ExecRoot, dFilePath+".deps"))
 	if err != nil {
 		return false, err
 	}
+	return p.VerifyDeps(buf, rec)
+}
+
+// VerifyDeps compares the contents of a deps file to the current digests of the
+// dependencies it lists.
+func (p *Parser) VerifyDeps(buf []byte, rec *logger.LogRecord) (bool, error) {
+	st := time.Now()
+	defer func() {
+		rec.RecordEventTime(event.LERCVerifyDeps, st)
+	}()
 	matches := depsFileParser.FindAllStringSubmatch(string(buf), -1)
 	for _, match := range matches {
 		if len(match) < 3 {
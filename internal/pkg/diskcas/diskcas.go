@@ -0,0 +1,392 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diskcas implements a bounded content addressable store on local disk, used as the first
+// tier in front of the remote CAS when downloading outputs of remote actions.
+//
+// Blobs are stored read-only in sharded subdirectories, named after their digest. Executable and
+// non-executable copies of a blob are stored separately since hard links share their permissions.
+// When the total size of the stored blobs exceeds the limit, the least recently used blobs are
+// evicted. The recency of blobs is persisted through their modification time, so it survives
+// restarts. The times of blobs that outputs are hard linked to are not updated, since the outputs
+// share them.
+//
+// Blobs that outputs are hard linked to could be modified through the outputs. Such blobs are
+// checked before being reused: against the size, modification time and mode they had when they
+// were linked, or against their digest the first time they are reused after a restart.
+package diskcas
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/digest"
+
+	log "github.com/golang/glog"
+)
+
+const (
+	regularMode    = 0644
+	executableMode = 0755
+	// Blobs are stored without write permissions, so that outputs hard linked to them cannot be
+	// modified in place.
+	readOnlyMask = 0555
+	execSuffix   = "_x"
+)
+
+// ErrNotFound is returned when a blob is not in the CAS.
+var ErrNotFound = errors.New("blob not found in disk CAS")
+
+// CAS is a bounded content addressable store in a local directory.
+type CAS struct {
+	// UseHardlinks allows outputs to be materialized as hard links to the stored blobs when reflinks
+	// are not supported by the filesystem. Outputs materialized this way are read-only. Since the
+	// contents of such outputs could still be modified in place after making them writable, blobs
+	// are not reused once their size, modification time or mode changed. Modifications that preserve
+	// all of them are not detected.
+	UseHardlinks bool
+
+	dir      string
+	maxBytes int64
+
+	mu    sync.Mutex
+	size  int64
+	lru   *list.List // of blobKey, most recently used first.
+	blobs map[blobKey]*list.Element
+	// linked holds the stamps of the blobs that outputs were hard linked to, taken when they were.
+	linked map[blobKey]stamp
+}
+
+type blobKey struct {
+	dg           digest.Digest
+	isExecutable bool
+}
+
+// stamp is the state of a blob file that changes when it is modified.
+type stamp struct {
+	size  int64
+	mtime time.Time
+	mode  os.FileMode
+}
+
+func stampOf(info os.FileInfo) stamp {
+	return stamp{size: info.Size(), mtime: info.ModTime(), mode: info.Mode()}
+}
+
+// New loads the CAS stored in dir, creating it if it does not exist yet. The total size of the
+// stored blobs is bounded by maxBytes.
+func New(dir string, maxBytes int64) (*CAS, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create disk CAS directory: %w", err)
+	}
+	c := &CAS{
+		dir:      dir,
+		maxBytes: maxBytes,
+		lru:      list.New(),
+		blobs:    make(map[blobKey]*list.Element),
+		linked:   make(map[blobKey]stamp),
+	}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// load rebuilds the in-memory index from the blobs stored on disk. Leftover temporary files and
+// blobs whose size does not match their digest are removed.
+func (c *CAS) load() error {
+	type loaded struct {
+		key   blobKey
+		mtime time.Time
+	}
+	var all []loaded
+	err := filepath.Walk(c.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		key, ok := parseName(info.Name())
+		if !ok || info.Size() != key.dg.Size || path != c.path(key) {
+			os.Remove(path)
+			return nil
+		}
+		all = append(all, loaded{key: key, mtime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].mtime.After(all[j].mtime) })
+	for _, l := range all {
+		c.blobs[l.key] = c.lru.PushBack(l.key)
+		c.size += l.key.dg.Size
+	}
+	c.evict()
+	log.Infof("Loaded %d blobs (%d bytes) from the disk CAS at %v", len(c.blobs), c.size, c.dir)
+	return nil
+}
+
+// Contains returns whether the given blob is in the CAS.
+func (c *CAS) Contains(dg digest.Digest, isExecutable bool) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.blobs[blobKey{dg: dg, isExecutable: isExecutable}]
+	return ok
+}
+
+// Materialize writes the given blob to dst, replacing any existing file. The file is cloned with a
+// reflink if supported by the filesystem, hard linked if allowed, and copied otherwise. Returns
+// ErrNotFound if the blob is not in the CAS.
+func (c *CAS) Materialize(dg digest.Digest, isExecutable bool, dst string) error {
+	key := blobKey{dg: dg, isExecutable: isExecutable}
+	c.mu.Lock()
+	el, ok := c.blobs[key]
+	if ok {
+		c.lru.MoveToFront(el)
+	}
+	c.mu.Unlock()
+	if !ok {
+		return ErrNotFound
+	}
+	src := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	// dst is removed first, so that an output previously hard linked to the blob no longer counts as
+	// a link to it.
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	info, err := os.Stat(src)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// The blob was evicted in the meantime.
+			return ErrNotFound
+		}
+		return err
+	}
+	if !c.unmodified(key, info) {
+		log.Warningf("Removing blob %v modified through a hard link from the disk CAS", dg)
+		c.remove(key)
+		return ErrNotFound
+	}
+	// Updating the times of a blob other files are hard linked to would update theirs too.
+	unlinked := linkCount(info) == 1
+	if unlinked {
+		// No output is hard linked to the blob anymore, so its stamp would be outdated by the new times.
+		c.mu.Lock()
+		delete(c.linked, key)
+		c.mu.Unlock()
+		now := time.Now()
+		if err := os.Chtimes(src, now, now); err != nil {
+			if os.IsNotExist(err) {
+				return ErrNotFound
+			}
+			return err
+		}
+	}
+	mode := os.FileMode(regularMode)
+	if isExecutable {
+		mode = executableMode
+	}
+	if err := reflink(src, dst); err == nil {
+		return os.Chmod(dst, mode)
+	}
+	// Outputs are only hard linked to blobs whose times were just updated, so that they are not
+	// older than the action that produced them.
+	if c.UseHardlinks && unlinked {
+		if err := os.Link(src, dst); err == nil {
+			if info, err := os.Stat(src); err == nil {
+				c.mu.Lock()
+				c.linked[key] = stampOf(info)
+				c.mu.Unlock()
+			}
+			return nil
+		}
+	}
+	if err := copyFile(src, dst, mode); err != nil {
+		if os.IsNotExist(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// unmodified returns whether the blob with the given file info was not modified through the
+// outputs hard linked to it. Blobs that were linked to before a restart have no stamp yet and are
+// verified against their digest once.
+func (c *CAS) unmodified(key blobKey, info os.FileInfo) bool {
+	c.mu.Lock()
+	st, ok := c.linked[key]
+	c.mu.Unlock()
+	if ok {
+		return stampOf(info) == st
+	}
+	if linkCount(info) <= 1 {
+		return true
+	}
+	if got, err := digest.NewFromFile(c.path(key)); err != nil || got != key.dg {
+		return false
+	}
+	c.mu.Lock()
+	c.linked[key] = stampOf(info)
+	c.mu.Unlock()
+	return true
+}
+
+// Add stores the contents of the file at src as the given blob, if it is not stored yet. The
+// caller is responsible for src matching the digest.
+func (c *CAS) Add(dg digest.Digest, isExecutable bool, src string) error {
+	if c.Contains(dg, isExecutable) {
+		return nil
+	}
+	if dg.Size > c.maxBytes {
+		return nil
+	}
+	key := blobKey{dg: dg, isExecutable: isExecutable}
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+	defer os.Remove(tmpPath)
+	// The temporary file only reserves a unique name, reflinks need to create the file themselves.
+	if err := os.Remove(tmpPath); err != nil {
+		return err
+	}
+	mode := os.FileMode(regularMode)
+	if isExecutable {
+		mode = executableMode
+	}
+	if err := reflink(src, tmpPath); err != nil {
+		if err := copyFile(src, tmpPath, mode); err != nil {
+			return err
+		}
+	}
+	info, err := os.Stat(tmpPath)
+	if err != nil {
+		return err
+	}
+	if info.Size() != dg.Size {
+		return fmt.Errorf("%v has size %d, want %d", src, info.Size(), dg.Size)
+	}
+	if err := os.Chmod(tmpPath, mode&readOnlyMask); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.blobs[key]; ok {
+		return nil
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	c.blobs[key] = c.lru.PushFront(key)
+	c.size += dg.Size
+	c.evict()
+	return nil
+}
+
+// Size returns the total size in bytes of the stored blobs.
+func (c *CAS) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+// remove removes the given blob from the CAS.
+func (c *CAS) remove(key blobKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.blobs[key]; ok {
+		c.removeElement(el)
+	}
+}
+
+// evict removes the least recently used blobs until the CAS fits in its size limit. Must be
+// called with c.mu held.
+func (c *CAS) evict() {
+	for c.size > c.maxBytes && c.lru.Len() > 0 {
+		c.removeElement(c.lru.Back())
+	}
+}
+
+// removeElement removes the blob of the given LRU element from the CAS. Must be called with c.mu
+// held.
+func (c *CAS) removeElement(el *list.Element) {
+	key := c.lru.Remove(el).(blobKey)
+	delete(c.blobs, key)
+	delete(c.linked, key)
+	c.size -= key.dg.Size
+	if err := os.Remove(c.path(key)); err != nil && !os.IsNotExist(err) {
+		log.Warningf("Failed to remove %v from the disk CAS: %v", key.dg, err)
+	}
+}
+
+func (c *CAS) path(key blobKey) string {
+	name := fmt.Sprintf("%s_%d", key.dg.Hash, key.dg.Size)
+	if key.isExecutable {
+		name += execSuffix
+	}
+	return filepath.Join(c.dir, key.dg.Hash[:2], name)
+}
+
+func parseName(name string) (blobKey, bool) {
+	isExecutable := strings.HasSuffix(name, execSuffix)
+	name = strings.TrimSuffix(name, execSuffix)
+	hash, size, ok := strings.Cut(name, "_")
+	if !ok {
+		return blobKey{}, false
+	}
+	sz, err := strconv.ParseInt(size, 10, 64)
+	if err != nil {
+		return blobKey{}, false
+	}
+	dg, err := digest.New(hash, sz)
+	if err != nil {
+		return blobKey{}, false
+	}
+	return blobKey{dg: dg, isExecutable: isExecutable}, true
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
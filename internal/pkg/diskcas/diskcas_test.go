@@ -0,0 +1,201 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diskcas
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/digest"
+)
+
+// writeBlob writes contents to a file in a new temporary directory and returns its digest and path.
+func writeBlob(t *testing.T, contents string) (digest.Digest, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "blob")
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("WriteFile(%v) failed: %v", path, err)
+	}
+	return digest.NewFromBlob([]byte(contents)), path
+}
+
+func TestAddMaterialize(t *testing.T) {
+	for _, useHardlinks := range []bool{false, true} {
+		dir := t.TempDir()
+		c, err := New(dir, 1<<20)
+		if err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		c.UseHardlinks = useHardlinks
+		dg, src := writeBlob(t, "contents")
+		dst := filepath.Join(t.TempDir(), "out", "file")
+		if err := c.Materialize(dg, false, dst); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Materialize() before Add() = %v, want %v", err, ErrNotFound)
+		}
+		if err := c.Add(dg, false, src); err != nil {
+			t.Fatalf("Add() failed: %v", err)
+		}
+		// Reload the CAS from disk to check that it persists.
+		if c, err = New(dir, 1<<20); err != nil {
+			t.Fatalf("New() failed: %v", err)
+		}
+		c.UseHardlinks = useHardlinks
+		if !c.Contains(dg, false) || c.Contains(dg, true) {
+			t.Errorf("Contains(%v) = %v, Contains(%v, executable) = %v, want true, false", dg, c.Contains(dg, false), dg, c.Contains(dg, true))
+		}
+		if err := os.WriteFile(src, []byte("modified"), 0644); err != nil {
+			t.Fatalf("WriteFile(%v) failed: %v", src, err)
+		}
+		if err := c.Materialize(dg, false, dst); err != nil {
+			t.Fatalf("Materialize() failed: %v", err)
+		}
+		got, err := os.ReadFile(dst)
+		if err != nil {
+			t.Fatalf("ReadFile(%v) failed: %v", dst, err)
+		}
+		if string(got) != "contents" {
+			t.Errorf("Materialize(hardlinks=%v) wrote %q, want %q", useHardlinks, got, "contents")
+		}
+		// Materializing over an existing output must not modify the stored blob.
+		if err := c.Materialize(dg, false, dst); err != nil {
+			t.Fatalf("Materialize() over an existing output failed: %v", err)
+		}
+		if got, err := os.ReadFile(c.path(blobKey{dg: dg})); err != nil || string(got) != "contents" {
+			t.Errorf("Stored blob = %q, %v, want %q", got, err, "contents")
+		}
+	}
+}
+
+func TestHardlinkedOutputs(t *testing.T) {
+	c, err := New(t.TempDir(), 1<<20)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	c.UseHardlinks = true
+	dg, src := writeBlob(t, "contents")
+	if err := c.Add(dg, false, src); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	out := t.TempDir()
+	first := filepath.Join(out, "first")
+	if err := c.Materialize(dg, false, first); err != nil {
+		t.Fatalf("Materialize(%v) failed: %v", first, err)
+	}
+	blob, err := os.Stat(c.path(blobKey{dg: dg}))
+	if err != nil {
+		t.Fatalf("Stat() of the stored blob failed: %v", err)
+	}
+	firstInfo, err := os.Stat(first)
+	if err != nil {
+		t.Fatalf("Stat(%v) failed: %v", first, err)
+	}
+	if !os.SameFile(blob, firstInfo) {
+		t.Skip("Outputs are not hard linked on this filesystem")
+	}
+	// Materializing the blob again must not update the times of the first output.
+	second := filepath.Join(out, "second")
+	if err := c.Materialize(dg, false, second); err != nil {
+		t.Fatalf("Materialize(%v) failed: %v", second, err)
+	}
+	if info, err := os.Stat(first); err != nil || !info.ModTime().Equal(firstInfo.ModTime()) {
+		t.Errorf("Stat(%v) = %v, %v, want modification time %v", first, info.ModTime(), err, firstInfo.ModTime())
+	}
+
+	// A blob modified in place through an output must not be reused.
+	if err := os.Chmod(first, 0644); err != nil {
+		t.Fatalf("Chmod(%v) failed: %v", first, err)
+	}
+	if err := os.WriteFile(first, []byte("modified"), 0644); err != nil {
+		t.Fatalf("WriteFile(%v) failed: %v", first, err)
+	}
+	if err := c.Materialize(dg, false, filepath.Join(out, "third")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Materialize() of a modified blob = %v, want %v", err, ErrNotFound)
+	}
+	if c.Contains(dg, false) {
+		t.Errorf("Contains(%v) = true after the blob was modified, want false", dg)
+	}
+
+	// After a restart, a blob modified through an output is detected by its digest even if its
+	// size and times were preserved.
+	if err := c.Add(dg, false, src); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	fourth := filepath.Join(out, "fourth")
+	if err := c.Materialize(dg, false, fourth); err != nil {
+		t.Fatalf("Materialize(%v) failed: %v", fourth, err)
+	}
+	info, err := os.Stat(fourth)
+	if err != nil {
+		t.Fatalf("Stat(%v) failed: %v", fourth, err)
+	}
+	if err := os.Chmod(fourth, 0644); err != nil {
+		t.Fatalf("Chmod(%v) failed: %v", fourth, err)
+	}
+	if err := os.WriteFile(fourth, []byte("modified"), 0644); err != nil {
+		t.Fatalf("WriteFile(%v) failed: %v", fourth, err)
+	}
+	if err := os.Chmod(fourth, info.Mode()); err != nil {
+		t.Fatalf("Chmod(%v) failed: %v", fourth, err)
+	}
+	if err := os.Chtimes(fourth, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatalf("Chtimes(%v) failed: %v", fourth, err)
+	}
+	restarted, err := New(c.dir, 1<<20)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	restarted.UseHardlinks = true
+	if err := restarted.Materialize(dg, false, filepath.Join(out, "fifth")); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Materialize() of a blob modified before a restart = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestEviction(t *testing.T) {
+	c, err := New(t.TempDir(), 20)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	dgA, srcA := writeBlob(t, "aaaaaaaaaa")
+	dgB, srcB := writeBlob(t, "bbbbbbbbbb")
+	dgC, srcC := writeBlob(t, "cccccccccc")
+	for _, b := range []struct {
+		dg  digest.Digest
+		src string
+	}{{dgA, srcA}, {dgB, srcB}} {
+		if err := c.Add(b.dg, false, b.src); err != nil {
+			t.Fatalf("Add(%v) failed: %v", b.src, err)
+		}
+	}
+	// Use a so that b is the least recently used blob.
+	if err := c.Materialize(dgA, false, filepath.Join(t.TempDir(), "a")); err != nil {
+		t.Fatalf("Materialize(a) failed: %v", err)
+	}
+	if err := c.Add(dgC, false, srcC); err != nil {
+		t.Fatalf("Add(c) failed: %v", err)
+	}
+	for dg, want := range map[digest.Digest]bool{dgA: true, dgB: false, dgC: true} {
+		if got := c.Contains(dg, false); got != want {
+			t.Errorf("Contains(%v) = %v, want %v", dg, got, want)
+		}
+	}
+	if got := c.Size(); got != 20 {
+		t.Errorf("Size() = %v, want 20", got)
+	}
+	if _, err := os.Stat(c.path(blobKey{dg: dgB})); !os.IsNotExist(err) {
+		t.Errorf("Stat(b) = %v, want the evicted blob to be removed", err)
+	}
+}
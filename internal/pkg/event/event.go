@@ -59,6 +59,21 @@ const (
 	// AtomicOutputOverhead: time spent writing outputs atomically.
 	AtomicOutputOverhead = "AtomicOutputOverhead"
 
+	// InFlightWait: time spent waiting for an identical action that was already in flight.
+	InFlightWait = "InFlightWait"
+
+	// LocalCacheLookup: time spent looking up the local action cache and materializing the
+	// outputs of a hit.
+	LocalCacheLookup = "LocalCacheLookup"
+
+	// LocalCacheUpdate: time spent storing the outputs of a local execution in the local action
+	// cache.
+	LocalCacheUpdate = "LocalCacheUpdate"
+
+	// DiskCASMaterialize: time spent materializing outputs of a remote action from the local disk
+	// CAS instead of downloading them.
+	DiskCASMaterialize = "DiskCASMaterialize"
+
 	// PostBuildMetricsUpload: time spent post build to upload metrics to Cloud Monitoring.
 	PostBuildMetricsUpload = "PostBuildMetricsUpload"
 
@@ -0,0 +1,205 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package filetrace traces the files accessed by locally executed commands, to verify the inputs
+// computed for them by input processors.
+//
+// On Linux, commands are traced with ptrace by a tracer process, which runs the command, records
+// the paths of the files it successfully opens, stats, reads links of or executes, and writes them
+// to a trace file once the command exits. All the processes and threads spawned by the command are
+// traced. Tracing stops the command on every syscall, so it noticeably slows it down.
+//
+// Binaries that trace commands must call Init at the very beginning of main, since the tracer
+// process is started by re-executing the current binary.
+package filetrace
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
+)
+
+// initArg is the first argument of the current binary when re-executed to trace a command.
+const initArg = "--reclient_filetrace_init"
+
+// ErrUnsupported is returned when file tracing is not supported on the current platform.
+var ErrUnsupported = errors.New("file tracing is not supported on this platform")
+
+// Files are the absolute paths of the files accessed by a traced command.
+type Files struct {
+	// Read are the files the command read, stat-ed or executed.
+	Read []string
+	// Written are the files the command opened for writing.
+	Written []string
+	// Error is set if the command could not be traced, in which case it was run untraced.
+	Error string `json:",omitempty"`
+}
+
+// Trace is the file a traced command writes the files it accessed to.
+type Trace struct {
+	path string
+}
+
+// Wrap returns a copy of cmd that runs it under the tracer, and the trace the accessed files are
+// written to. The trace is created in tmpDir, or in the system temporary directory if tmpDir is
+// empty, and must be closed once read.
+func Wrap(cmd *command.Command, tmpDir string) (*command.Command, *Trace, error) {
+	if !supported {
+		return nil, nil, ErrUnsupported
+	}
+	if len(cmd.Args) < 1 {
+		return nil, nil, fmt.Errorf("command must have at least 1 argument")
+	}
+	path := cmd.Args[0]
+	if !strings.Contains(path, string(filepath.Separator)) {
+		var err error
+		if path, err = exec.LookPath(path); err != nil {
+			return nil, nil, err
+		}
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.CreateTemp(tmpDir, "reclient-trace-*.json")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create trace file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, nil, err
+	}
+	traced := *cmd
+	traced.Args = append([]string{self, initArg, f.Name(), path}, cmd.Args...)
+	return &traced, &Trace{path: f.Name()}, nil
+}
+
+// Files returns the files accessed by the traced command, once it has exited.
+func (t *Trace) Files() (*Files, error) {
+	blob, err := os.ReadFile(t.path)
+	if err != nil {
+		return nil, err
+	}
+	if len(blob) == 0 {
+		return nil, fmt.Errorf("command was not traced")
+	}
+	files := &Files{}
+	if err := json.Unmarshal(blob, files); err != nil {
+		return nil, fmt.Errorf("failed to parse trace %v: %w", t.path, err)
+	}
+	if files.Error != "" {
+		return nil, fmt.Errorf("failed to trace command: %v", files.Error)
+	}
+	return files, nil
+}
+
+// Close removes the trace file.
+func (t *Trace) Close() error {
+	return os.Remove(t.path)
+}
+
+// Diff compares the declared inputs of cmd with the files it accessed. It returns the files under
+// the exec root that the command read without declaring them as inputs, and the declared inputs
+// that the command did not access. Directories, files that do not exist after the command
+// finished, files the command wrote and declared outputs are ignored. All paths are relative to the
+// exec root.
+func Diff(cmd *command.Command, files *Files) (underdeclared, overdeclared []string) {
+	roots := []string{filepath.Clean(cmd.ExecRoot)}
+	// Traced paths are built from the working directories of the traced processes, in which
+	// symlinks are resolved.
+	if r, err := filepath.EvalSymlinks(cmd.ExecRoot); err == nil && r != roots[0] {
+		roots = append(roots, r)
+	}
+	rel := func(p string) (string, bool) {
+		for _, r := range roots {
+			if rp, err := filepath.Rel(r, p); err == nil && rp != "." && rp != ".." && !strings.HasPrefix(rp, ".."+string(filepath.Separator)) {
+				return rp, true
+			}
+		}
+		return "", false
+	}
+	var outs []string
+	for _, o := range cmd.OutputFiles {
+		outs = append(outs, filepath.Clean(filepath.Join(cmd.WorkingDir, o)))
+	}
+	for _, o := range cmd.OutputDirs {
+		outs = append(outs, filepath.Clean(filepath.Join(cmd.WorkingDir, o)))
+	}
+	written := make(map[string]bool)
+	for _, w := range files.Written {
+		if p, ok := rel(w); ok {
+			written[p] = true
+		}
+	}
+	var inputs []string
+	if cmd.InputSpec != nil {
+		for _, in := range cmd.InputSpec.Inputs {
+			inputs = append(inputs, filepath.Clean(in))
+		}
+		for _, vi := range cmd.InputSpec.VirtualInputs {
+			inputs = append(inputs, filepath.Clean(vi.Path))
+		}
+	}
+	accessed := make(map[string]bool)
+	for _, r := range files.Read {
+		p, ok := rel(r)
+		if !ok || accessed[p] {
+			continue
+		}
+		accessed[p] = true
+		if written[p] || under(p, outs) || under(p, inputs) {
+			continue
+		}
+		if fi, err := os.Stat(filepath.Join(cmd.ExecRoot, p)); err != nil || fi.IsDir() {
+			continue
+		}
+		underdeclared = append(underdeclared, p)
+	}
+	seen := make(map[string]bool)
+	for _, in := range inputs {
+		if accessed[in] || seen[in] {
+			continue
+		}
+		seen[in] = true
+		used := false
+		for p := range accessed {
+			if under(p, []string{in}) {
+				used = true
+				break
+			}
+		}
+		if !used {
+			overdeclared = append(overdeclared, in)
+		}
+	}
+	sort.Strings(underdeclared)
+	sort.Strings(overdeclared)
+	return underdeclared, overdeclared
+}
+
+// under returns whether path is one of the given paths or contained in one of them.
+func under(path string, paths []string) bool {
+	for _, p := range paths {
+		if path == p || p == "." || strings.HasPrefix(path, p+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
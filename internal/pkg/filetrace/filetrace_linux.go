@@ -0,0 +1,300 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux && (amd64 || arm64)
+
+package filetrace
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"syscall"
+)
+
+const (
+	supported = true
+
+	// ptraceOExitKill kills the tracees if the tracer exits.
+	ptraceOExitKill = 0x100000
+	// syscallTrap is the stop signal of tracees stopped at the entry or exit of a syscall.
+	syscallTrap = syscall.SIGTRAP | 0x80
+	atFDCWD     = -100
+	// maxPath is the maximum length of the paths read from the memory of tracees.
+	maxPath = 4096
+	// writeFlags are the open flags of files opened for writing.
+	writeFlags = syscall.O_WRONLY | syscall.O_RDWR | syscall.O_CREAT | syscall.O_TRUNC
+)
+
+// fileSyscall describes the arguments of a syscall accessing a file by path.
+type fileSyscall struct {
+	// dirFD is the index of the directory file descriptor argument relative paths are resolved
+	// against, or -1 if they are resolved against the working directory.
+	dirFD int
+	// path is the index of the path argument.
+	path int
+	// flags is the index of the open flags argument, or -1 if the syscall does not open the file.
+	flags int
+	// openHow is set if the flags argument points to a struct open_how rather than holding the flags.
+	openHow bool
+	// write is set if the syscall always writes the file.
+	write bool
+}
+
+// Init traces a command if the current process was started as a tracer, in which case it exits
+// with the exit code of the command once it is done. Otherwise, it is a noop.
+func Init() {
+	if len(os.Args) < 5 || os.Args[1] != initArg {
+		return
+	}
+	os.Exit(runTracer(os.Args[2], os.Args[3], os.Args[4:]))
+}
+
+// runTracer runs the command with the given path and args, writes the files it accessed to out and
+// returns its exit code. If the command cannot be traced, it is executed untraced and this only
+// returns on failure.
+func runTracer(out, path string, args []string) int {
+	// The tracees can only be controlled by the thread that started them.
+	runtime.LockOSThread()
+	t := &tracer{
+		inSyscall: make(map[int]bool),
+		pending:   make(map[int]*access),
+		read:      make(map[string]bool),
+		written:   make(map[string]bool),
+	}
+	pid, err := t.start(path, args)
+	if err != nil {
+		writeFiles(out, &Files{Error: err.Error()})
+		err = syscall.Exec(path, args, os.Environ())
+		fmt.Fprintf(os.Stderr, "reclient: failed to execute %v: %v\n", path, err)
+		return 127
+	}
+	ws, err := t.run(pid)
+	files := &Files{Read: keys(t.read), Written: keys(t.written)}
+	if err != nil {
+		files.Error = err.Error()
+	}
+	if err := writeFiles(out, files); err != nil {
+		fmt.Fprintf(os.Stderr, "reclient: failed to write file trace: %v\n", err)
+	}
+	switch {
+	case ws.Exited():
+		return ws.ExitStatus()
+	case ws.Signaled():
+		return 128 + int(ws.Signal())
+	}
+	return 1
+}
+
+func writeFiles(path string, files *Files) error {
+	blob, err := json.Marshal(files)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, blob, 0600)
+}
+
+func keys(m map[string]bool) []string {
+	ks := make([]string, 0, len(m))
+	for k := range m {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+	return ks
+}
+
+// access is a file access of a tracee, recorded at the entry of a syscall until its exit tells
+// whether it succeeded.
+type access struct {
+	path  string
+	write bool
+}
+
+type tracer struct {
+	// inSyscall records whether each tracee is stopped between the entry and the exit of a syscall.
+	inSyscall map[int]bool
+	// pending are the file accesses of the tracees that are in a syscall accessing a file.
+	pending map[int]*access
+	read    map[string]bool
+	written map[string]bool
+}
+
+// start starts the traced command, stopped after its execution.
+func (t *tracer) start(path string, args []string) (int, error) {
+	c := &exec.Cmd{
+		Path:        path,
+		Args:        args,
+		Stdin:       os.Stdin,
+		Stdout:      os.Stdout,
+		Stderr:      os.Stderr,
+		SysProcAttr: &syscall.SysProcAttr{Ptrace: true},
+	}
+	if err := c.Start(); err != nil {
+		return 0, err
+	}
+	// The execution of the command itself is not traced.
+	if abs, err := filepath.Abs(path); err == nil {
+		t.read[abs] = true
+	}
+	pid := c.Process.Pid
+	var ws syscall.WaitStatus
+	if _, err := syscall.Wait4(pid, &ws, syscall.WALL, nil); err != nil {
+		return 0, err
+	}
+	opts := syscall.PTRACE_O_TRACESYSGOOD | syscall.PTRACE_O_TRACECLONE | syscall.PTRACE_O_TRACEFORK |
+		syscall.PTRACE_O_TRACEVFORK | syscall.PTRACE_O_TRACEEXEC | ptraceOExitKill
+	if err := syscall.PtraceSetOptions(pid, opts); err != nil {
+		syscall.Kill(pid, syscall.SIGKILL)
+		return 0, fmt.Errorf("failed to set ptrace options: %w", err)
+	}
+	if err := syscall.PtraceSyscall(pid, 0); err != nil {
+		syscall.Kill(pid, syscall.SIGKILL)
+		return 0, err
+	}
+	return pid, nil
+}
+
+// run traces the command with the given pid and its descendants until they all exited, and returns
+// the wait status of the command.
+func (t *tracer) run(pid int) (syscall.WaitStatus, error) {
+	var status syscall.WaitStatus
+	for {
+		var ws syscall.WaitStatus
+		tid, err := syscall.Wait4(-1, &ws, syscall.WALL, nil)
+		if err == syscall.EINTR {
+			continue
+		}
+		if err == syscall.ECHILD {
+			return status, nil
+		}
+		if err != nil {
+			return status, err
+		}
+		if ws.Exited() || ws.Signaled() {
+			delete(t.inSyscall, tid)
+			delete(t.pending, tid)
+			if tid == pid {
+				status = ws
+			}
+			continue
+		}
+		if !ws.Stopped() {
+			continue
+		}
+		sig := 0
+		switch s := ws.StopSignal(); {
+		case s == syscallTrap:
+			t.syscallStop(tid)
+		case s == syscall.SIGTRAP && ws.TrapCause() == syscall.PTRACE_EVENT_EXEC:
+			// A thread other than the thread group leader executing a program takes over the thread
+			// ID of the leader.
+			if former, err := syscall.PtraceGetEventMsg(tid); err == nil && int(former) != tid {
+				t.inSyscall[tid], t.pending[tid] = t.inSyscall[int(former)], t.pending[int(former)]
+				delete(t.inSyscall, int(former))
+				delete(t.pending, int(former))
+			}
+		case s == syscall.SIGTRAP && ws.TrapCause() > 0:
+			// Clone, fork and vfork events. The new tracee is attached automatically.
+		case s == syscall.SIGSTOP:
+			// New tracees start with a SIGSTOP, which is not delivered to them. A genuine SIGSTOP
+			// is lost, which is fine for build commands.
+		default:
+			sig = int(s)
+		}
+		// The tracee might have been killed in the meantime.
+		syscall.PtraceSyscall(tid, sig)
+	}
+}
+
+// syscallStop handles a tracee stopped at the entry or exit of a syscall.
+func (t *tracer) syscallStop(tid int) {
+	var regs syscall.PtraceRegs
+	if err := syscall.PtraceGetRegs(tid, &regs); err != nil {
+		return
+	}
+	if t.inSyscall[tid] {
+		t.inSyscall[tid] = false
+		a := t.pending[tid]
+		delete(t.pending, tid)
+		if a == nil || syscallRet(&regs) < 0 {
+			return
+		}
+		if a.write {
+			t.written[a.path] = true
+		} else {
+			t.read[a.path] = true
+		}
+		return
+	}
+	t.inSyscall[tid] = true
+	fs, ok := fileSyscalls[syscallNr(&regs)]
+	if !ok {
+		return
+	}
+	path, err := readString(tid, uintptr(syscallArg(&regs, fs.path)))
+	if err != nil || path == "" {
+		return
+	}
+	if !filepath.IsAbs(path) {
+		dir := fmt.Sprintf("/proc/%d/cwd", tid)
+		if fs.dirFD >= 0 {
+			if fd := int32(syscallArg(&regs, fs.dirFD)); fd != atFDCWD {
+				dir = fmt.Sprintf("/proc/%d/fd/%d", tid, fd)
+			}
+		}
+		base, err := os.Readlink(dir)
+		if err != nil {
+			return
+		}
+		path = filepath.Join(base, path)
+	}
+	a := &access{path: filepath.Clean(path), write: fs.write}
+	if fs.flags >= 0 {
+		flags := syscallArg(&regs, fs.flags)
+		if fs.openHow {
+			// The flags are the first field of struct open_how.
+			buf := make([]byte, 8)
+			if _, err := syscall.PtracePeekData(tid, uintptr(flags), buf); err != nil {
+				return
+			}
+			flags = binary.LittleEndian.Uint64(buf)
+		}
+		a.write = a.write || flags&writeFlags != 0
+	}
+	t.pending[tid] = a
+}
+
+// readString reads a NUL terminated string at the given address of the memory of a tracee.
+func readString(tid int, addr uintptr) (string, error) {
+	var path []byte
+	buf := make([]byte, 256)
+	for len(path) < maxPath {
+		n, err := syscall.PtracePeekData(tid, addr, buf)
+		if i := bytes.IndexByte(buf[:n], 0); i >= 0 {
+			return string(append(path, buf[:i]...)), nil
+		}
+		if err != nil {
+			return "", err
+		}
+		path = append(path, buf[:n]...)
+		addr += uintptr(n)
+	}
+	return "", fmt.Errorf("path of tracee %v longer than %v", tid, maxPath)
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux && (amd64 || arm64)
+
+package filetrace
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bazelbuild/reclient/internal/pkg/subprocess"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/outerr"
+)
+
+func TestMain(m *testing.M) {
+	Init()
+	os.Exit(m.Run())
+}
+
+func TestTrace(t *testing.T) {
+	execRoot := t.TempDir()
+	for _, f := range []string{"src/in.txt", "src/probed.txt", "src/unused.txt"} {
+		p := filepath.Join(execRoot, f)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("MkdirAll(%v) failed: %v", filepath.Dir(p), err)
+		}
+		if err := os.WriteFile(p, []byte(f), 0644); err != nil {
+			t.Fatalf("WriteFile(%v) failed: %v", p, err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(execRoot, "out"), 0755); err != nil {
+		t.Fatalf("MkdirAll() failed: %v", err)
+	}
+	cmd := &command.Command{
+		Args: []string{"/bin/sh", "-c", strings.Join([]string{
+			// Read by a child process.
+			"cat ../src/in.txt > out.txt",
+			"test -e ../src/probed.txt",
+			"test -e ../src/missing.txt || echo missing",
+			"exit 3",
+		}, "; ")},
+		ExecRoot:    execRoot,
+		WorkingDir:  "out",
+		InputSpec:   &command.InputSpec{Inputs: []string{"src/in.txt", "src/unused.txt"}},
+		OutputFiles: []string{"out.txt"},
+	}
+	traced, trace, err := Wrap(cmd, t.TempDir())
+	if err != nil {
+		t.Fatalf("Wrap() returned error: %v", err)
+	}
+	defer trace.Close()
+	oe := outerr.NewRecordingOutErr()
+	err = subprocess.SystemExecutor{}.ExecuteWithOutErr(context.Background(), traced, oe)
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Errorf("ExecuteWithOutErr() returned error %v, want exit code 3", err)
+	}
+	if got := oe.Stdout(); string(got) != "missing\n" {
+		t.Errorf("ExecuteWithOutErr() stdout = %q, want %q", got, "missing\n")
+	}
+	files, err := trace.Files()
+	if err != nil {
+		t.Fatalf("Files() returned error: %v", err)
+	}
+	read := make(map[string]bool)
+	for _, f := range files.Read {
+		read[f] = true
+	}
+	for _, f := range []string{"/bin/sh", filepath.Join(execRoot, "src/in.txt"), filepath.Join(execRoot, "src/probed.txt")} {
+		if !read[f] {
+			t.Errorf("Files().Read = %v, want it to contain %v", files.Read, f)
+		}
+	}
+	if read[filepath.Join(execRoot, "src/missing.txt")] {
+		t.Errorf("Files().Read = %v, want it not to contain files that were not found", files.Read)
+	}
+	if want := []string{filepath.Join(execRoot, "out/out.txt")}; len(files.Written) != 1 || files.Written[0] != want[0] {
+		t.Errorf("Files().Written = %v, want %v", files.Written, want)
+	}
+	under, over := Diff(cmd, files)
+	if len(under) != 1 || under[0] != "src/probed.txt" {
+		t.Errorf("Diff() returned underdeclared inputs %v, want [src/probed.txt]", under)
+	}
+	if len(over) != 1 || over[0] != "src/unused.txt" {
+		t.Errorf("Diff() returned overdeclared inputs %v, want [src/unused.txt]", over)
+	}
+}
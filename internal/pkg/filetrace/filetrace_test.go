@@ -0,0 +1,73 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filetrace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiff(t *testing.T) {
+	execRoot := t.TempDir()
+	for _, f := range []string{"src/a.h", "src/b.h", "src/unused.h", "lib/x.h", "lib/y.h", "out/foo.o", "out/tmp.txt", "unused/z.h"} {
+		p := filepath.Join(execRoot, f)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("MkdirAll(%v) failed: %v", filepath.Dir(p), err)
+		}
+		if err := os.WriteFile(p, nil, 0644); err != nil {
+			t.Fatalf("WriteFile(%v) failed: %v", p, err)
+		}
+	}
+	cmd := &command.Command{
+		ExecRoot:   execRoot,
+		WorkingDir: "out",
+		InputSpec: &command.InputSpec{
+			Inputs: []string{"src/a.h", "src/unused.h", "lib", "unused", "src/a.h"},
+			VirtualInputs: []*command.VirtualInput{
+				{Path: "src/gen.h", Contents: []byte("gen")},
+			},
+		},
+		OutputFiles: []string{"foo.o"},
+	}
+	abs := func(p string) string { return filepath.Join(execRoot, p) }
+	files := &Files{
+		Read: []string{
+			abs("src/a.h"),
+			abs("src/b.h"),
+			abs("src/gen.h"),
+			abs("lib/x.h"),
+			abs("out/foo.o"),
+			abs("out/tmp.txt"),
+			// Directories and files deleted by the command are ignored.
+			abs("src"),
+			abs("out/deleted.txt"),
+			// Files outside of the exec root are ignored.
+			"/usr/include/stdio.h",
+			execRoot,
+		},
+		Written: []string{abs("out/foo.o"), abs("out/tmp.txt")},
+	}
+	under, over := Diff(cmd, files)
+	if diff := cmp.Diff([]string{"src/b.h"}, under); diff != "" {
+		t.Errorf("Diff() returned diff in underdeclared inputs: (-want +got)\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"src/unused.h", "unused"}, over); diff != "" {
+		t.Errorf("Diff() returned diff in overdeclared inputs: (-want +got)\n%s", diff)
+	}
+}
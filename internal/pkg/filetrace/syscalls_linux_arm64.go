@@ -0,0 +1,51 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filetrace
+
+import "syscall"
+
+// Syscalls missing from the syscall package.
+const (
+	sysExecveat   = 281
+	sysStatx      = 291
+	sysOpenat2    = 437
+	sysFaccessat2 = 439
+)
+
+// fileSyscalls are the traced syscalls, by number. arm64 only has the *at variants of the syscalls
+// accessing files.
+var fileSyscalls = map[uint64]fileSyscall{
+	syscall.SYS_OPENAT:     {dirFD: 0, path: 1, flags: 2},
+	sysOpenat2:             {dirFD: 0, path: 1, flags: 2, openHow: true},
+	syscall.SYS_FSTATAT:    {dirFD: 0, path: 1, flags: -1},
+	sysStatx:               {dirFD: 0, path: 1, flags: -1},
+	syscall.SYS_FACCESSAT:  {dirFD: 0, path: 1, flags: -1},
+	sysFaccessat2:          {dirFD: 0, path: 1, flags: -1},
+	syscall.SYS_READLINKAT: {dirFD: 0, path: 1, flags: -1},
+	syscall.SYS_EXECVE:     {dirFD: -1, path: 0, flags: -1},
+	sysExecveat:            {dirFD: 0, path: 1, flags: -1},
+}
+
+func syscallNr(r *syscall.PtraceRegs) uint64 {
+	return r.Regs[8]
+}
+
+func syscallArg(r *syscall.PtraceRegs, i int) uint64 {
+	return r.Regs[i]
+}
+
+func syscallRet(r *syscall.PtraceRegs) int64 {
+	return int64(r.Regs[0])
+}
@@ -0,0 +1,215 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localresources
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+
+	log "github.com/golang/glog"
+)
+
+const (
+	// DefaultSampleInterval is the default interval at which the load of the system is sampled by
+	// AdjustToSystemLoad.
+	DefaultSampleInterval = 2 * time.Second
+
+	procDir = "/proc"
+	// minCapacityFraction is the fraction of the resources of the manager that remain available to
+	// local actions regardless of the load of the system, so that builds keep making progress.
+	minCapacityFraction = 0.1
+	// ramHeadroomFraction is the fraction of the total RAM of the manager that is kept free for
+	// other processes.
+	ramHeadroomFraction = 0.05
+	// memPressureThreshold is the share of time, in percent, some processes stalled on memory over
+	// the last 10 seconds, above which no more RAM is granted to local actions.
+	memPressureThreshold = 10
+)
+
+// systemLoad is a sample of the load of the system.
+type systemLoad struct {
+	// load1 is the load average over the last minute.
+	load1 float64
+	// memAvailableMBs is the amount of memory available for starting new processes without
+	// swapping.
+	memAvailableMBs int64
+	// memPressure is the share of time, in percent, some processes stalled on memory over the last
+	// 10 seconds. Zero if pressure stall information is not supported by the kernel.
+	memPressure float64
+}
+
+// readSystemLoad samples the load of the system from the given procfs directory.
+func readSystemLoad(dir string) (*systemLoad, error) {
+	l := &systemLoad{}
+	b, err := os.ReadFile(filepath.Join(dir, "loadavg"))
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(string(b))
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("malformed loadavg: %q", b)
+	}
+	if l.load1, err = strconv.ParseFloat(fields[0], 64); err != nil {
+		return nil, fmt.Errorf("malformed loadavg: %w", err)
+	}
+	if l.memAvailableMBs, err = readMemAvailableMBs(filepath.Join(dir, "meminfo")); err != nil {
+		return nil, err
+	}
+	// Pressure stall information is only available since Linux 4.20 and might be disabled.
+	if l.memPressure, err = readPressure(filepath.Join(dir, "pressure", "memory")); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return l, nil
+}
+
+func readMemAvailableMBs(path string) (int64, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "MemAvailable:" {
+			continue
+		}
+		kbs, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("malformed MemAvailable in %v: %w", path, err)
+		}
+		return kbs / 1024, nil
+	}
+	return 0, fmt.Errorf("no MemAvailable in %v", path)
+}
+
+// readPressure returns the avg10 value of the "some" line of a pressure stall information file.
+func readPressure(path string) (float64, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "some" {
+			continue
+		}
+		for _, f := range fields[1:] {
+			if v, ok := strings.CutPrefix(f, "avg10="); ok {
+				p, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					return 0, fmt.Errorf("malformed avg10 in %v: %w", path, err)
+				}
+				return p, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("no avg10 in %v", path)
+}
+
+// AdjustToSystemLoad periodically samples the load of the system and shrinks or grows the
+// resources available to callers of Lock accordingly, so that local actions do not overload the
+// machine when other processes are running alongside the build. The resources of the manager are
+// never exceeded. Blocks until ctx is done or the load of the system can't be sampled, e.g. on
+// platforms without procfs.
+func (m *Manager) AdjustToSystemLoad(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		l, err := readSystemLoad(procDir)
+		if err != nil {
+			log.Warningf("Failed to sample system load, local resources are no longer adjusted: %v", err)
+			cpus, ramMBs := m.total()
+			m.setCapacity(ctx, cpus, ramMBs, 0)
+			return
+		}
+		cpus, ramMBs := m.capacity(l)
+		m.setCapacity(ctx, cpus, ramMBs, interval)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// capacity returns the resources that can be used by local actions given the load of the system.
+func (m *Manager) capacity(l *systemLoad) (cpus, ramMBs int64) {
+	heldCPUs, heldRAMMBs := m.heldCPUs.Load(), m.heldRAMMBs.Load()
+	totalCPUs, totalRAMMBs := m.total()
+	// Local actions contribute to the load average, only the load of other processes reduces the
+	// CPUs available to them.
+	otherLoad := math.Max(0, l.load1-float64(heldCPUs))
+	cpus = totalCPUs - int64(math.Ceil(otherLoad))
+	ramMBs = heldRAMMBs + l.memAvailableMBs - int64(float64(totalRAMMBs)*ramHeadroomFraction)
+	if l.memPressure >= memPressureThreshold {
+		ramMBs = min(ramMBs, heldRAMMBs)
+	}
+	minCPUs := max(1, int64(float64(totalCPUs)*minCapacityFraction))
+	minRAMMBs := max(1, int64(float64(totalRAMMBs)*minCapacityFraction))
+	return min(totalCPUs, max(minCPUs, cpus)), min(totalRAMMBs, max(minRAMMBs, ramMBs))
+}
+
+// setCapacity makes the given resources available to callers of Lock. Resources are withheld by
+// reserving them in the semaphores: while resources can be withheld immediately when they are
+// free, withholding resources that are locked waits for them to be released, for up to timeout.
+func (m *Manager) setCapacity(ctx context.Context, cpus, ramMBs int64, timeout time.Duration) {
+	m.capMu.Lock()
+	defer m.capMu.Unlock()
+	m.setCapacityLocked(ctx, cpus, ramMBs, timeout)
+}
+
+func (m *Manager) setCapacityLocked(ctx context.Context, cpus, ramMBs int64, timeout time.Duration) {
+	prevCPUs, prevRAMMBs := m.sizeCPUs-m.reservedCPUs, m.sizeRAMMBs-m.reservedRAMMBs
+	m.reservedCPUs = reserve(ctx, m.cpus, m.reservedCPUs, m.sizeCPUs-cpus, timeout/2)
+	m.reservedRAMMBs = reserve(ctx, m.ram, m.reservedRAMMBs, m.sizeRAMMBs-ramMBs, timeout/2)
+	if newCPUs, newRAMMBs := m.sizeCPUs-m.reservedCPUs, m.sizeRAMMBs-m.reservedRAMMBs; newCPUs != prevCPUs || newRAMMBs != prevRAMMBs {
+		log.V(1).Infof("Local resources adjusted: cpus=%v(target=%v), ramMBs=%v(target=%v)", newCPUs, cpus, newRAMMBs, ramMBs)
+	}
+}
+
+// reserve changes the amount reserved in s to target and returns the new amount reserved, which
+// falls short of target if the resources were not released in time.
+func reserve(ctx context.Context, s *semaphore.Weighted, reserved, target int64, timeout time.Duration) int64 {
+	if target <= reserved {
+		s.Release(reserved - target)
+		return target
+	}
+	for n := target - reserved; n > 0; {
+		if s.TryAcquire(n) {
+			reserved += n
+			n = target - reserved
+			continue
+		}
+		n /= 2
+	}
+	if reserved == target || timeout <= 0 {
+		return reserved
+	}
+	// Waiting for the remaining resources also prevents new local actions from starting until
+	// they are released.
+	tCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := s.Acquire(tCtx, target-reserved); err != nil {
+		return reserved
+	}
+	return target
+}
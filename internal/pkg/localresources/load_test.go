@@ -0,0 +1,223 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localresources
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeProcFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("MkdirAll(%v) failed: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile(%v) failed: %v", path, err)
+		}
+	}
+	return dir
+}
+
+func TestReadSystemLoad(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  systemLoad
+	}{
+		{
+			name: "WithPressure",
+			files: map[string]string{
+				"loadavg": "3.52 2.10 1.05 4/1234 5678\n",
+				"meminfo": "MemTotal:       16384000 kB\nMemFree:         1024000 kB\nMemAvailable:    4096000 kB\n",
+				"pressure/memory": "some avg10=12.50 avg60=3.00 avg300=1.00 total=123\n" +
+					"full avg10=1.00 avg60=0.50 avg300=0.10 total=45\n",
+			},
+			want: systemLoad{load1: 3.52, memAvailableMBs: 4000, memPressure: 12.5},
+		},
+		{
+			name: "WithoutPressure",
+			files: map[string]string{
+				"loadavg": "0.00 0.01 0.05 1/100 42\n",
+				"meminfo": "MemTotal:       16384000 kB\nMemAvailable:    2048000 kB\n",
+			},
+			want: systemLoad{load1: 0, memAvailableMBs: 2000},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := readSystemLoad(writeProcFiles(t, tc.files))
+			if err != nil {
+				t.Fatalf("readSystemLoad() returned error: %v", err)
+			}
+			if *got != tc.want {
+				t.Errorf("readSystemLoad() = %+v, want %+v", *got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReadSystemLoadErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{
+			name:  "NoProcfs",
+			files: map[string]string{},
+		},
+		{
+			name: "NoMemAvailable",
+			files: map[string]string{
+				"loadavg": "1.00 1.00 1.00 1/100 42\n",
+				"meminfo": "MemTotal:       16384000 kB\n",
+			},
+		},
+		{
+			name: "MalformedPressure",
+			files: map[string]string{
+				"loadavg":         "1.00 1.00 1.00 1/100 42\n",
+				"meminfo":         "MemAvailable:    2048000 kB\n",
+				"pressure/memory": "some avg10=abc\n",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got, err := readSystemLoad(writeProcFiles(t, tc.files)); err == nil {
+				t.Errorf("readSystemLoad() = %+v, want error", *got)
+			}
+		})
+	}
+}
+
+func TestCapacity(t *testing.T) {
+	tests := []struct {
+		name       string
+		heldCPUs   int64
+		heldRAMMBs int64
+		load       systemLoad
+		wantCPUs   int64
+		wantRAMMBs int64
+	}{
+		{
+			name:       "Idle",
+			load:       systemLoad{load1: 0, memAvailableMBs: 20000},
+			wantCPUs:   20,
+			wantRAMMBs: 10000,
+		},
+		{
+			name:       "OtherLoad",
+			load:       systemLoad{load1: 4.2, memAvailableMBs: 6000},
+			wantCPUs:   15,
+			wantRAMMBs: 5500,
+		},
+		{
+			name:       "OwnLoad",
+			heldCPUs:   8,
+			heldRAMMBs: 4000,
+			load:       systemLoad{load1: 8.5, memAvailableMBs: 2000},
+			wantCPUs:   19,
+			wantRAMMBs: 5500,
+		},
+		{
+			name:       "Overloaded",
+			load:       systemLoad{load1: 64, memAvailableMBs: 100},
+			wantCPUs:   2,
+			wantRAMMBs: 1000,
+		},
+		{
+			name:       "MemoryPressure",
+			heldCPUs:   2,
+			heldRAMMBs: 3000,
+			load:       systemLoad{load1: 2, memAvailableMBs: 8000, memPressure: 25},
+			wantCPUs:   20,
+			wantRAMMBs: 3000,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewManager(20, 10000)
+			m.heldCPUs.Store(tc.heldCPUs)
+			m.heldRAMMBs.Store(tc.heldRAMMBs)
+			gotCPUs, gotRAMMBs := m.capacity(&tc.load)
+			if gotCPUs != tc.wantCPUs || gotRAMMBs != tc.wantRAMMBs {
+				t.Errorf("capacity(%+v) = %v, %v, want %v, %v", tc.load, gotCPUs, gotRAMMBs, tc.wantCPUs, tc.wantRAMMBs)
+			}
+		})
+	}
+}
+
+func TestSetCapacity(t *testing.T) {
+	ctx := context.Background()
+	m := NewManager(4, 4096)
+	rel, err := m.Lock(ctx, 2, 1024)
+	if err != nil {
+		t.Fatalf("Lock(2, 1024) returned error: %v", err)
+	}
+	// Only the resources that are not locked can be withheld right away.
+	m.setCapacity(ctx, 1, 1024, 0)
+	if m.reservedCPUs != 2 || m.reservedRAMMBs != 3072 {
+		t.Errorf("setCapacity(1, 1024) reserved %v CPUs and %v MBs, want 2 and 3072", m.reservedCPUs, m.reservedRAMMBs)
+	}
+	tCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if rel2, err := m.Lock(tCtx, 1, 1); err == nil {
+		rel2()
+		t.Errorf("Lock(1, 1) succeeded with all resources locked or withheld, want error")
+	}
+	rel()
+	m.setCapacity(ctx, 1, 1024, time.Second)
+	if m.reservedCPUs != 3 || m.reservedRAMMBs != 3072 {
+		t.Errorf("setCapacity(1, 1024) reserved %v CPUs and %v MBs, want 3 and 3072", m.reservedCPUs, m.reservedRAMMBs)
+	}
+	rel, err = m.Lock(ctx, 1, 1024)
+	if err != nil {
+		t.Fatalf("Lock(1, 1024) returned error: %v", err)
+	}
+	rel()
+	m.setCapacity(ctx, 4, 4096, 0)
+	if m.reservedCPUs != 0 || m.reservedRAMMBs != 0 {
+		t.Errorf("setCapacity(4, 4096) reserved %v CPUs and %v MBs, want 0 and 0", m.reservedCPUs, m.reservedRAMMBs)
+	}
+	rel, err = m.Lock(ctx, 4, 4096)
+	if err != nil {
+		t.Fatalf("Lock(4, 4096) returned error: %v", err)
+	}
+	rel()
+}
+
+func TestSetCapacityWaitsForLockedResources(t *testing.T) {
+	ctx := context.Background()
+	m := NewManager(4, 4096)
+	rel, err := m.Lock(ctx, 4, 1024)
+	if err != nil {
+		t.Fatalf("Lock(4, 1024) returned error: %v", err)
+	}
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		rel()
+	}()
+	m.setCapacity(ctx, 2, 4096, 10*time.Second)
+	if m.reservedCPUs != 2 {
+		t.Errorf("setCapacity(2, 4096) reserved %v CPUs, want 2", m.reservedCPUs)
+	}
+}
@@ -18,6 +18,9 @@ package localresources
 import (
 	"context"
 	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"golang.org/x/sync/semaphore"
 
@@ -28,9 +31,27 @@ import (
 type Manager struct {
 	cpus *semaphore.Weighted
 	ram  *semaphore.Weighted
+	// sizeCPUs and sizeRAMMBs are the sizes of the semaphores, i.e. the most resources the manager
+	// can ever make available.
+	sizeCPUs   int64
+	sizeRAMMBs int64
 
+	// mu guards totalCPUs and totalRAMMBs, the resources of the manager, which SetFraction may set
+	// below the sizes of the semaphores.
+	mu          sync.RWMutex
 	totalCPUs   int64
 	totalRAMMBs int64
+
+	// heldCPUs and heldRAMMBs are the resources currently locked by callers of Lock.
+	heldCPUs   atomic.Int64
+	heldRAMMBs atomic.Int64
+	// capMu serializes changes to the resources available to callers of Lock.
+	capMu sync.Mutex
+	// reservedCPUs and reservedRAMMBs are the resources withheld from callers of Lock because of
+	// the load of the system or the fraction of the resources of the machine in use. Guarded by
+	// capMu.
+	reservedCPUs   int64
+	reservedRAMMBs int64
 }
 
 // NewDefaultManager retrieves a Manager with default local resources.
@@ -41,11 +62,11 @@ func NewDefaultManager() *Manager {
 }
 
 // NewFractionalDefaultManager retrieves a Manager with the given fraction of default local
-// resources.
+// resources. The fraction can later be changed with SetFraction.
 func NewFractionalDefaultManager(fraction float64) *Manager {
-	numCPU := int64(float64(runtime.NumCPU()) * fraction)
-	ramMBs := int64(float64(TotalRAMMBs()) * fraction)
-	return NewManager(numCPU, ramMBs)
+	m := NewDefaultManager()
+	m.SetFraction(context.Background(), fraction, 0)
+	return m
 }
 
 // NewManager is used to initialize the manager with non default local resources.
@@ -53,20 +74,46 @@ func NewManager(cpus, ramMBs int64) *Manager {
 	return &Manager{
 		cpus:        semaphore.NewWeighted(max(1, cpus)),
 		ram:         semaphore.NewWeighted(max(1, ramMBs)),
+		sizeCPUs:    max(1, cpus),
+		sizeRAMMBs:  max(1, ramMBs),
 		totalCPUs:   max(1, cpus),
 		totalRAMMBs: max(1, ramMBs),
 	}
 }
 
+// SetFraction changes the resources of the manager to the given fraction of the resources it was
+// created with, withholding locked resources once they are released, for up to timeout. If the
+// load of the system is being adjusted to, the resources are further adjusted at the next sample.
+func (m *Manager) SetFraction(ctx context.Context, fraction float64, timeout time.Duration) {
+	cpus := min(m.sizeCPUs, max(1, int64(float64(m.sizeCPUs)*fraction)))
+	ramMBs := min(m.sizeRAMMBs, max(1, int64(float64(m.sizeRAMMBs)*fraction)))
+	m.mu.Lock()
+	m.totalCPUs, m.totalRAMMBs = cpus, ramMBs
+	m.mu.Unlock()
+	m.capMu.Lock()
+	defer m.capMu.Unlock()
+	// The resources are read again, as a concurrent call might have changed them while this one
+	// waited for the previous change to complete.
+	cpus, ramMBs = m.total()
+	m.setCapacityLocked(ctx, cpus, ramMBs, timeout)
+}
+
+// total returns the resources of the manager.
+func (m *Manager) total() (cpus, ramMBs int64) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.totalCPUs, m.totalRAMMBs
+}
+
 // Lock locks the desired resources and returns a function to release them.
 func (m *Manager) Lock(ctx context.Context, cpus, ramMBs int64) (func(), error) {
 	if m == nil {
 		return func() {}, nil
 	}
-	if cpus > m.totalCPUs || ramMBs > m.totalRAMMBs {
-		log.Warningf("Capping request to available system resources, cpu-max=%v(req=%v), ramMBs-max=%v(req=%v)", m.totalCPUs, cpus, m.totalRAMMBs, ramMBs)
-		cpus = min(cpus, m.totalCPUs)
-		ramMBs = min(ramMBs, m.totalRAMMBs)
+	if totalCPUs, totalRAMMBs := m.total(); cpus > totalCPUs || ramMBs > totalRAMMBs {
+		log.Warningf("Capping request to available system resources, cpu-max=%v(req=%v), ramMBs-max=%v(req=%v)", totalCPUs, cpus, totalRAMMBs, ramMBs)
+		cpus = min(cpus, totalCPUs)
+		ramMBs = min(ramMBs, totalRAMMBs)
 	}
 	if err := m.cpus.Acquire(ctx, cpus); err != nil {
 		return nil, err
@@ -75,7 +122,11 @@ func (m *Manager) Lock(ctx context.Context, cpus, ramMBs int64) (func(), error)
 		m.cpus.Release(cpus)
 		return nil, err
 	}
+	m.heldCPUs.Add(cpus)
+	m.heldRAMMBs.Add(ramMBs)
 	return func() {
+		m.heldCPUs.Add(-cpus)
+		m.heldRAMMBs.Add(-ramMBs)
 		m.cpus.Release(cpus)
 		m.ram.Release(ramMBs)
 	}, nil
@@ -65,3 +65,29 @@ func TestMinimumCpus(t *testing.T) {
 		t.Errorf("Expected 1 totalCPUs, got %v", mgr.totalCPUs)
 	}
 }
+
+func TestSetFraction(t *testing.T) {
+	ctx := context.Background()
+	m := NewManager(4, 4096)
+	m.SetFraction(ctx, 0.5, 0)
+	if cpus, ramMBs := m.total(); cpus != 2 || ramMBs != 2048 {
+		t.Errorf("SetFraction(0.5) set resources to %v CPUs and %v MBs, want 2 and 2048", cpus, ramMBs)
+	}
+	tCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	rel, err := m.Lock(tCtx, 2, 2048)
+	if err != nil {
+		t.Fatalf("Lock(2, 2048) returned error: %v", err)
+	}
+	if rel2, err := m.Lock(tCtx, 1, 1); err == nil {
+		rel2()
+		t.Errorf("Lock(1, 1) succeeded with all resources of the fraction locked, want error")
+	}
+	rel()
+	m.SetFraction(ctx, 1, 0)
+	if rel, err := m.Lock(ctx, 4, 4096); err != nil {
+		t.Errorf("Lock(4, 4096) returned error after SetFraction(1): %v", err)
+	} else {
+		rel()
+	}
+}
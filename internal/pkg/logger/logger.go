@@ -43,8 +43,11 @@ import (
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/digest"
 	log "github.com/golang/glog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Format specifies how the Logger serializes its records.
@@ -69,6 +72,10 @@ const (
 	textDelimiter  string = "\n\n\n"
 	peakNumActions string = "PEAK_NUM_ACTIONS"
 	unixTime       string = "UNIX_TIME"
+
+	// maxInvocations is the number of invocations whose actions are accounted for separately. Once
+	// there are more, the oldest invocation without running actions is forgotten.
+	maxInvocations = 100
 )
 
 // Logger logs Records asynchronously into a file.
@@ -88,11 +95,22 @@ type Logger struct {
 	peakRunningActions int32
 	completedActions   map[lpb.CompletionStatus]int32
 
+	// invocations accounts for the actions of each invocation ID separately, invocationIDs being
+	// their IDs from the oldest to the most recent. Both are only accessed by processEvents.
+	invocations   map[string]*invocation
+	invocationIDs []string
+
 	mu               sync.RWMutex
 	open             bool
 	resourceUsage    map[string][]int64
 	u                *usage.PsutilSampler
 	cancelSamplerCtx context.CancelFunc
+	// lastUsage is the latest resource usage sample, guarded by usageMu.
+	lastUsage map[string]int64
+	usageMu   sync.Mutex
+	// usageSamples are the resource usage samples with the time they were taken, recorded in the
+	// ProxyInfo when the logger is closed.
+	usageSamples []*lpb.ResourceUsageSample
 
 	// qps indicates the rate of completed actions.
 	// The formula is: number of completed actions / total duration in which the number of running actions was greater than zero.
@@ -102,6 +120,60 @@ type Logger struct {
 	qpsCount        int32
 }
 
+// invocation accounts for the actions of a single invocation ID, so that a reproxy shared by
+// several builds can report on each of them.
+type invocation struct {
+	runningActions   int32
+	completedActions map[lpb.CompletionStatus]int32
+	stats            *stats.Stats
+	// start is when the first action of the invocation started, and end when the last one completed.
+	start, end time.Time
+}
+
+// qps returns the rate of completed actions of the invocation.
+func (inv *invocation) qps() int32 {
+	end := inv.end
+	if inv.runningActions > 0 {
+		end = time.Now()
+	}
+	var count int32
+	for _, cnt := range inv.completedActions {
+		count += cnt
+	}
+	if seconds := int32(end.Sub(inv.start).Seconds()); seconds > 0 {
+		return count / seconds
+	}
+	return count
+}
+
+// invocation returns the accounting of the invocation with the given ID, which is created if it
+// does not exist yet, or nil if the ID is empty.
+func (l *Logger) invocation(id string) *invocation {
+	if id == "" {
+		return nil
+	}
+	if inv, ok := l.invocations[id]; ok {
+		return inv
+	}
+	if len(l.invocationIDs) >= maxInvocations {
+		for i, old := range l.invocationIDs {
+			if l.invocations[old].runningActions == 0 {
+				delete(l.invocations, old)
+				l.invocationIDs = append(l.invocationIDs[:i], l.invocationIDs[i+1:]...)
+				break
+			}
+		}
+	}
+	inv := &invocation{
+		completedActions: make(map[lpb.CompletionStatus]int32),
+		stats:            stats.New(),
+		start:            time.Now(),
+	}
+	l.invocations[id] = inv
+	l.invocationIDs = append(l.invocationIDs, id)
+	return inv
+}
+
 type logEvent interface {
 	apply(l *Logger)
 }
@@ -115,6 +187,9 @@ func (s *startActionEvent) apply(l *Logger) {
 		return
 	}
 	s.lr.open = true
+	if inv := l.invocation(s.lr.invocationID); inv != nil {
+		inv.runningActions++
+	}
 	if l.runningActions == 0 {
 		// Reset qps start time to exclude the period in which there were no running actions.
 		l.qpsStartTime = time.Now()
@@ -146,8 +221,33 @@ func (e *endActionEvent) apply(l *Logger) {
 	l.mi.ProcessLogRecord(e.lr.LogRecord)
 	l.stats.AddRecord(e.lr.LogRecord)
 	e.lr.open = false
-	l.completedActions[e.lr.CompletionStatus]++
+	if inv := l.invocations[e.lr.invocationID]; inv != nil {
+		inv.stats.AddRecord(e.lr.LogRecord)
+	}
+	if !e.lr.ended {
+		l.endAction(e.lr)
+	}
+
+	blob, err := toBytes(l.Format, e.lr.LogRecord)
+	if err != nil {
+		log.Errorf("Error serializing %v: %v", e.lr.LogRecord, err)
+		return
+	}
+	if _, err := l.recsFile.Write(blob); err != nil {
+		log.Errorf("Write error: %v", err)
+	}
+}
+
+// endAction accounts for the completion of the action in the running and completed action counts.
+func (l *Logger) endAction(lr *LogRecord) {
+	lr.ended = true
+	l.completedActions[lr.CompletionStatus]++
 	l.runningActions--
+	if inv := l.invocations[lr.invocationID]; inv != nil {
+		inv.completedActions[lr.CompletionStatus]++
+		inv.runningActions--
+		inv.end = time.Now()
+	}
 
 	l.qpsCount++
 	totalDuration := time.Since(l.qpsStartTime) + l.qpsLastDuration
@@ -159,39 +259,91 @@ func (e *endActionEvent) apply(l *Logger) {
 		// Reset start time here just in case it doesn't get set properly in a start event.
 		l.qpsStartTime = time.Now()
 	}
+}
 
-	blob, err := toBytes(l.Format, e.lr.LogRecord)
-	if err != nil {
-		log.Errorf("Error serializing %v: %v", e.lr.LogRecord, err)
+// earlyEndActionEvent ends an action whose record is logged later.
+type earlyEndActionEvent struct {
+	lr *LogRecord
+}
+
+func (e *earlyEndActionEvent) apply(l *Logger) {
+	if !e.lr.open || e.lr.ended {
 		return
 	}
-	if _, err := l.recsFile.Write(blob); err != nil {
-		log.Errorf("Write error: %v", err)
-	}
+	l.endAction(e.lr)
 }
 
 type summarizeActionsEvent struct {
-	out chan<- *ppb.GetStatusSummaryResponse
+	// invocationID restricts the summary to an invocation if set. A nil summary is sent if the
+	// invocation is unknown.
+	invocationID string
+	out          chan<- *ppb.GetStatusSummaryResponse
 }
 
 func (s *summarizeActionsEvent) apply(l *Logger) {
-	completedActions := make(map[string]int32)
-	for status, cnt := range l.completedActions {
-		completedActions[status.String()] = cnt
+	if s.invocationID != "" {
+		inv, ok := l.invocations[s.invocationID]
+		if !ok {
+			s.out <- nil
+			return
+		}
+		s.out <- &ppb.GetStatusSummaryResponse{
+			CompletedActionStats: completedActionStats(inv.completedActions),
+			RunningActions:       inv.runningActions,
+			Qps:                  inv.qps(),
+		}
+		return
 	}
 	s.out <- &ppb.GetStatusSummaryResponse{
-		CompletedActionStats: completedActions,
+		CompletedActionStats: completedActionStats(l.completedActions),
 		RunningActions:       l.runningActions,
 		Qps:                  l.qps,
+		InvocationIds:        append([]string(nil), l.invocationIDs...),
+	}
+}
+
+func completedActionStats(completed map[lpb.CompletionStatus]int32) map[string]int32 {
+	completedActions := make(map[string]int32)
+	for status, cnt := range completed {
+		completedActions[status.String()] = cnt
 	}
+	return completedActions
+}
+
+type invocationStatsEvent struct {
+	invocationID string
+	// out receives the stats of the invocation, or nil if the invocation is unknown.
+	out chan<- *spb.Stats
+}
+
+func (s *invocationStatsEvent) apply(l *Logger) {
+	inv, ok := l.invocations[s.invocationID]
+	if !ok {
+		s.out <- nil
+		return
+	}
+	// Aggregating is idempotent, so more records can be added to the stats afterwards. The proto is
+	// cloned as it shares slices with the stats, which later events modify.
+	inv.stats.FinalizeAggregate(nil)
+	s.out <- proto.Clone(inv.stats.ToProto()).(*spb.Stats)
+}
+
+type setMismatchIgnorerEvent struct {
+	mi *ignoremismatch.MismatchIgnorer
+}
+
+func (e *setMismatchIgnorerEvent) apply(l *Logger) {
+	l.mi = e.mi
 }
 
 // LogRecord wraps proxy.LogRecord while tracking if the command has been ended yet for logging purposes.
 type LogRecord struct {
 	*lpb.LogRecord
 
-	mu   sync.RWMutex
-	open bool
+	mu           sync.RWMutex
+	open         bool
+	ended        bool // The completion of the action was already accounted for by EndAction.
+	invocationID string
 }
 
 // NewLogRecord creates a new LogRecord without logging the start of an action.
@@ -341,6 +493,7 @@ func newLogger(format Format, recs, info *os.File, s stats.StatCollector, mi *ig
 		e:                e,
 		open:             true,
 		completedActions: make(map[lpb.CompletionStatus]int32),
+		invocations:      make(map[string]*invocation),
 		u:                u,
 	}
 	l.startBackgroundProcess()
@@ -440,12 +593,23 @@ func (l *Logger) collectResourceUsageSamples(samples map[string]int64) {
 	if samples == nil {
 		samples = make(map[string]int64)
 	}
-	samples[unixTime] = time.Now().Unix()
+	now := time.Now()
+	samples[unixTime] = now.Unix()
 	samples[peakNumActions] = int64(atomic.SwapInt32(&l.peakRunningActions, 0))
 	// These log messages in reproxy.INFO are used for plotting the time series
 	// of resource usage by a plotter.
 	log.Infof("Resource Usage: %v", samples)
 	delete(samples, unixTime)
+	l.usageMu.Lock()
+	l.lastUsage = make(map[string]int64, len(samples))
+	for k, v := range samples {
+		l.lastUsage[k] = v
+	}
+	l.usageMu.Unlock()
+	l.usageSamples = append(l.usageSamples, &lpb.ResourceUsageSample{
+		Time:   tspb.New(now),
+		Values: l.lastUsage,
+	})
 	for k, v := range samples {
 		if _, ok := l.resourceUsage[k]; ok {
 			l.resourceUsage[k] = append(l.resourceUsage[k], v)
@@ -455,6 +619,17 @@ func (l *Logger) collectResourceUsageSamples(samples map[string]int64) {
 	}
 }
 
+// ResourceUsage returns the latest sample of the resource usage of reproxy, or nil if none was
+// collected yet.
+func (l *Logger) ResourceUsage() map[string]int64 {
+	if l == nil {
+		return nil
+	}
+	l.usageMu.Lock()
+	defer l.usageMu.Unlock()
+	return l.lastUsage
+}
+
 // IncrementMetricIntToProxyInfo will increment a reproxy level event to the ProxyInfo object.
 func (l *Logger) IncrementMetricIntToProxyInfo(key string, delta int64) {
 	if l == nil {
@@ -529,8 +704,31 @@ func (l *Logger) AddFlags(flagSet *flag.FlagSet) {
 	})
 }
 
-// GetStatusSummary returns a snapshot for currently running and completed actions.
-func (l *Logger) GetStatusSummary(ctx context.Context, _ *ppb.GetStatusSummaryRequest) (*ppb.GetStatusSummaryResponse, error) {
+// AddReloadedFlagsToProxyInfo adds reproxy flags whose values changed at the given time, while
+// reproxy was running, to the ProxyInfo object. The time of the change is recorded under the
+// name of the flag suffixed with "_reloaded_at".
+func (l *Logger) AddReloadedFlagsToProxyInfo(flags map[string]string, t time.Time) {
+	for k, v := range flags {
+		l.AddFlagStringToProxyInfo(k, v)
+		l.AddFlagStringToProxyInfo(k+"_reloaded_at", t.Format(time.RFC3339))
+	}
+}
+
+// SetMismatchIgnorer changes the mismatch ignorer applied to the records logged from now on.
+func (l *Logger) SetMismatchIgnorer(mi *ignoremismatch.MismatchIgnorer) {
+	if l == nil {
+		return
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.open {
+		l.ch <- &setMismatchIgnorerEvent{mi: mi}
+	}
+}
+
+// GetStatusSummary returns a snapshot for currently running and completed actions, either of the
+// whole proxy or of a single invocation.
+func (l *Logger) GetStatusSummary(ctx context.Context, req *ppb.GetStatusSummaryRequest) (*ppb.GetStatusSummaryResponse, error) {
 	if l == nil {
 		return nil, errors.New("not running")
 	}
@@ -543,21 +741,64 @@ func (l *Logger) GetStatusSummary(ctx context.Context, _ *ppb.GetStatusSummaryRe
 
 	out := make(chan *ppb.GetStatusSummaryResponse, 1)
 	defer close(out)
-	l.ch <- &summarizeActionsEvent{out}
+	l.ch <- &summarizeActionsEvent{invocationID: req.GetInvocationId(), out: out}
 
 	for {
 		select {
 		case <-ctx.Done():
 			return nil, errors.New("timed out")
 		case resp := <-out:
+			if resp == nil {
+				return nil, status.Errorf(codes.NotFound, "unknown invocation %v", req.GetInvocationId())
+			}
 			return resp, nil
 		}
 	}
 }
 
+// GetInvocationStats returns the aggregated stats of the actions of an invocation that completed so
+// far.
+func (l *Logger) GetInvocationStats(ctx context.Context, req *ppb.GetInvocationStatsRequest) (*ppb.GetInvocationStatsResponse, error) {
+	if req.GetInvocationId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no invocation_id provided in the request")
+	}
+	if l == nil {
+		return nil, errors.New("not running")
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if !l.open {
+		return nil, errors.New("not running")
+	}
+
+	// out is not closed, since the event may still be processed after this returns. Being
+	// buffered, it never blocks the processing of events.
+	out := make(chan *spb.Stats, 1)
+	l.ch <- &invocationStatsEvent{invocationID: req.GetInvocationId(), out: out}
+
+	select {
+	case <-ctx.Done():
+		return nil, errors.New("timed out")
+	case st := <-out:
+		if st == nil {
+			return nil, status.Errorf(codes.NotFound, "unknown invocation %v", req.GetInvocationId())
+		}
+		return &ppb.GetInvocationStatsResponse{Stats: st}, nil
+	}
+}
+
 // LogActionStart logs start of an action. Use the returned LogRecord to track log events then pass to Log at the end of the action.
 func (l *Logger) LogActionStart() *LogRecord {
+	return l.LogActionStartForInvocation("")
+}
+
+// LogActionStartForInvocation logs start of an action of the invocation with the given ID, whose
+// actions are also accounted for separately if the ID is not empty. Use the returned LogRecord to
+// track log events then pass to Log at the end of the action.
+func (l *Logger) LogActionStartForInvocation(invocationID string) *LogRecord {
 	lr := NewLogRecord()
+	lr.invocationID = invocationID
 	if l != nil {
 		l.mu.RLock()
 		defer l.mu.RUnlock()
@@ -584,6 +825,22 @@ func (l *Logger) Log(rec *LogRecord) {
 	}
 }
 
+// EndAction marks the action as completed in the running and completed action counts before its
+// record is logged with Log, for actions whose record is still updated after their result was
+// returned. The record must have its CompletionStatus set.
+func (l *Logger) EndAction(rec *LogRecord) {
+	if l == nil {
+		return
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.open {
+		l.ch <- &earlyEndActionEvent{
+			lr: rec,
+		}
+	}
+}
+
 // CloseAndAggregate deactivates the logger and waits for pending records to finish logging.
 // The log file is then closed. Any subsequent Log calls will be discarded.
 // Finally, aggregated build stats are generated and returned.
@@ -603,6 +860,7 @@ func (l *Logger) CloseAndAggregate() *spb.Stats {
 	}
 	l.wg.Wait()
 	l.info.Stats = append(l.info.Stats, summarize(l.resourceUsage)...)
+	l.info.ResourceUsageSamples = l.usageSamples
 	l.writeProxyInfo()
 	l.stats.FinalizeAggregate([]*lpb.ProxyInfo{l.info})
 	return l.stats.ToProto()
@@ -17,6 +17,7 @@ package logger
 import (
 	"context"
 	"flag"
+	"fmt"
 	"math/rand"
 	"os"
 	"path/filepath"
@@ -29,6 +30,8 @@ import (
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/digest"
 	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/testing/protocmp"
 
 	lpb "github.com/bazelbuild/reclient/api/log"
@@ -130,6 +133,36 @@ func TestProxyInfo(t *testing.T) {
 	}
 }
 
+func TestReloadedFlags(t *testing.T) {
+	execRoot := t.TempDir()
+	logger, err := New(TextFormat, execRoot, &stubStats{}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to initialize logger: %v", err)
+	}
+	testFlagSet := flag.NewFlagSet("TestFlagSet", flag.ContinueOnError)
+	testFlagSet.String("key1", "val1", "test")
+	testFlagSet.String("key2", "val2", "test")
+	logger.AddFlags(testFlagSet)
+	rt := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	logger.AddReloadedFlagsToProxyInfo(map[string]string{"key2": "val3"}, rt)
+	logger.CloseAndAggregate()
+	_, gotPInfo, err := ParseFromLogDirs(TextFormat, []string{execRoot})
+	if err != nil {
+		t.Fatalf("failed to parse from log dir %s: %v", execRoot, err)
+	}
+	if len(gotPInfo) != 1 {
+		t.Fatalf("Parsed %v ProxyInfos, want 1", len(gotPInfo))
+	}
+	want := map[string]string{
+		"key1":             "val1",
+		"key2":             "val3",
+		"key2_reloaded_at": "2023-05-01T10:00:00Z",
+	}
+	if diff := cmp.Diff(want, gotPInfo[0].GetFlags()); diff != "" {
+		t.Errorf("Parse logged ProxyInfo returned diff in flags: (-want +got)\n%s", diff)
+	}
+}
+
 func TestReducedLogging(t *testing.T) {
 	recs := []*lpb.LogRecord{
 		&lpb.LogRecord{
@@ -428,6 +461,31 @@ func TestEndActionTwice(t *testing.T) {
 	}
 }
 
+func TestEndActionBeforeLog(t *testing.T) {
+	execRoot := t.TempDir()
+	logger, _ := New(TextFormat, execRoot, &stubStats{}, nil, nil, nil)
+	defer logger.CloseAndAggregate()
+	rec := logger.LogActionStart()
+	rec.CompletionStatus = lpb.CompletionStatus_STATUS_REMOTE_EXECUTION
+	logger.EndAction(rec)
+	summaryAfterEnd, _ := logger.GetStatusSummary(context.Background(), &ppb.GetStatusSummaryRequest{})
+	logger.Log(rec)
+	summaryAfterLog, _ := logger.GetStatusSummary(context.Background(), &ppb.GetStatusSummaryRequest{})
+
+	wantSummary := &ppb.GetStatusSummaryResponse{
+		CompletedActionStats: map[string]int32{
+			lpb.CompletionStatus_STATUS_REMOTE_EXECUTION.String(): 1,
+		},
+		RunningActions: 0,
+	}
+	if diff := cmp.Diff(wantSummary, summaryAfterEnd, protocmp.Transform()); diff != "" {
+		t.Errorf("GetStatusSummary() after EndAction had diff in result: (-want +got)\n%s", diff)
+	}
+	if diff := cmp.Diff(wantSummary, summaryAfterLog, protocmp.Transform()); diff != "" {
+		t.Errorf("GetStatusSummary() after Log had diff in result: (-want +got)\n%s", diff)
+	}
+}
+
 func TestConcurrentActions(t *testing.T) {
 	execRoot := t.TempDir()
 	logger, _ := New(TextFormat, execRoot, &stubStats{}, nil, nil, nil)
@@ -458,6 +516,109 @@ func TestConcurrentActions(t *testing.T) {
 	}
 }
 
+func TestInvocationAccounting(t *testing.T) {
+	execRoot := t.TempDir()
+	logger, _ := New(TextFormat, execRoot, &stubStats{}, nil, nil, nil)
+	defer logger.CloseAndAggregate()
+	ctx := context.Background()
+	first := logger.LogActionStartForInvocation("first")
+	first.CompletionStatus = lpb.CompletionStatus_STATUS_CACHE_HIT
+	first.Result = &cpb.CommandResult{Status: cpb.CommandResultStatus_CACHE_HIT}
+	logger.Log(first)
+	second := logger.LogActionStartForInvocation("second")
+	second.CompletionStatus = lpb.CompletionStatus_STATUS_REMOTE_EXECUTION
+
+	tests := []struct {
+		name         string
+		invocationID string
+		want         *ppb.GetStatusSummaryResponse
+	}{
+		{
+			name: "Proxy",
+			want: &ppb.GetStatusSummaryResponse{
+				CompletedActionStats: map[string]int32{
+					lpb.CompletionStatus_STATUS_CACHE_HIT.String(): 1,
+				},
+				RunningActions: 1,
+				InvocationIds:  []string{"first", "second"},
+			},
+		},
+		{
+			name:         "CompletedInvocation",
+			invocationID: "first",
+			want: &ppb.GetStatusSummaryResponse{
+				CompletedActionStats: map[string]int32{
+					lpb.CompletionStatus_STATUS_CACHE_HIT.String(): 1,
+				},
+			},
+		},
+		{
+			name:         "RunningInvocation",
+			invocationID: "second",
+			want: &ppb.GetStatusSummaryResponse{
+				CompletedActionStats: map[string]int32{},
+				RunningActions:       1,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := logger.GetStatusSummary(ctx, &ppb.GetStatusSummaryRequest{InvocationId: tc.invocationID})
+			if err != nil {
+				t.Fatalf("GetStatusSummary() returned error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform(), protocmp.IgnoreFields(&ppb.GetStatusSummaryResponse{}, "qps")); diff != "" {
+				t.Errorf("GetStatusSummary() had diff in result: (-want +got)\n%s", diff)
+			}
+		})
+	}
+	if _, err := logger.GetStatusSummary(ctx, &ppb.GetStatusSummaryRequest{InvocationId: "unknown"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetStatusSummary() of an unknown invocation returned error %v, want NotFound", err)
+	}
+
+	logger.Log(second)
+	for _, id := range []string{"first", "second"} {
+		resp, err := logger.GetInvocationStats(ctx, &ppb.GetInvocationStatsRequest{InvocationId: id})
+		if err != nil {
+			t.Fatalf("GetInvocationStats(%v) returned error: %v", id, err)
+		}
+		if got := resp.GetStats().GetNumRecords(); got != 1 {
+			t.Errorf("GetInvocationStats(%v) returned %v records, want 1", id, got)
+		}
+	}
+	resp, err := logger.GetInvocationStats(ctx, &ppb.GetInvocationStatsRequest{InvocationId: "first"})
+	if err != nil {
+		t.Fatalf("GetInvocationStats(first) returned error: %v", err)
+	}
+	if got := resp.GetStats().GetBuildCacheHitRatio(); got != 1 {
+		t.Errorf("GetInvocationStats(first) returned cache hit ratio %v, want 1", got)
+	}
+	if _, err := logger.GetInvocationStats(ctx, &ppb.GetInvocationStatsRequest{InvocationId: "unknown"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetInvocationStats() of an unknown invocation returned error %v, want NotFound", err)
+	}
+}
+
+func TestForgetOldInvocations(t *testing.T) {
+	execRoot := t.TempDir()
+	logger, _ := New(TextFormat, execRoot, &stubStats{}, nil, nil, nil)
+	defer logger.CloseAndAggregate()
+	ctx := context.Background()
+	// The first invocation is still running, so the second one is forgotten first.
+	running := logger.LogActionStartForInvocation("inv0")
+	for i := 1; i <= maxInvocations; i++ {
+		logger.Log(logger.LogActionStartForInvocation(fmt.Sprintf("inv%d", i)))
+	}
+	summary, err := logger.GetStatusSummary(ctx, &ppb.GetStatusSummaryRequest{})
+	if err != nil {
+		t.Fatalf("GetStatusSummary() returned error: %v", err)
+	}
+	ids := summary.GetInvocationIds()
+	if len(ids) != maxInvocations || ids[0] != "inv0" || ids[1] != "inv2" {
+		t.Errorf("GetStatusSummary() returned invocations %v..., want %v invocations starting with inv0, inv2", ids[:2], maxInvocations)
+	}
+	logger.Log(running)
+}
+
 func randSleep() {
 	rand.Seed(time.Now().UnixNano())
 	r := rand.Intn(100)
@@ -711,3 +872,25 @@ func TestLogger_CollectResourceUsageSamples(t *testing.T) {
 		})
 	}
 }
+
+func TestResourceUsage(t *testing.T) {
+	l := &Logger{}
+	if got := l.ResourceUsage(); got != nil {
+		t.Errorf("ResourceUsage() before any sample = %v, want nil", got)
+	}
+	l.collectResourceUsageSamples(map[string]int64{"cpu": 1, "mem": 2})
+	l.collectResourceUsageSamples(map[string]int64{"cpu": 3, "mem": 4})
+	want := map[string]int64{peakNumActions: 0, "cpu": 3, "mem": 4}
+	if diff := cmp.Diff(want, l.ResourceUsage()); diff != "" {
+		t.Errorf("ResourceUsage() returned diff: (-want +got)\n%s", diff)
+	}
+	if len(l.usageSamples) != 2 {
+		t.Fatalf("collectResourceUsageSamples() recorded %d samples, want 2", len(l.usageSamples))
+	}
+	if l.usageSamples[0].GetTime().AsTime().After(l.usageSamples[1].GetTime().AsTime()) {
+		t.Errorf("collectResourceUsageSamples() recorded samples out of order: %v", l.usageSamples)
+	}
+	if diff := cmp.Diff(want, l.usageSamples[1].GetValues()); diff != "" {
+		t.Errorf("collectResourceUsageSamples() recorded last sample with diff: (-want +got)\n%s", diff)
+	}
+}
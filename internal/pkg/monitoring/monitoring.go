@@ -12,7 +12,8 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package monitoring is responsible for uploading metrics to a monitoring service that supports OpenCensus.
+// Package monitoring is responsible for uploading metrics to a monitoring service that supports OpenCensus,
+// and for serving them to be scraped by Prometheus.
 package monitoring
 
 import (
@@ -68,6 +69,10 @@ var (
 	ActionCount = stats.Int64("rbe/action/count", "Number of actions processed by reproxy", stats.UnitDimensionless)
 	// ActionLatency is a metric for tracking the e2e latency of an action in reproxy.
 	ActionLatency = stats.Float64("rbe/action/latency", "Time spent processing an action e2e in reproxy", stats.UnitMilliseconds)
+	// ActionUploadedBytes is a metric for tracking the bytes uploaded for actions in reproxy.
+	ActionUploadedBytes = stats.Int64("rbe/action/uploaded_bytes", "Bytes uploaded to the remote CAS for actions processed by reproxy", stats.UnitBytes)
+	// ActionDownloadedBytes is a metric for tracking the bytes downloaded for actions in reproxy.
+	ActionDownloadedBytes = stats.Int64("rbe/action/downloaded_bytes", "Bytes downloaded from the remote CAS for actions processed by reproxy", stats.UnitBytes)
 	// BuildCacheHitRatio is a metric of the ratio of cache hits in a build.
 	BuildCacheHitRatio = stats.Float64("rbe/build/cache_hit_ratio", "Ratio of cache hits in a build", stats.UnitDimensionless)
 	// BuildLatency is a metric for tracking the e2e latency of a build in reproxy.
@@ -123,6 +128,12 @@ func NewExporter(ctx context.Context, project, prefix, namespace string, ts *oau
 	return e, nil
 }
 
+// NewLocalExporter returns an exporter that only records metrics to the registered views, e.g. to
+// be served by a MetricsHandler, without exporting them to Cloud monitoring.
+func NewLocalExporter() *Exporter {
+	return &Exporter{recorder: &localRecorder{}}
+}
+
 // MonitoredResource returns resource type and resource labels for the build.
 func (e *Exporter) MonitoredResource() (resType string, labels map[string]string) {
 	hn, err := os.Hostname()
@@ -165,6 +176,16 @@ func SetupViews(labels map[string]string) error {
 			TagKeys:     append(keys, labelsKey, osFamilyKey, versionKey, statusKey, remoteStatusKey, exitCodeKey, remoteExitCodeKey, remoteDisabledKey),
 			Aggregation: view.Sum(),
 		},
+		{
+			Measure:     ActionUploadedBytes,
+			TagKeys:     append(keys, labelsKey, osFamilyKey, versionKey, statusKey, remoteStatusKey, exitCodeKey, remoteExitCodeKey, remoteDisabledKey),
+			Aggregation: view.Sum(),
+		},
+		{
+			Measure:     ActionDownloadedBytes,
+			TagKeys:     append(keys, labelsKey, osFamilyKey, versionKey, statusKey, remoteStatusKey, exitCodeKey, remoteExitCodeKey, remoteDisabledKey),
+			Aggregation: view.Sum(),
+		},
 		{
 			Measure:     BuildCacheHitRatio,
 			TagKeys:     append(keys, osFamilyKey, versionKey, remoteDisabledKey),
@@ -240,6 +261,12 @@ func (e *Exporter) ExportActionMetrics(ctx context.Context, r *lpb.LogRecord, re
 	}
 	e.recorder.recordWithTags(aCtx, e.makeActionTags(r, remoteDisabled), ActionCount.M(1))
 	e.recorder.recordWithTags(aCtx, e.makeActionTags(r, remoteDisabled), ActionLatency.M(latency))
+	if b := r.GetRemoteMetadata().GetRealBytesUploaded(); b > 0 {
+		e.recorder.recordWithTags(aCtx, e.makeActionTags(r, remoteDisabled), ActionUploadedBytes.M(b))
+	}
+	if b := r.GetRemoteMetadata().GetRealBytesDownloaded(); b > 0 {
+		e.recorder.recordWithTags(aCtx, e.makeActionTags(r, remoteDisabled), ActionDownloadedBytes.M(b))
+	}
 }
 
 func (e *Exporter) makeActionTags(r *lpb.LogRecord, remoteDisabled bool) map[tag.Key]string {
@@ -348,6 +375,17 @@ func (s *stackDriverRecorder) recordWithTags(ctx context.Context, labels map[tag
 	stats.Record(aCtx, val)
 }
 
+// localRecorder records metrics to the registered views only.
+type localRecorder struct {
+	stackDriverRecorder
+}
+
+func (l *localRecorder) initialize(o stackdriver.Options) error {
+	return nil
+}
+
+func (l *localRecorder) close() {}
+
 // CleanLogDir removes stray log files which may cause confusion when bootstrap starts
 func CleanLogDir(logDir string) {
 	for _, f := range failureFiles {
@@ -0,0 +1,240 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitoring
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/golang/glog"
+
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/metric/metricproducer"
+)
+
+// openMetricsContentType is the content type of the OpenMetrics text format.
+const openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// gauge is a metric whose values are read when metrics are served.
+type gauge struct {
+	name, description, label string
+	values                   func() map[string]float64
+}
+
+// MetricsHandler serves metrics in the OpenMetrics text format, for scraping by Prometheus. It
+// serves the metrics recorded in the registered views, i.e. the action and build metrics, along
+// with gauges of the current state of reproxy. Metric names are the names of the measures with
+// slashes replaced by underscores, e.g. rbe_action_count.
+type MetricsHandler struct {
+	mu     sync.Mutex
+	gauges []*gauge
+}
+
+// NewMetricsHandler returns a handler serving the metrics recorded in the registered views.
+func NewMetricsHandler() *MetricsHandler {
+	return &MetricsHandler{}
+}
+
+// AddGauge adds a gauge whose value is read by calling value when metrics are served.
+func (h *MetricsHandler) AddGauge(name, description string, value func() float64) {
+	h.AddLabeledGauge(name, description, "", func() map[string]float64 {
+		return map[string]float64{"": value()}
+	})
+}
+
+// AddLabeledGauge adds a gauge with a time series for each of the keys of the map returned by
+// values when metrics are served, the keys being the values of the given label.
+func (h *MetricsHandler) AddLabeledGauge(name, description, label string, values func() map[string]float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.gauges = append(h.gauges, &gauge{name: name, description: description, label: label, values: values})
+}
+
+// ServeHTTP writes the current values of the metrics.
+func (h *MetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", openMetricsContentType)
+	if err := h.write(w); err != nil {
+		log.Warningf("Failed to serve metrics: %v", err)
+	}
+}
+
+// write writes the metrics in the OpenMetrics text format, sorted by name.
+func (h *MetricsHandler) write(out io.Writer) error {
+	var metrics []*metricdata.Metric
+	for _, p := range metricproducer.GlobalManager().GetAll() {
+		metrics = append(metrics, p.Read()...)
+	}
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Descriptor.Name < metrics[j].Descriptor.Name })
+	h.mu.Lock()
+	gauges := append([]*gauge(nil), h.gauges...)
+	h.mu.Unlock()
+	sort.Slice(gauges, func(i, j int) bool { return gauges[i].name < gauges[j].name })
+
+	w := bufio.NewWriter(out)
+	for _, m := range metrics {
+		writeMetric(w, m)
+	}
+	for _, g := range gauges {
+		writeGauge(w, g)
+	}
+	fmt.Fprintln(w, "# EOF")
+	return w.Flush()
+}
+
+func writeMetric(w io.Writer, m *metricdata.Metric) {
+	name := metricName(m.Descriptor.Name)
+	var typ string
+	switch m.Descriptor.Type {
+	case metricdata.TypeCumulativeInt64, metricdata.TypeCumulativeFloat64:
+		typ = "counter"
+	case metricdata.TypeGaugeInt64, metricdata.TypeGaugeFloat64:
+		typ = "gauge"
+	case metricdata.TypeCumulativeDistribution:
+		typ = "histogram"
+	case metricdata.TypeGaugeDistribution:
+		typ = "gaugehistogram"
+	default:
+		log.V(1).Infof("Not serving metric %v of unsupported type %v", m.Descriptor.Name, m.Descriptor.Type)
+		return
+	}
+	fmt.Fprintf(w, "# TYPE %v %v\n", name, typ)
+	if m.Descriptor.Description != "" {
+		fmt.Fprintf(w, "# HELP %v %v\n", name, escapeHelp(m.Descriptor.Description))
+	}
+	keys := make([]string, len(m.Descriptor.LabelKeys))
+	for i, k := range m.Descriptor.LabelKeys {
+		keys[i] = k.Key
+	}
+	series := make([]string, 0, len(m.TimeSeries))
+	for _, ts := range m.TimeSeries {
+		if len(ts.Points) == 0 {
+			continue
+		}
+		var lbls []string
+		for i, v := range ts.LabelValues {
+			if v.Present && i < len(keys) {
+				lbls = append(lbls, label(keys[i], v.Value))
+			}
+		}
+		var sb strings.Builder
+		switch v := ts.Points[len(ts.Points)-1].Value.(type) {
+		case int64:
+			writeSample(&sb, name, typ, lbls, float64(v))
+		case float64:
+			writeSample(&sb, name, typ, lbls, v)
+		case *metricdata.Distribution:
+			writeDistribution(&sb, name, typ, lbls, v)
+		}
+		series = append(series, sb.String())
+	}
+	sort.Strings(series)
+	for _, s := range series {
+		io.WriteString(w, s)
+	}
+}
+
+func writeSample(w io.Writer, name, typ string, lbls []string, v float64) {
+	if typ == "counter" {
+		name += "_total"
+	}
+	fmt.Fprintf(w, "%v%v %v\n", name, labelSet(lbls), formatFloat(v))
+}
+
+func writeDistribution(w io.Writer, name, typ string, lbls []string, d *metricdata.Distribution) {
+	var cnt int64
+	if d.BucketOptions != nil {
+		for i, b := range d.Buckets {
+			cnt += b.Count
+			le := math.Inf(1)
+			if i < len(d.BucketOptions.Bounds) {
+				le = d.BucketOptions.Bounds[i]
+			}
+			fmt.Fprintf(w, "%v_bucket%v %v\n", name, labelSet(append(lbls, label("le", formatFloat(le)))), cnt)
+		}
+	}
+	// Histograms must have a +Inf bucket, which the last bucket of distributions is unless they
+	// have no buckets.
+	if d.BucketOptions == nil || len(d.Buckets) <= len(d.BucketOptions.Bounds) {
+		fmt.Fprintf(w, "%v_bucket%v %v\n", name, labelSet(append(lbls, label("le", "+Inf"))), d.Count)
+	}
+	count, sum := "_count", "_sum"
+	if typ == "gaugehistogram" {
+		count, sum = "_gcount", "_gsum"
+	}
+	fmt.Fprintf(w, "%v%v%v %v\n", name, count, labelSet(lbls), d.Count)
+	fmt.Fprintf(w, "%v%v%v %v\n", name, sum, labelSet(lbls), formatFloat(d.Sum))
+}
+
+func writeGauge(w io.Writer, g *gauge) {
+	name := metricName(g.name)
+	fmt.Fprintf(w, "# TYPE %v gauge\n", name)
+	if g.description != "" {
+		fmt.Fprintf(w, "# HELP %v %v\n", name, escapeHelp(g.description))
+	}
+	values := g.values()
+	lvs := make([]string, 0, len(values))
+	for lv := range values {
+		lvs = append(lvs, lv)
+	}
+	sort.Strings(lvs)
+	for _, lv := range lvs {
+		var lbls []string
+		if g.label != "" {
+			lbls = []string{label(g.label, lv)}
+		}
+		writeSample(w, name, "gauge", lbls, values[lv])
+	}
+}
+
+// metricName converts an OpenCensus metric name to a valid OpenMetrics name.
+func metricName(name string) string {
+	return invalidNameChars.ReplaceAllString(name, "_")
+}
+
+func label(key, value string) string {
+	v := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+	return fmt.Sprintf("%v=\"%v\"", invalidNameChars.ReplaceAllString(key, "_"), v)
+}
+
+func labelSet(lbls []string) string {
+	if len(lbls) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(lbls, ",") + "}"
+}
+
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
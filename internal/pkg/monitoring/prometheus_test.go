@@ -0,0 +1,93 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitoring
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+func TestMetricsHandler(t *testing.T) {
+	count := stats.Int64("test/prometheus/count", "Number of things", stats.UnitDimensionless)
+	latency := stats.Float64("test/prometheus/latency", "Time spent on things", stats.UnitMilliseconds)
+	thingKey := tag.MustNewKey("thing")
+	views := []*view.View{
+		{Measure: count, TagKeys: []tag.Key{thingKey}, Aggregation: view.Sum()},
+		{Measure: latency, Aggregation: view.Distribution(10, 100)},
+	}
+	if err := view.Register(views...); err != nil {
+		t.Fatalf("Failed to register views: %v", err)
+	}
+	t.Cleanup(func() { view.Unregister(views...) })
+	ctx, err := tag.New(context.Background(), tag.Insert(thingKey, "a \"quoted\" value"))
+	if err != nil {
+		t.Fatalf("Failed to create tags: %v", err)
+	}
+	stats.Record(ctx, count.M(2))
+	stats.Record(ctx, count.M(1))
+	stats.Record(ctx, latency.M(5), latency.M(50), latency.M(500))
+	// Measurements are recorded asynchronously, retrieving data waits for them to be processed.
+	if _, err := view.RetrieveData(latency.Name()); err != nil {
+		t.Fatalf("Failed to retrieve data: %v", err)
+	}
+
+	h := NewMetricsHandler()
+	h.AddGauge("test/prometheus/running", "Running things", func() float64 { return 3 })
+	h.AddLabeledGauge("test/prometheus/usage", "", "resource", func() map[string]float64 {
+		return map[string]float64{"CPU_pct": 40, "MEM_pct": 12.5}
+	})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/openmetrics-text") {
+		t.Errorf("Content-Type = %q, want application/openmetrics-text", ct)
+	}
+	b, err := io.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response: %v", err)
+	}
+	got := string(b)
+	for _, want := range []string{
+		"# TYPE test_prometheus_count counter\n" +
+			"# HELP test_prometheus_count Number of things\n" +
+			"test_prometheus_count_total{thing=\"a \\\"quoted\\\" value\"} 3\n",
+		"# TYPE test_prometheus_latency histogram\n" +
+			"# HELP test_prometheus_latency Time spent on things\n" +
+			"test_prometheus_latency_bucket{le=\"10\"} 1\n" +
+			"test_prometheus_latency_bucket{le=\"100\"} 2\n" +
+			"test_prometheus_latency_bucket{le=\"+Inf\"} 3\n" +
+			"test_prometheus_latency_count 3\n" +
+			"test_prometheus_latency_sum 555\n",
+		"# TYPE test_prometheus_running gauge\n" +
+			"# HELP test_prometheus_running Running things\n" +
+			"test_prometheus_running 3\n",
+		"# TYPE test_prometheus_usage gauge\n" +
+			"test_prometheus_usage{resource=\"CPU_pct\"} 40\n" +
+			"test_prometheus_usage{resource=\"MEM_pct\"} 12.5\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Served metrics do not contain:\n%s\ngot:\n%s", want, got)
+		}
+	}
+	if !strings.HasSuffix(got, "# EOF\n") {
+		t.Errorf("Served metrics do not end with # EOF, got:\n%s", got)
+	}
+}
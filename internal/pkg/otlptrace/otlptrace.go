@@ -0,0 +1,308 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package otlptrace exports the actions of reproxy as OpenTelemetry traces while the build runs,
+// to an OTLP/gRPC collector or to a file in the OTLP JSON format.
+package otlptrace
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bazelbuild/reclient/internal/pkg/version"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	lpb "github.com/bazelbuild/reclient/api/log"
+	opb "github.com/bazelbuild/reclient/api/otlp"
+	log "github.com/golang/glog"
+)
+
+const (
+	// scopeName is the name of the instrumentation scope of the exported spans.
+	scopeName = "github.com/bazelbuild/reclient/internal/pkg/otlptrace"
+	// batchSize is the maximum number of spans exported at a time.
+	batchSize = 512
+	// maxQueuedSpans is the number of spans waiting to be exported above which the spans of further
+	// actions are dropped, e.g. while the collector is unreachable.
+	maxQueuedSpans = 64 * batchSize
+	// exportInterval is how often queued spans are exported if there are fewer than batchSize.
+	exportInterval = 5 * time.Second
+	// exportTimeout is the maximum time spent exporting a batch of spans.
+	exportTimeout = 30 * time.Second
+)
+
+// client sends batches of spans to their destination.
+type client interface {
+	export(ctx context.Context, req *opb.ExportTraceServiceRequest) error
+	close() error
+}
+
+// Exporter exports the spans of the actions of reproxy in batches, in the background.
+type Exporter struct {
+	client   client
+	resource *opb.Resource
+
+	mu      sync.Mutex
+	spans   []*opb.Span
+	dropped int
+
+	flush chan bool
+	done  chan bool
+	wg    sync.WaitGroup
+}
+
+// NewGRPCExporter returns an Exporter sending the spans to the OTLP/gRPC collector at endpoint, in
+// the host:port format, over TLS unless useInsecure is set. attrs are added to the attributes of
+// the resource of the spans, which name the service as reproxy.
+func NewGRPCExporter(ctx context.Context, endpoint string, useInsecure bool, attrs map[string]string) (*Exporter, error) {
+	creds := credentials.NewClientTLSFromCert(nil, "")
+	if useInsecure {
+		creds = insecure.NewCredentials()
+	}
+	conn, err := grpc.DialContext(ctx, endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial OTLP collector at %v: %w", endpoint, err)
+	}
+	return newExporter(&grpcClient{conn: conn, client: opb.NewTraceServiceClient(conn)}, attrs), nil
+}
+
+// NewFileExporter returns an Exporter appending the spans to the file at path, in the OTLP JSON
+// format with an ExportTraceServiceRequest per line, which OpenTelemetry collectors can read with
+// the otlpjsonfile receiver. attrs are added to the attributes of the resource of the spans.
+func NewFileExporter(path string, attrs map[string]string) (*Exporter, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open OTLP file: %w", err)
+	}
+	return newExporter(&fileClient{f: f}, attrs), nil
+}
+
+func newExporter(c client, attrs map[string]string) *Exporter {
+	e := &Exporter{
+		client:   c,
+		resource: newResource(attrs),
+		flush:    make(chan bool, 1),
+		done:     make(chan bool),
+	}
+	e.wg.Add(1)
+	go e.run()
+	return e
+}
+
+func newResource(attrs map[string]string) *opb.Resource {
+	all := map[string]string{
+		"service.name":    "reproxy",
+		"service.version": version.CurrentVersion(),
+	}
+	for k, v := range attrs {
+		all[k] = v
+	}
+	keys := make([]string, 0, len(all))
+	for k := range all {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	res := &opb.Resource{}
+	for _, k := range keys {
+		res.Attributes = append(res.Attributes, stringAttr(k, all[k]))
+	}
+	return res
+}
+
+// ExportRecord queues the spans of the action of a record for export. The record must not change
+// afterwards.
+func (e *Exporter) ExportRecord(rec *lpb.LogRecord) {
+	if e == nil {
+		return
+	}
+	spans := Spans(rec)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.spans)+len(spans) > maxQueuedSpans {
+		e.dropped += len(spans)
+		return
+	}
+	e.spans = append(e.spans, spans...)
+	if len(e.spans) >= batchSize {
+		select {
+		case e.flush <- true:
+		default:
+		}
+	}
+}
+
+func (e *Exporter) run() {
+	defer e.wg.Done()
+	ticker := time.NewTicker(exportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.done:
+			e.exportQueued()
+			return
+		case <-ticker.C:
+		case <-e.flush:
+		}
+		e.exportQueued()
+	}
+}
+
+// exportQueued exports the queued spans in batches, until there are none left or a batch fails to
+// be exported.
+func (e *Exporter) exportQueued() {
+	for {
+		e.mu.Lock()
+		n := len(e.spans)
+		if n > batchSize {
+			n = batchSize
+		}
+		batch := e.spans[:n]
+		e.spans = e.spans[n:]
+		dropped := e.dropped
+		e.dropped = 0
+		e.mu.Unlock()
+		if dropped > 0 {
+			log.Warningf("Dropped %d spans because too many were waiting to be exported to OTLP", dropped)
+		}
+		if len(batch) == 0 {
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		err := e.client.export(ctx, e.request(batch))
+		cancel()
+		if err != nil {
+			log.Warningf("Failed to export %d spans to OTLP: %v", len(batch), err)
+			return
+		}
+	}
+}
+
+func (e *Exporter) request(spans []*opb.Span) *opb.ExportTraceServiceRequest {
+	return &opb.ExportTraceServiceRequest{
+		ResourceSpans: []*opb.ResourceSpans{{
+			Resource: e.resource,
+			ScopeSpans: []*opb.ScopeSpans{{
+				Scope: &opb.InstrumentationScope{Name: scopeName, Version: version.CurrentVersion()},
+				Spans: spans,
+			}},
+		}},
+	}
+}
+
+// Close exports the spans queued so far and closes the exporter.
+func (e *Exporter) Close() error {
+	if e == nil {
+		return nil
+	}
+	close(e.done)
+	e.wg.Wait()
+	e.mu.Lock()
+	if n := len(e.spans) + e.dropped; n > 0 {
+		log.Warningf("Dropped %d spans that were not exported to OTLP before shutdown", n)
+	}
+	e.mu.Unlock()
+	return e.client.close()
+}
+
+type grpcClient struct {
+	conn   *grpc.ClientConn
+	client opb.TraceServiceClient
+}
+
+func (c *grpcClient) export(ctx context.Context, req *opb.ExportTraceServiceRequest) error {
+	resp, err := c.client.Export(ctx, req)
+	if err != nil {
+		return err
+	}
+	if ps := resp.GetPartialSuccess(); ps.GetRejectedSpans() > 0 {
+		return fmt.Errorf("collector rejected %d spans: %v", ps.GetRejectedSpans(), ps.GetErrorMessage())
+	}
+	return nil
+}
+
+func (c *grpcClient) close() error {
+	return c.conn.Close()
+}
+
+type fileClient struct {
+	f *os.File
+}
+
+func (c *fileClient) export(_ context.Context, req *opb.ExportTraceServiceRequest) error {
+	b, err := marshalJSON(req)
+	if err != nil {
+		return err
+	}
+	_, err = c.f.Write(append(b, '\n'))
+	return err
+}
+
+func (c *fileClient) close() error {
+	return c.f.Close()
+}
+
+// marshalJSON encodes an export request in the OTLP JSON format, which differs from the canonical
+// JSON encoding of protos in that enums are encoded as integers, and trace and span IDs as hex
+// strings instead of base64.
+func marshalJSON(req *opb.ExportTraceServiceRequest) ([]byte, error) {
+	b, err := protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	for _, rs := range objects(m, "resourceSpans") {
+		for _, ss := range objects(rs, "scopeSpans") {
+			for _, s := range objects(ss, "spans") {
+				for _, k := range []string{"traceId", "spanId", "parentSpanId"} {
+					v, ok := s[k].(string)
+					if !ok {
+						continue
+					}
+					id, err := base64.StdEncoding.DecodeString(v)
+					if err != nil {
+						return nil, fmt.Errorf("invalid %v %q: %w", k, v, err)
+					}
+					s[k] = hex.EncodeToString(id)
+				}
+			}
+		}
+	}
+	return json.Marshal(m)
+}
+
+// objects returns the JSON objects of the array at key in m.
+func objects(m map[string]any, key string) []map[string]any {
+	arr, _ := m[key].([]any)
+	var objs []map[string]any
+	for _, v := range arr {
+		if o, ok := v.(map[string]any); ok {
+			objs = append(objs, o)
+		}
+	}
+	return objs
+}
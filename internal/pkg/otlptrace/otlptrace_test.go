@@ -0,0 +1,264 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptrace
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bazelbuild/reclient/internal/pkg/event"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+
+	lpb "github.com/bazelbuild/reclient/api/log"
+	opb "github.com/bazelbuild/reclient/api/otlp"
+
+	cpb "github.com/bazelbuild/remote-apis-sdks/go/api/command"
+)
+
+const testExecutionID = "0f8fad5b-d9cb-469f-a165-70867728950e"
+
+var testStart = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func interval(from, to int) *cpb.TimeInterval {
+	return &cpb.TimeInterval{
+		From: tspb.New(testStart.Add(time.Duration(from) * time.Millisecond)),
+		To:   tspb.New(testStart.Add(time.Duration(to) * time.Millisecond)),
+	}
+}
+
+func testRecord() *lpb.LogRecord {
+	return &lpb.LogRecord{
+		Command: &cpb.Command{
+			Identifiers: &cpb.Identifiers{
+				CommandId:    "cmd",
+				InvocationId: "invocation",
+				ExecutionId:  testExecutionID,
+			},
+		},
+		CompletionStatus: lpb.CompletionStatus_STATUS_REMOTE_EXECUTION,
+		Result:           &cpb.CommandResult{Status: cpb.CommandResultStatus_SUCCESS},
+		LocalMetadata: &lpb.LocalMetadata{
+			Labels: map[string]string{"type": "tool"},
+			EventTimes: map[string]*cpb.TimeInterval{
+				event.ProxyExecution:     interval(0, 100),
+				event.ProcessInputs:      interval(1, 10),
+				event.InputProcessorWait: interval(1, 2),
+			},
+		},
+		RemoteMetadata: &lpb.RemoteMetadata{
+			ActionDigest: "abc/1",
+			EventTimes: map[string]*cpb.TimeInterval{
+				command.EventUploadInputs:          interval(10, 20),
+				command.EventExecuteRemotely:       interval(20, 90),
+				command.EventServerWorker:          interval(30, 80),
+				command.EventServerWorkerExecution: interval(40, 70),
+				command.EventDownloadResults:       interval(90, 99),
+				// Events without an end are left out.
+				command.EventServerWorkerOutputUpload: {From: tspb.New(testStart)},
+			},
+		},
+	}
+}
+
+func TestSpans(t *testing.T) {
+	spans := Spans(testRecord())
+	wantTraceID, _ := hex.DecodeString(strings.ReplaceAll(testExecutionID, "-", ""))
+	byID := make(map[string]*opb.Span)
+	for _, s := range spans {
+		if !cmp.Equal(s.GetTraceId(), wantTraceID) {
+			t.Errorf("Spans() returned span %q with trace ID %x, want %x", s.GetName(), s.GetTraceId(), wantTraceID)
+		}
+		byID[string(s.GetSpanId())] = s
+	}
+	type span struct {
+		Parent     string
+		Start, End time.Duration
+	}
+	got := make(map[string]span)
+	for _, s := range spans {
+		got[s.GetName()] = span{
+			Parent: byID[string(s.GetParentSpanId())].GetName(),
+			Start:  time.Duration(s.GetStartTimeUnixNano() - unixNano(testStart)),
+			End:    time.Duration(s.GetEndTimeUnixNano() - unixNano(testStart)),
+		}
+	}
+	ms := time.Millisecond
+	want := map[string]span{
+		rootSpanName:                       {End: 100 * ms},
+		event.ProcessInputs:                {Parent: rootSpanName, Start: ms, End: 10 * ms},
+		event.InputProcessorWait:           {Parent: event.ProcessInputs, Start: ms, End: 2 * ms},
+		command.EventUploadInputs:          {Parent: rootSpanName, Start: 10 * ms, End: 20 * ms},
+		command.EventExecuteRemotely:       {Parent: rootSpanName, Start: 20 * ms, End: 90 * ms},
+		command.EventServerWorker:          {Parent: command.EventExecuteRemotely, Start: 30 * ms, End: 80 * ms},
+		command.EventServerWorkerExecution: {Parent: command.EventServerWorker, Start: 40 * ms, End: 70 * ms},
+		command.EventDownloadResults:       {Parent: rootSpanName, Start: 90 * ms, End: 99 * ms},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Spans() returned diff in spans: (-want +got)\n%s", diff)
+	}
+	root := spans[0]
+	if root.GetName() != rootSpanName || root.GetStatus().GetCode() != opb.Status_STATUS_CODE_OK {
+		t.Errorf("Spans() returned first span %q with status %v, want %q with status OK", root.GetName(), root.GetStatus(), rootSpanName)
+	}
+	attrs := make(map[string]string)
+	for _, kv := range root.GetAttributes() {
+		attrs[kv.GetKey()] = kv.GetValue().GetStringValue()
+	}
+	for k, v := range map[string]string{"command_id": "cmd", "labels": "[type=tool]", "action_digest": "abc/1"} {
+		if attrs[k] != v {
+			t.Errorf("Spans() returned root span with attribute %v=%q, want %q", k, attrs[k], v)
+		}
+	}
+}
+
+func TestSpansWithoutProxyExecution(t *testing.T) {
+	rec := &lpb.LogRecord{
+		Result: &cpb.CommandResult{Status: cpb.CommandResultStatus_NON_ZERO_EXIT, Msg: "failed"},
+		LocalMetadata: &lpb.LocalMetadata{
+			EventTimes: map[string]*cpb.TimeInterval{
+				event.LocalCommandQueued:    interval(5, 10),
+				event.LocalCommandExecution: interval(10, 30),
+			},
+		},
+	}
+	spans := Spans(rec)
+	if len(spans) != 3 {
+		t.Fatalf("Spans() returned %d spans, want 3", len(spans))
+	}
+	root := spans[0]
+	if start, end := root.GetStartTimeUnixNano(), root.GetEndTimeUnixNano(); start != unixNano(testStart.Add(5*time.Millisecond)) || end != unixNano(testStart.Add(30*time.Millisecond)) {
+		t.Errorf("Spans() returned root span from %v to %v, want it to cover the events", start, end)
+	}
+	wantStatus := &opb.Status{Code: opb.Status_STATUS_CODE_ERROR, Message: "NON_ZERO_EXIT: failed"}
+	if root.GetStatus().GetCode() != wantStatus.Code || root.GetStatus().GetMessage() != wantStatus.Message {
+		t.Errorf("Spans() returned root span with status %v, want %v", root.GetStatus(), wantStatus)
+	}
+	if len(root.GetTraceId()) != 16 {
+		t.Errorf("Spans() returned trace ID %x, want a random 16 bytes ID", root.GetTraceId())
+	}
+	if got := Spans(&lpb.LogRecord{}); got != nil {
+		t.Errorf("Spans() of a record without events returned %v, want none", got)
+	}
+}
+
+type fakeCollector struct {
+	opb.UnimplementedTraceServiceServer
+	mu   sync.Mutex
+	reqs []*opb.ExportTraceServiceRequest
+}
+
+func (c *fakeCollector) Export(_ context.Context, req *opb.ExportTraceServiceRequest) (*opb.ExportTraceServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reqs = append(c.reqs, req)
+	return &opb.ExportTraceServiceResponse{}, nil
+}
+
+func TestGRPCExporter(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	collector := &fakeCollector{}
+	grpcServer := grpc.NewServer()
+	opb.RegisterTraceServiceServer(grpcServer, collector)
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	e, err := NewGRPCExporter(context.Background(), lis.Addr().String(), true, map[string]string{"build": "123"})
+	if err != nil {
+		t.Fatalf("NewGRPCExporter() returned error: %v", err)
+	}
+	e.ExportRecord(testRecord())
+	e.ExportRecord(testRecord())
+	if err := e.Close(); err != nil {
+		t.Fatalf("Close() returned error: %v", err)
+	}
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	if len(collector.reqs) != 1 {
+		t.Fatalf("Collector received %d requests, want 1", len(collector.reqs))
+	}
+	rs := collector.reqs[0].GetResourceSpans()[0]
+	attrs := make(map[string]string)
+	for _, kv := range rs.GetResource().GetAttributes() {
+		attrs[kv.GetKey()] = kv.GetValue().GetStringValue()
+	}
+	if attrs["service.name"] != "reproxy" || attrs["build"] != "123" {
+		t.Errorf("Collector received resource attributes %v, want service.name=reproxy and build=123", attrs)
+	}
+	if got, want := len(rs.GetScopeSpans()[0].GetSpans()), 2*len(Spans(testRecord())); got != want {
+		t.Errorf("Collector received %d spans, want %d", got, want)
+	}
+}
+
+func TestFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	e, err := NewFileExporter(path, nil)
+	if err != nil {
+		t.Fatalf("NewFileExporter() returned error: %v", err)
+	}
+	e.ExportRecord(testRecord())
+	if err := e.Close(); err != nil {
+		t.Fatalf("Close() returned error: %v", err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %v: %v", path, err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("NewFileExporter() wrote %d lines, want 1", len(lines))
+	}
+	var req struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []struct {
+					TraceID           string `json:"traceId"`
+					SpanID            string `json:"spanId"`
+					Name              string `json:"name"`
+					Kind              int    `json:"kind"`
+					StartTimeUnixNano string `json:"startTimeUnixNano"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &req); err != nil {
+		t.Fatalf("NewFileExporter() wrote invalid JSON %q: %v", lines[0], err)
+	}
+	root := req.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	if want := strings.ReplaceAll(testExecutionID, "-", ""); root.TraceID != want {
+		t.Errorf("NewFileExporter() wrote trace ID %q, want %q", root.TraceID, want)
+	}
+	if want := hex.EncodeToString(spanID(rootSpanName)); root.SpanID != want {
+		t.Errorf("NewFileExporter() wrote span ID %q, want %q", root.SpanID, want)
+	}
+	if root.Name != rootSpanName || root.Kind != int(opb.Span_SPAN_KIND_SERVER) || root.StartTimeUnixNano != "1672628645000000000" {
+		t.Errorf("NewFileExporter() wrote root span %+v, want name %q, kind %d and start 1672628645000000000", root, rootSpanName, opb.Span_SPAN_KIND_SERVER)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlptrace
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bazelbuild/reclient/internal/pkg/event"
+	"github.com/bazelbuild/reclient/internal/pkg/labels"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
+
+	lpb "github.com/bazelbuild/reclient/api/log"
+	opb "github.com/bazelbuild/reclient/api/otlp"
+	cpb "github.com/bazelbuild/remote-apis-sdks/go/api/command"
+)
+
+// rootSpanName is the name of the span of the whole action, the root of the spans of its events.
+const rootSpanName = "RunCommand"
+
+// parentEvents are the events whose spans are nested under the span of another event of the same
+// action. The spans of the other events are children of the root span.
+var parentEvents = map[string]string{
+	event.CPPInputProcessor:               event.ProcessInputs,
+	event.InputProcessorWait:              event.ProcessInputs,
+	event.InputProcessorCacheLookup:       event.ProcessInputs,
+	command.EventServerQueued:             command.EventExecuteRemotely,
+	command.EventServerWorker:             command.EventExecuteRemotely,
+	command.EventServerWorkerInputFetch:   command.EventServerWorker,
+	command.EventServerWorkerExecution:    command.EventServerWorker,
+	command.EventServerWorkerOutputUpload: command.EventServerWorker,
+}
+
+// Spans returns the span tree of the action of a LogRecord: a root span covering the time spent in
+// reproxy, and a span for each event time of its local and remote metadata. All spans of an action
+// share a trace, whose ID is the execution ID of the action.
+func Spans(rec *lpb.LogRecord) []*opb.Span {
+	events := make(map[string]*cpb.TimeInterval)
+	for _, ets := range []map[string]*cpb.TimeInterval{
+		rec.GetRemoteMetadata().GetEventTimes(),
+		rec.GetLocalMetadata().GetEventTimes(),
+	} {
+		for name, et := range ets {
+			// Events of failed remote executions can miss their end, e.g. ServerWorkerExecution.
+			if et.GetFrom() == nil || et.GetTo() == nil {
+				continue
+			}
+			events[name] = et
+		}
+	}
+	root, ok := events[event.ProxyExecution]
+	delete(events, event.ProxyExecution)
+	if !ok {
+		root = coveringInterval(events)
+	}
+	if root == nil {
+		return nil
+	}
+	traceID := traceID(rec.GetCommand().GetIdentifiers().GetExecutionId())
+	rootSpan := &opb.Span{
+		TraceId:           traceID,
+		SpanId:            spanID(rootSpanName),
+		Name:              rootSpanName,
+		Kind:              opb.Span_SPAN_KIND_SERVER,
+		StartTimeUnixNano: unixNano(root.GetFrom().AsTime()),
+		EndTimeUnixNano:   unixNano(root.GetTo().AsTime()),
+		Attributes:        recordAttributes(rec),
+		Status:            recordStatus(rec),
+	}
+	spans := []*opb.Span{rootSpan}
+	names := make([]string, 0, len(events))
+	for name := range events {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		parent := rootSpanName
+		if p, ok := parentEvents[name]; ok {
+			if _, ok := events[p]; ok {
+				parent = p
+			}
+		}
+		spans = append(spans, &opb.Span{
+			TraceId:           traceID,
+			SpanId:            spanID(name),
+			ParentSpanId:      spanID(parent),
+			Name:              name,
+			Kind:              opb.Span_SPAN_KIND_INTERNAL,
+			StartTimeUnixNano: unixNano(events[name].GetFrom().AsTime()),
+			EndTimeUnixNano:   unixNano(events[name].GetTo().AsTime()),
+		})
+	}
+	return spans
+}
+
+// coveringInterval returns the interval from the earliest start to the latest end of the events.
+func coveringInterval(events map[string]*cpb.TimeInterval) *cpb.TimeInterval {
+	var cov *cpb.TimeInterval
+	for _, et := range events {
+		if cov == nil {
+			cov = &cpb.TimeInterval{From: et.GetFrom(), To: et.GetTo()}
+			continue
+		}
+		if et.GetFrom().AsTime().Before(cov.GetFrom().AsTime()) {
+			cov.From = et.GetFrom()
+		}
+		if et.GetTo().AsTime().After(cov.GetTo().AsTime()) {
+			cov.To = et.GetTo()
+		}
+	}
+	return cov
+}
+
+// traceID returns the ID of the trace of the action with the given execution ID, which is a UUID,
+// or a random ID if it is not.
+func traceID(executionID string) []byte {
+	if id, err := hex.DecodeString(strings.ReplaceAll(executionID, "-", "")); err == nil && len(id) == 16 {
+		return id
+	}
+	id := make([]byte, 16)
+	rand.Read(id)
+	return id
+}
+
+// spanID returns the ID of the span with the given name. Span names are unique within the trace of
+// an action.
+func spanID(name string) []byte {
+	s := sha256.Sum256([]byte(name))
+	return s[:8]
+}
+
+func unixNano(t time.Time) uint64 {
+	return uint64(t.UnixNano())
+}
+
+func recordAttributes(rec *lpb.LogRecord) []*opb.KeyValue {
+	ids := rec.GetCommand().GetIdentifiers()
+	attrs := []*opb.KeyValue{
+		stringAttr("command_id", ids.GetCommandId()),
+		stringAttr("invocation_id", ids.GetInvocationId()),
+		stringAttr("correlated_invocations_id", ids.GetCorrelatedInvocationsId()),
+		stringAttr("tool_name", ids.GetToolName()),
+		stringAttr("execution_id", ids.GetExecutionId()),
+		stringAttr("labels", labels.ToKey(rec.GetLocalMetadata().GetLabels())),
+		stringAttr("completion_status", rec.GetCompletionStatus().String()),
+		intAttr("exit_code", int64(rec.GetResult().GetExitCode())),
+	}
+	if outs := rec.GetCommand().GetOutput().GetOutputFiles(); len(outs) > 0 {
+		attrs = append(attrs, stringAttr("output", outs[0]))
+	}
+	if rm := rec.GetRemoteMetadata(); rm != nil {
+		attrs = append(attrs,
+			stringAttr("action_digest", rm.GetActionDigest()),
+			boolAttr("cache_hit", rm.GetCacheHit()),
+			intAttr("total_input_bytes", rm.GetTotalInputBytes()),
+			intAttr("total_output_bytes", rm.GetTotalOutputBytes()))
+	}
+	return attrs
+}
+
+func recordStatus(rec *lpb.LogRecord) *opb.Status {
+	switch rec.GetResult().GetStatus() {
+	case cpb.CommandResultStatus_SUCCESS, cpb.CommandResultStatus_CACHE_HIT:
+		return &opb.Status{Code: opb.Status_STATUS_CODE_OK}
+	case cpb.CommandResultStatus_UNKNOWN:
+		return nil
+	default:
+		msg := rec.GetResult().GetStatus().String()
+		if m := rec.GetResult().GetMsg(); m != "" {
+			msg += ": " + m
+		}
+		return &opb.Status{Code: opb.Status_STATUS_CODE_ERROR, Message: msg}
+	}
+}
+
+func stringAttr(key, v string) *opb.KeyValue {
+	return &opb.KeyValue{Key: key, Value: &opb.AnyValue{Value: &opb.AnyValue_StringValue{StringValue: v}}}
+}
+
+func intAttr(key string, v int64) *opb.KeyValue {
+	return &opb.KeyValue{Key: key, Value: &opb.AnyValue{Value: &opb.AnyValue_IntValue{IntValue: v}}}
+}
+
+func boolAttr(key string, v bool) *opb.KeyValue {
+	return &opb.KeyValue{Key: key, Value: &opb.AnyValue{Value: &opb.AnyValue_BoolValue{BoolValue: v}}}
+}
@@ -17,6 +17,7 @@ package rbeflag
 
 import (
 	"bufio"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -28,6 +29,12 @@ import (
 
 var (
 	rgx = regexp.MustCompile(`[\s=]`)
+
+	// cfgFile is the config file flag defined by Parse.
+	cfgFile *string
+	// explicit are the names of the flags set in the command line or environment variables, which
+	// the config file does not override.
+	explicit map[string]bool
 )
 
 // Parse parses flags which are set in environment variables using the RBE_ prefix, otherwise
@@ -40,18 +47,22 @@ var (
 // by the contents of the config file.
 func Parse() {
 	if !flag.Parsed() {
-		cfgFile := flag.String("cfg", "", "Optional configuration file containing command-line argument settings")
+		cfgFile = flag.String("cfg", "", "Optional configuration file containing command-line argument settings")
 		ParseFromEnv()
 		flag.Parse()
+		explicit = make(map[string]bool)
+		flag.Visit(func(f *flag.Flag) {
+			explicit[f.Name] = true
+		})
 		if *cfgFile != "" {
 			cfgMap, err := parseFromFile(*cfgFile)
 			if err != nil {
 				log.Fatalf("failed reading config file %v: %v", *cfgFile, err)
 			}
 			// Remove keys from the map that are already set.
-			flag.Visit(func(f *flag.Flag) {
-				delete(cfgMap, f.Name)
-			})
+			for name := range explicit {
+				delete(cfgMap, name)
+			}
 			// Set the flags remaining in the config map.
 			for k, v := range cfgMap {
 				if err := flag.Set(k, v); err != nil {
@@ -62,6 +73,23 @@ func Parse() {
 	}
 }
 
+// ReloadConfig reads the config file given to Parse again and returns the values it defines for
+// flags that were not set in the command line or environment variables, which keep precedence.
+// Flags are not set: callers decide which of them can safely change at runtime.
+func ReloadConfig() (map[string]string, error) {
+	if cfgFile == nil || *cfgFile == "" {
+		return nil, errors.New("no config file given with --cfg")
+	}
+	cfgMap, err := parseFromFile(*cfgFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading config file %v: %w", *cfgFile, err)
+	}
+	for name := range explicit {
+		delete(cfgMap, name)
+	}
+	return cfgMap, nil
+}
+
 // parseFromFile parses flags which are defined in a configuration file. The file format is a
 // single argument per line. For arguments assigning values, they should be separated by '=' or
 // whitespace.
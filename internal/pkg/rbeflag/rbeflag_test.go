@@ -17,7 +17,10 @@ package rbeflag
 import (
 	"flag"
 	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestParseUnset(t *testing.T) {
@@ -225,3 +228,40 @@ func TestParseWithConfigFileCmdLineWins(t *testing.T) {
 		t.Errorf("Flag has wrong value, want 'abc', got %q", *f)
 	}
 }
+
+func TestReloadConfig(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "test.cfg")
+	if err := os.WriteFile(cfgPath, []byte("arg=xyz\nother=1\n"), 0644); err != nil {
+		t.Fatalf("Failed writing config file: %v", err)
+	}
+
+	argsCopy := os.Args
+	t.Cleanup(func() {
+		os.Args = argsCopy
+	})
+	cl := flag.CommandLine
+	t.Cleanup(func() {
+		flag.CommandLine = cl
+	})
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	os.Args = []string{os.Args[0], "--cfg", cfgPath, "--arg", "abc"}
+	flag.String("arg", "", "Some value")
+	other := flag.String("other", "", "Some other value")
+	Parse()
+	if *other != "1" {
+		t.Fatalf("Flag has wrong value, want '1', got %q", *other)
+	}
+
+	if err := os.WriteFile(cfgPath, []byte("arg=uvw\nother=2\n"), 0644); err != nil {
+		t.Fatalf("Failed writing config file: %v", err)
+	}
+	got, err := ReloadConfig()
+	if err != nil {
+		t.Fatalf("ReloadConfig() returned error: %v", err)
+	}
+	// Flags set in the command line are not overridden by the config file.
+	want := map[string]string{"other": "2"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ReloadConfig() returned diff (-want +got):\n%s", diff)
+	}
+}
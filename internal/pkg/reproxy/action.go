@@ -23,9 +23,11 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bazelbuild/reclient/internal/pkg/deps"
+	"github.com/bazelbuild/reclient/internal/pkg/diskcas"
 	"github.com/bazelbuild/reclient/internal/pkg/event"
 	"github.com/bazelbuild/reclient/internal/pkg/logger"
 	"github.com/bazelbuild/reclient/internal/pkg/pathtranslator"
@@ -60,6 +62,8 @@ const (
 	testOnlyBlockRemoteExecKey testOnlyCtxKey = iota
 	testOnlyBlockLocalExecKey
 	testOnlyBlockFallbackKey
+	// testOnlyRemoteAttemptKey is called with the context of each attempt of remote executions.
+	testOnlyRemoteAttemptKey
 )
 
 type action struct {
@@ -88,6 +92,15 @@ type action struct {
 	downloadRegex          string
 	downloadTmp            string
 	atomicDownloads        bool
+	diskCAS                *diskcas.CAS
+	// stream, if set, receives the output of local execution as it is produced.
+	stream *outputStream
+	// progress, if set, reports the phases of the action to WatchActions streams.
+	progress *actionProgress
+	// start is the time reproxy received the action.
+	start time.Time
+	// outputChecks are the checks of the outputs of the action once it succeeds.
+	outputChecks outputChecks
 
 	// Below parameters are computed by struct functions.
 	execContext   *rexec.Context
@@ -97,6 +110,12 @@ type action struct {
 	res           *command.Result
 	rawInOutFiles []string
 	digest        string
+	inFlight      *inFlightActions
+	// inOutSnapshot is the directory holding copies of the in-out files taken before remote
+	// execution, for compare mode reruns in the background.
+	inOutSnapshot string
+	// outputsValidated is set once the outputs of the action were validated.
+	outputsValidated bool
 }
 
 func (a *action) runLocal(ctx context.Context, pool *LocalPool) {
@@ -114,9 +133,20 @@ func (a *action) runLocal(ctx context.Context, pool *LocalPool) {
 	}
 
 	log.V(2).Infof("%v: Executing locally...\n%s", cmd.Identifiers.ExecutionID, strings.Join(cmd.Args, " "))
-	exitCode, err := pool.Run(ctx, ctx, cmd, a.lbls, a.oe, a.rec)
+	oe := a.oe
+	if a.stream != nil {
+		oe = a.stream.tee(a.oe)
+	}
+	ctx = a.progress.withProgress(ctx)
+	exitCode, err := pool.Run(ctx, ctx, cmd, a.lbls, a.lOpt, oe, a.rec)
 	a.res = command.NewResultFromExitCode(exitCode)
-	if exitCode == 0 && err != nil {
+	if errors.Is(err, errLocalTimeout) {
+		a.res = &command.Result{
+			Status:   command.TimeoutResultStatus,
+			Err:      err,
+			ExitCode: LocalTimeoutExitCode,
+		}
+	} else if exitCode == 0 && err != nil {
 		a.res = command.NewLocalErrorResult(err)
 	}
 	a.rec.LocalMetadata.ExecutedLocally = true
@@ -160,10 +190,26 @@ func (a *action) runRemote(ctx context.Context, client *rexec.Client) {
 		res, meta = command.NewLocalErrorResult(err), &command.Metadata{}
 		return
 	}
+	a.progress.setPhase(ppb.ActionPhase_CACHE_LOOKUP)
 	if ec.GetCachedResult(); ec.Result == nil {
-		ec.ExecuteRemotely()
+		// Identical actions can only reuse each other's results through the cache.
+		if opts.AcceptCached && !opts.DoNotCache {
+			release, cached := a.dedupInFlight(ctx, ec.Metadata.ActionDigest, func() bool {
+				ec.GetCachedResult()
+				return ec.Result != nil
+			})
+			defer release()
+			if !cached {
+				a.progress.setPhase(ppb.ActionPhase_UPLOAD_AND_EXECUTE)
+				ec.ExecuteRemotely()
+			}
+		} else {
+			a.progress.setPhase(ppb.ActionPhase_UPLOAD_AND_EXECUTE)
+			ec.ExecuteRemotely()
+		}
 	}
 	res, meta = ec.Result, ec.Metadata
+	a.forecast.RecordRemoteSample(a, res, meta)
 	if !res.IsOk() {
 		return
 	}
@@ -176,13 +222,13 @@ func (a *action) runRemote(ctx context.Context, client *rexec.Client) {
 	outs = a.excludeOutputsViaFilter(outs)
 	if excludeUnchanged {
 		outs = a.excludeUnchangedOutputs(outs, a.cmd.ExecRoot)
-		ec.DownloadSpecifiedOutputs(outs, outDir)
+		a.downloadOutputs(ec, outs, outDir)
 		res, meta = ec.Result, ec.Metadata
 		if ec.Result.Err != nil {
 			return
 		}
 	} else {
-		ec.DownloadSpecifiedOutputs(outs, outDir)
+		a.downloadOutputs(ec, outs, outDir)
 		res, meta = ec.Result, ec.Metadata
 		if ec.Result.Err != nil {
 			return
@@ -263,7 +309,9 @@ type raceResult struct {
 	t   resultType
 }
 
-func (a *action) race(ctx context.Context, client *rexec.Client, pool *LocalPool, numFallbacks *windowedCount, maxHoldoff time.Duration) {
+// race races remote and local execution of the action. reportRemote is called with the result of
+// the remote execution once it is known, or with nil if remote execution did not happen.
+func (a *action) race(ctx context.Context, client *rexec.Client, pool *LocalPool, numFallbacks *windowedCount, maxHoldoff time.Duration, reportRemote func(*command.Result)) {
 	cCtx, cancel := context.WithCancel(ctx)
 	// Get digests and mtimes of existing outs on disk if needed for comparison later
 	var preExecOuts map[string]filemetadata.Metadata
@@ -278,6 +326,7 @@ func (a *action) race(ctx context.Context, client *rexec.Client, pool *LocalPool
 	if err != nil {
 		log.Warningf("%v: could not create temp directory for remote output: %v", a.cmd.Identifiers.ExecutionID, err)
 		a.res = command.NewLocalErrorResult(err)
+		reportRemote(nil)
 		return
 	}
 	defer func() {
@@ -292,7 +341,7 @@ func (a *action) race(ctx context.Context, client *rexec.Client, pool *LocalPool
 		// the testOnlyBlock value to ensure we can still test blocking remote
 		// results.
 		bCtx := context.WithValue(context.Background(), testOnlyBlockRemoteExecKey, ctx.Value(testOnlyBlockRemoteExecKey))
-		ch <- a.runRemoteRace(bCtx, cCtx, client, lCh, tmpDir, maxHoldoff)
+		ch <- a.runRemoteRace(bCtx, cCtx, client, lCh, tmpDir, maxHoldoff, reportRemote)
 	}()
 	go func() {
 		select {
@@ -369,7 +418,7 @@ func (a *action) race(ctx context.Context, client *rexec.Client, pool *LocalPool
 
 // runRemoteRace runs the remote part of the race. lCh is used to start local
 // execution when remote execution is expected to take time.
-func (a *action) runRemoteRace(ctx, cCtx context.Context, client *rexec.Client, lCh chan<- bool, tmpDir string, maxHoldoff time.Duration) raceResult {
+func (a *action) runRemoteRace(ctx, cCtx context.Context, client *rexec.Client, lCh chan<- bool, tmpDir string, maxHoldoff time.Duration, reportRemote func(*command.Result)) raceResult {
 	opts := execOptionsFromProto(a.rOpt)
 	opts.DownloadOutputs = false // We want to download them to tmpDir instead of execRoot.
 	rcmd := a.cmd
@@ -380,15 +429,28 @@ func (a *action) runRemoteRace(ctx, cCtx context.Context, client *rexec.Client,
 		rcmd.Args = append([]string{a.rOpt.GetWrapper()}, a.cmd.Args...)
 	}
 	rOE := outerr.NewRecordingOutErr()
+	var once sync.Once
+	startLocal := func() { once.Do(func() { close(lCh) }) }
+	mode := a.forecast.racingMode(a)
+	if mode == raceLocalFirst {
+		log.V(2).Infof("%v: Forecast to be faster locally, starting local execution", a.cmd.Identifiers.ExecutionID)
+		startLocal()
+	}
 	// Use the non-cancellable context since we don't want to abort the remote execution
 	// attempt even if local wins. This helps get cache hits for subsequent builds
 	var err error
 	if a.execContext, err = client.NewContext(ctx, rcmd, opts, rOE); err != nil {
 		log.Warningf("%v: Failed to create execution context: %v", a.cmd.Identifiers.ExecutionID, err)
-		close(lCh)
+		startLocal()
+		reportRemote(nil)
 		return raceResult{t: canceled, res: command.NewLocalErrorResult(err)}
 	}
+	a.progress.setPhase(ppb.ActionPhase_CACHE_LOOKUP)
 	a.execContext.GetCachedResult()
+	if a.execContext.Result != nil {
+		reportRemote(a.execContext.Result)
+		a.forecast.RecordRemoteSample(a, a.execContext.Result, a.execContext.Metadata)
+	}
 
 	// Always record command and action digests, regardless of race result.
 	if a.rec.RemoteMetadata == nil {
@@ -401,15 +463,21 @@ func (a *action) runRemoteRace(ctx, cCtx context.Context, client *rexec.Client,
 	if a.execContext.Result == nil {
 		// If action is a cache miss, start remote execution and local execution.
 		log.V(2).Infof("%v: Cache miss, starting race", a.cmd.Identifiers.ExecutionID)
-		close(lCh)
+		startLocal()
+		a.progress.setPhase(ppb.ActionPhase_UPLOAD_AND_EXECUTE)
 		a.execContext.ExecuteRemotely()
 		log.V(2).Infof("%v: Executed remotely: %+v", a.cmd.Identifiers.ExecutionID, a.execContext.Result)
+		reportRemote(a.execContext.Result)
+		a.forecast.RecordRemoteSample(a, a.execContext.Result, a.execContext.Metadata)
 		select {
 		case <-cCtx.Done():
 			// If local has already completed, no need to download outputs.
 			return raceResult{t: canceled}
 		default:
 		}
+	} else if a.execContext.Result.Status == command.CacheHitResultStatus && mode == raceRemoteOnCacheHit {
+		// If action is almost always a cache hit, only start local execution if downloads fail.
+		log.V(2).Infof("%v: Cache hit forecast to be faster remotely, not starting local execution", a.cmd.Identifiers.ExecutionID)
 	} else if a.execContext.Result.Status == command.CacheHitResultStatus {
 		// If action is a cache hit, wait for dl milliseconds, then start local execution.
 		go func() {
@@ -427,7 +495,7 @@ func (a *action) runRemoteRace(ctx, cCtx context.Context, client *rexec.Client,
 			}
 			time.Sleep(sl)
 			log.V(2).Infof("%v: Hold off of %v done, will signal local execution", sl, a.cmd.Identifiers.ExecutionID)
-			close(lCh)
+			startLocal()
 		}()
 	} else {
 		// If a.execContext.GetCachedResult() must have returned a result, which is
@@ -435,7 +503,7 @@ func (a *action) runRemoteRace(ctx, cCtx context.Context, client *rexec.Client,
 		// remote error or a local error (say, input processing fail). In this case,
 		// we start local execution immediately.
 		log.Warningf("%v: GetCachedResult() returned a result neither cache hit nor cache miss: %v", a.cmd.Identifiers.ExecutionID, a.execContext.Result)
-		close(lCh)
+		startLocal()
 	}
 	// Store action result before calling DownloadOutputs, which will overwrite the result in the
 	// exec context.
@@ -451,7 +519,7 @@ func (a *action) runRemoteRace(ctx, cCtx context.Context, client *rexec.Client,
 		log.Errorf("%v: Unable to get flattened outputs from Action Result: %v", a.cmd.Identifiers.ExecutionID, err)
 		a.execContext.DownloadOutputs(tmpDir)
 	} else {
-		a.execContext.DownloadSpecifiedOutputs(a.excludeOutputsViaFilter(outs), tmpDir)
+		a.downloadOutputs(a.execContext, a.excludeOutputsViaFilter(outs), tmpDir)
 	}
 	select {
 	case <-cCtx.Done():
@@ -461,6 +529,7 @@ func (a *action) runRemoteRace(ctx, cCtx context.Context, client *rexec.Client,
 	}
 	if !a.execContext.Result.IsOk() {
 		// Download failed.
+		startLocal()
 		return raceResult{t: canceled, res: a.execContext.Result, oe: rOE}
 	}
 	if v := ctx.Value(testOnlyBlockRemoteExecKey); v != nil {
@@ -490,23 +559,33 @@ func (a *action) runLocalRace(ctx, cCtx context.Context, pool *LocalPool, client
 			mergeMaps(cmd.InputSpec.EnvironmentVariables, sliceToMap(a.cmdEnvironment, "="))
 		}
 	}
-	exitCode, err := pool.Run(ctx, cCtx, cmd, a.lbls, lOE, lr)
+	exitCode, err := pool.Run(a.progress.withProgress(ctx), cCtx, cmd, a.lbls, a.lOpt, lOE, lr)
 	if errors.Is(err, context.Canceled) {
 		// Local did not run due to intentional context cancelation.
 		return raceResult{t: canceled}
 	}
-	if exitCode == 0 && err != nil {
+	timedOut := errors.Is(err, errLocalTimeout)
+	if exitCode == 0 && err != nil && !timedOut {
 		// An unexpected local error occured, report to caller.
 		return raceResult{t: canceled, res: command.NewLocalErrorResult(err)}
 	}
-	// Local ran successfully (even if non-zero exit code). At this point, we use
-	// the local result regardless of remote. Hence the below update to the action
-	// metadata.
+	// Local ran successfully (even if non-zero exit code) or timed out. At this
+	// point, we use the local result regardless of remote. Hence the below update
+	// to the action metadata.
 	a.res = command.NewResultFromExitCode(exitCode)
+	if timedOut {
+		a.res = &command.Result{
+			Status:   command.TimeoutResultStatus,
+			Err:      err,
+			ExitCode: LocalTimeoutExitCode,
+		}
+	}
 	if a.rec.GetLocalMetadata() == nil {
 		a.rec.LocalMetadata = &lpb.LocalMetadata{}
 	}
 	a.rec.LocalMetadata.ExecutedLocally = true
+	a.rec.LocalMetadata.OomKilled = lr.GetLocalMetadata().GetOomKilled()
+	a.rec.LocalMetadata.TimeoutPhase = lr.GetLocalMetadata().GetTimeoutPhase()
 	a.rec.CopyEventTimesFrom(lr)
 	a.rec.LocalMetadata.Result = command.ResultToProto(a.res)
 	return raceResult{t: local, res: a.res, oe: lOE}
@@ -712,6 +791,10 @@ func (a *action) cacheLocal() {
 		log.Warningf("%v: Failed to generate deps file: %v", a.cmd.Identifiers.ExecutionID, err)
 		return
 	}
+	a.validateOutputs()
+	if !a.res.IsOk() || a.invalidOutputs() {
+		return
+	}
 	// Clear cache entries of output files / output files in output directories in the FMC
 	// before updating cached results to RBE.
 	// The file metadata cache entries need to be cleared since we did a local run
@@ -849,35 +932,6 @@ func (a *action) cachedResultValid() bool {
 	return ok
 }
 
-func (a *action) removeAllOutputs() {
-	for _, f := range a.cmd.OutputFiles {
-		if f == a.depsFile {
-			continue
-		}
-		path := filepath.Join(a.cmd.ExecRoot, a.cmd.WorkingDir, f)
-		if err := os.Remove(path); err != nil {
-			log.Warningf("%v: Failed to remove file %v during compare mode: %v", a.cmd.Identifiers.ExecutionID, f, err)
-		}
-	}
-	for _, f := range a.cmd.OutputDirs {
-		path := filepath.Join(a.cmd.ExecRoot, a.cmd.WorkingDir, f)
-		// Don't recreate the directory if the directory does not exist
-		info, err := os.Stat(path)
-		if err != nil {
-			log.Warningf("%v: Failed to stat directory %v during compare mode: %v", a.cmd.Identifiers.ExecutionID, path, err)
-			continue
-		}
-		if err := os.RemoveAll(path); err != nil {
-			log.Warningf("%v: Failed to remove directory %v during compare mode: %v", a.cmd.Identifiers.ExecutionID, path, err)
-			continue
-		}
-		// Recreate the directory in case it is needed for local execution.
-		if err := os.MkdirAll(path, info.Mode()); err != nil {
-			log.Warningf("%v: Cannot recreate directory %v during compare mode: %v", a.cmd.Identifiers.ExecutionID, f, err)
-		}
-	}
-}
-
 // inOutFiles calculates the list of files contained both in the input and output lists lazily.
 // Inputs are expected to be relative to the exec root and Outputs are expected to be relative
 // to the working directory. The resulting list of paths are absolute paths.
@@ -965,6 +1019,8 @@ func (a *action) duplicate(n int) []*action {
 		newAction.rOpt = &trOpt
 		newAction.lOpt = &tlOpt
 		newAction.oe = outerr.NewRecordingOutErr()
+		newAction.stream = nil
+		newAction.progress = nil
 		res = append(res, newAction)
 	}
 	return res
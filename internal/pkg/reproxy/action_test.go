@@ -16,14 +16,18 @@ package reproxy
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 	"runtime"
 	"testing"
 	"time"
 
+	lpb "github.com/bazelbuild/reclient/api/log"
 	ppb "github.com/bazelbuild/reclient/api/proxy"
+	"github.com/bazelbuild/reclient/internal/pkg/actioncache"
 	"github.com/bazelbuild/reclient/internal/pkg/execroot"
 	"github.com/bazelbuild/reclient/internal/pkg/localresources"
+	"github.com/bazelbuild/reclient/internal/pkg/logger"
 	"github.com/bazelbuild/reclient/internal/pkg/subprocess"
 	"github.com/bazelbuild/reclient/pkg/inputprocessor"
 	cpb "github.com/bazelbuild/remote-apis-sdks/go/api/command"
@@ -31,6 +35,12 @@ import (
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/digest"
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/fakes"
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/filemetadata"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/outerr"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/uploadinfo"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	repb "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
 )
 
 // TestDownloadRegex verifies that --download_regex controls which files to download from output list.
@@ -273,3 +283,132 @@ func TestDownloadRegex(t *testing.T) {
 		}
 	}
 }
+
+// TestMissingOutputs verifies that outputs of remote results are only looked up in the action
+// result, not in the exec root.
+func TestMissingOutputs(t *testing.T) {
+	execRoot := t.TempDir()
+	if err := os.WriteFile(filepath.Join(execRoot, "local.o"), nil, 0644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	a := &action{
+		cmd: &command.Command{
+			ExecRoot:    execRoot,
+			OutputFiles: []string{"local.o", "remote.o", "missing.o"},
+			OutputDirs:  []string{"remote_dir", "missing_dir"},
+		},
+		rec: &logger.LogRecord{LogRecord: &lpb.LogRecord{
+			RemoteMetadata: &lpb.RemoteMetadata{
+				OutputFileDigests:      map[string]string{"remote.o": "abc/3"},
+				OutputDirectoryDigests: map[string]string{"remote_dir": "def/3"},
+			},
+		}},
+	}
+	if diff := cmp.Diff([]string{"local.o", "missing.o", "missing_dir"}, a.missingOutputs(false)); diff != "" {
+		t.Errorf("missingOutputs(false) returned diff: (-want +got)\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"missing.o", "missing_dir", "remote.o", "remote_dir"}, a.missingOutputs(true)); diff != "" {
+		t.Errorf("missingOutputs(true) returned diff: (-want +got)\n%s", diff)
+	}
+}
+
+// TestInputManifest verifies that the digests of input files, including those of input
+// directories and virtual inputs, are collected.
+func TestInputManifest(t *testing.T) {
+	execRoot := t.TempDir()
+	files := map[string]string{
+		"foo.cc":         "foo",
+		"inc/a.h":        "a",
+		"inc/sub/b.h":    "b",
+		"inc/excluded":   "excluded",
+		"not_an_input.h": "c",
+	}
+	for f, c := range files {
+		p := filepath.Join(execRoot, f)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("MkdirAll(%v) failed: %v", filepath.Dir(p), err)
+		}
+		if err := os.WriteFile(p, []byte(c), 0644); err != nil {
+			t.Fatalf("WriteFile(%v) failed: %v", p, err)
+		}
+	}
+	a := &action{
+		cmd: &command.Command{
+			Identifiers: &command.Identifiers{ExecutionID: "exec-id"},
+			Args:        []string{"clang", "-c", "foo.cc"},
+			ExecRoot:    execRoot,
+			InputSpec: &command.InputSpec{
+				Inputs:               []string{"foo.cc", "inc", "missing.h"},
+				VirtualInputs:        []*command.VirtualInput{{Path: "gen/v.h", Contents: []byte("v")}, {Path: "empty", IsEmptyDirectory: true}},
+				InputExclusions:      []*command.InputExclusion{{Regex: "excluded$", Type: command.FileInputType}},
+				EnvironmentVariables: map[string]string{"B": "2", "A": "1"},
+			},
+		},
+		fmc: filemetadata.NewSingleFlightCache(),
+	}
+	got := a.inputManifest()
+	want := &lpb.InputManifest{
+		FileDigests: map[string]string{
+			"foo.cc":      digest.NewFromBlob([]byte("foo")).String(),
+			"inc/a.h":     digest.NewFromBlob([]byte("a")).String(),
+			"inc/sub/b.h": digest.NewFromBlob([]byte("b")).String(),
+			"gen/v.h":     digest.NewFromBlob([]byte("v")).String(),
+		},
+		ArgsDigest:        digest.NewFromBlob([]byte("clang\x00-c\x00foo.cc")).String(),
+		EnvironmentDigest: digest.NewFromBlob([]byte("A=1\x00B=2")).String(),
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("inputManifest() returned diff: (-want +got)\n%s", diff)
+	}
+}
+
+// TestLocalCachedResultValidatedBeforeMaterializing verifies that results in the local action
+// cache whose deps file does not match the inputs do not overwrite the outputs in the exec root.
+func TestLocalCachedResultValidatedBeforeMaterializing(t *testing.T) {
+	root := t.TempDir()
+	execroot.AddFilesWithContent(t, root, map[string][]byte{
+		"foo.c": []byte("SOURCE"),
+		"foo.o": []byte("old"),
+	})
+	lc, err := actioncache.New(t.TempDir(), 1<<20)
+	if err != nil {
+		t.Fatalf("actioncache.New() failed: %v", err)
+	}
+	a := &action{
+		cmd: &command.Command{
+			Identifiers: &command.Identifiers{ExecutionID: "1"},
+			ExecRoot:    root,
+		},
+		dFile:    "foo.d",
+		depsFile: "foo.d.deps",
+		fmc:      filemetadata.NewSingleFlightCache(),
+		rec:      &logger.LogRecord{LogRecord: &lpb.LogRecord{LocalMetadata: &lpb.LocalMetadata{}}},
+		oe:       outerr.NewRecordingOutErr(),
+	}
+	for _, tc := range []struct {
+		name    string
+		dep     []byte
+		wantHit bool
+		wantOut string
+	}{
+		{name: "stale", dep: []byte("OTHER"), wantHit: false, wantOut: "old"},
+		{name: "valid", dep: []byte("SOURCE"), wantHit: true, wantOut: "new"},
+	} {
+		out := uploadinfo.EntryFromBlob([]byte("new"))
+		deps := uploadinfo.EntryFromBlob([]byte("foo.c:" + digest.NewFromBlob(tc.dep).String() + "\n"))
+		ar := &repb.ActionResult{OutputFiles: []*repb.OutputFile{
+			{Path: "foo.o", Digest: out.Digest.ToProto()},
+			{Path: "foo.d.deps", Digest: deps.Digest.ToProto()},
+		}}
+		dg := digest.NewFromBlob([]byte(tc.name))
+		if err := lc.Put(dg, ar, map[digest.Digest]*uploadinfo.Entry{out.Digest: out, deps.Digest: deps}); err != nil {
+			t.Fatalf("%v: Put() failed: %v", tc.name, err)
+		}
+		if got := a.getLocalCachedResult(lc, dg); got != tc.wantHit {
+			t.Errorf("%v: getLocalCachedResult() = %v, want %v", tc.name, got, tc.wantHit)
+		}
+		if got, err := os.ReadFile(filepath.Join(root, "foo.o")); err != nil || string(got) != tc.wantOut {
+			t.Errorf("%v: ReadFile(foo.o) = %q, %v, want %q", tc.name, got, err, tc.wantOut)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reproxy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	log "github.com/golang/glog"
+)
+
+type breakerState int
+
+const (
+	// breakerClosed lets all actions execute remotely.
+	breakerClosed breakerState = iota
+	// breakerOpen makes actions that can run locally skip remote execution.
+	breakerOpen
+	// breakerHalfOpen lets a limited number of probe actions execute remotely to decide whether
+	// remote execution has recovered.
+	breakerHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerClosed:
+		return "closed"
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// remoteSample is the outcome of a remote execution.
+type remoteSample struct {
+	t         time.Time
+	unhealthy bool
+}
+
+// circuitBreaker tracks the outcomes of remote executions over a sliding window, and trips once
+// the ratio of unhealthy ones, i.e. remote errors, timeouts and executions slower than the slow
+// threshold, reaches the error ratio. While it is open, actions that can run locally skip remote
+// execution. After the open duration, it lets probe actions execute remotely: it closes once the
+// configured number of probes succeed, and opens again as soon as one fails.
+type circuitBreaker struct {
+	errorRatio    float64
+	minActions    int64
+	window        time.Duration
+	slowThreshold time.Duration
+	openDuration  time.Duration
+	probes        int
+	// now is overridden in tests.
+	now func() time.Time
+
+	mu       sync.Mutex
+	state    breakerState
+	samples  []remoteSample
+	openedAt time.Time
+	// period identifies the current half-open period, whose probes are counted by inFlightProbes
+	// and succeededProbes.
+	period          int
+	inFlightProbes  int
+	succeededProbes int
+}
+
+// newCircuitBreaker returns a circuit breaker for the options of the server, or nil if it is
+// disabled.
+func newCircuitBreaker(s *Server) *circuitBreaker {
+	if s.BreakerErrorRatio <= 0 {
+		return nil
+	}
+	probes := s.BreakerProbes
+	if probes < 1 {
+		probes = 1
+	}
+	return &circuitBreaker{
+		errorRatio:    s.BreakerErrorRatio,
+		minActions:    s.BreakerMinActions,
+		window:        s.BreakerWindow,
+		slowThreshold: s.BreakerSlowThreshold,
+		openDuration:  s.BreakerOpenDuration,
+		probes:        probes,
+		now:           time.Now,
+	}
+}
+
+// acquire returns whether an action may execute remotely and, if so, a function to report the
+// result of the remote execution with. The function must be called exactly once, with a nil result
+// if remote execution did not happen. A nil circuit breaker always allows remote execution.
+func (cb *circuitBreaker) acquire() (report func(res *command.Result), ok bool) {
+	if cb == nil {
+		return func(*command.Result) {}, true
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	start := cb.now()
+	probe := -1
+	switch cb.state {
+	case breakerOpen:
+		if start.Sub(cb.openedAt) < cb.openDuration {
+			return nil, false
+		}
+		cb.setState(breakerHalfOpen)
+		cb.period++
+		cb.inFlightProbes, cb.succeededProbes = 0, 0
+		fallthrough
+	case breakerHalfOpen:
+		if cb.inFlightProbes+cb.succeededProbes >= cb.probes {
+			return nil, false
+		}
+		cb.inFlightProbes++
+		probe = cb.period
+	}
+	var once sync.Once
+	return func(res *command.Result) {
+		once.Do(func() { cb.record(res, start, probe) })
+	}, true
+}
+
+// record records the result of a remote execution started at start. probe is the half-open period
+// the execution was a probe of, or -1 if it was not a probe.
+func (cb *circuitBreaker) record(res *command.Result, start time.Time, probe int) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	now := cb.now()
+	unhealthy, known := cb.unhealthy(res, now.Sub(start))
+	if probe >= 0 {
+		// Probes of an earlier half-open period are ignored.
+		if cb.state != breakerHalfOpen || probe != cb.period {
+			return
+		}
+		cb.inFlightProbes--
+		switch {
+		case !known:
+		case unhealthy:
+			cb.open(now)
+		default:
+			cb.succeededProbes++
+			if cb.succeededProbes >= cb.probes {
+				cb.samples = nil
+				cb.setState(breakerClosed)
+			}
+		}
+		return
+	}
+	// Results of remote executions started before the breaker tripped do not tell anything new.
+	if !known || cb.state != breakerClosed {
+		return
+	}
+	cb.samples = append(cb.samples, remoteSample{t: now, unhealthy: unhealthy})
+	if cb.window > 0 {
+		i := 0
+		for i < len(cb.samples) && now.Sub(cb.samples[i].t) > cb.window {
+			i++
+		}
+		cb.samples = cb.samples[i:]
+	}
+	if int64(len(cb.samples)) < cb.minActions {
+		return
+	}
+	n := 0
+	for _, s := range cb.samples {
+		if s.unhealthy {
+			n++
+		}
+	}
+	if float64(n)/float64(len(cb.samples)) >= cb.errorRatio {
+		log.Warningf("%v of the last %v remote executions failed or were too slow, running actions locally for %v", n, len(cb.samples), cb.openDuration)
+		cb.open(now)
+	}
+}
+
+// unhealthy returns whether a remote execution result with the given latency indicates that remote
+// execution is unhealthy, and whether it tells anything about its health at all. Failures of the
+// command itself, local errors, such as input processing failures, and cancellations, e.g. of
+// interrupted builds, do not. As in classifyRemoteError, remote errors without a gRPC status are
+// not attributed to remote execution.
+func (cb *circuitBreaker) unhealthy(res *command.Result, latency time.Duration) (unhealthy, known bool) {
+	if res == nil {
+		return false, false
+	}
+	switch res.Status {
+	case command.RemoteErrorResultStatus:
+		if errors.Is(res.Err, context.Canceled) {
+			return false, false
+		}
+		st, ok := status.FromError(res.Err)
+		if !ok || st.Code() == codes.Canceled {
+			return false, false
+		}
+		return true, true
+	case command.TimeoutResultStatus:
+		return true, true
+	case command.SuccessResultStatus, command.CacheHitResultStatus, command.NonZeroExitResultStatus:
+		return cb.slowThreshold > 0 && latency > cb.slowThreshold, true
+	}
+	return false, false
+}
+
+func (cb *circuitBreaker) open(now time.Time) {
+	cb.openedAt = now
+	cb.setState(breakerOpen)
+}
+
+func (cb *circuitBreaker) setState(s breakerState) {
+	if cb.state != s {
+		log.Infof("Remote execution circuit breaker is now %v", s)
+	}
+	cb.state = s
+}
@@ -0,0 +1,196 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	remoteErr  = &command.Result{Status: command.RemoteErrorResultStatus, Err: status.Error(codes.Unavailable, "unavailable")}
+	remoteOk   = &command.Result{Status: command.SuccessResultStatus}
+	localErr   = command.NewLocalErrorResult(errors.New("input processing failed"))
+	nonZeroErr = &command.Result{Status: command.NonZeroExitResultStatus, ExitCode: 1}
+)
+
+// fakeClock is a clock advanced manually by tests.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func newTestBreaker(t *testing.T, clk *fakeClock) *circuitBreaker {
+	t.Helper()
+	cb := newCircuitBreaker(&Server{
+		BreakerErrorRatio:    0.5,
+		BreakerMinActions:    4,
+		BreakerWindow:        time.Minute,
+		BreakerSlowThreshold: 10 * time.Second,
+		BreakerOpenDuration:  30 * time.Second,
+		BreakerProbes:        2,
+	})
+	cb.now = clk.now
+	return cb
+}
+
+// run executes an action remotely through the breaker, and returns whether it was allowed to.
+func run(cb *circuitBreaker, clk *fakeClock, res *command.Result, latency time.Duration) bool {
+	report, ok := cb.acquire()
+	if !ok {
+		return false
+	}
+	clk.t = clk.t.Add(latency)
+	report(res)
+	return true
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	cb := newCircuitBreaker(&Server{})
+	if cb != nil {
+		t.Fatalf("newCircuitBreaker() = %v, want nil when the error ratio is 0", cb)
+	}
+	for i := 0; i < 10; i++ {
+		report, ok := cb.acquire()
+		if !ok {
+			t.Fatalf("acquire() on a disabled breaker = false, want true")
+		}
+		report(remoteErr)
+	}
+}
+
+func TestCircuitBreakerTrips(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []*command.Result
+		latency time.Duration
+		want    bool
+	}{
+		{
+			name:    "RemoteErrors",
+			results: []*command.Result{remoteOk, remoteErr, remoteOk, remoteErr},
+			want:    true,
+		},
+		{
+			name:    "TooFewActions",
+			results: []*command.Result{remoteErr, remoteErr, remoteErr},
+		},
+		{
+			name:    "BelowRatio",
+			results: []*command.Result{remoteOk, remoteOk, remoteOk, remoteErr},
+		},
+		{
+			name:    "Slow",
+			results: []*command.Result{remoteOk, remoteOk, remoteOk, remoteOk},
+			latency: 11 * time.Second,
+			want:    true,
+		},
+		{
+			name: "CancellationsIgnored",
+			results: []*command.Result{
+				{Status: command.RemoteErrorResultStatus, Err: context.Canceled},
+				{Status: command.RemoteErrorResultStatus, Err: fmt.Errorf("execution failed: %w", context.Canceled)},
+				{Status: command.RemoteErrorResultStatus, Err: status.Error(codes.Canceled, "canceled")},
+				{Status: command.RemoteErrorResultStatus, Err: errors.New("no gRPC status")},
+				remoteErr,
+			},
+		},
+		{
+			name:    "CommandAndLocalErrorsIgnored",
+			results: []*command.Result{nonZeroErr, localErr, localErr, nonZeroErr, localErr, nil, remoteErr},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			clk := &fakeClock{t: time.Unix(0, 0)}
+			cb := newTestBreaker(t, clk)
+			for _, res := range tc.results {
+				if !run(cb, clk, res, tc.latency) {
+					t.Fatalf("acquire() = false before all results were reported")
+				}
+			}
+			if _, ok := cb.acquire(); ok == tc.want {
+				t.Errorf("acquire() = %v, want %v", ok, !tc.want)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerWindow(t *testing.T) {
+	clk := &fakeClock{t: time.Unix(0, 0)}
+	cb := newTestBreaker(t, clk)
+	run(cb, clk, remoteErr, 0)
+	run(cb, clk, remoteErr, 0)
+	clk.t = clk.t.Add(2 * time.Minute)
+	// The earlier errors are out of the window, so only one of the four recent executions failed.
+	for _, res := range []*command.Result{remoteOk, remoteOk, remoteOk, remoteErr} {
+		run(cb, clk, res, 0)
+	}
+	if _, ok := cb.acquire(); !ok {
+		t.Errorf("acquire() = false, want true as only recent executions are considered")
+	}
+}
+
+func TestCircuitBreakerProbes(t *testing.T) {
+	clk := &fakeClock{t: time.Unix(0, 0)}
+	cb := newTestBreaker(t, clk)
+	for i := 0; i < 4; i++ {
+		run(cb, clk, remoteErr, 0)
+	}
+	if _, ok := cb.acquire(); ok {
+		t.Fatalf("acquire() = true after remote errors, want false")
+	}
+
+	// After the open duration, a failed probe opens the breaker again.
+	clk.t = clk.t.Add(30 * time.Second)
+	if !run(cb, clk, remoteErr, 0) {
+		t.Fatalf("acquire() = false after the open duration, want a probe")
+	}
+	if _, ok := cb.acquire(); ok {
+		t.Fatalf("acquire() = true after a failed probe, want false")
+	}
+
+	// Only as many probes as needed to close the breaker run at once.
+	clk.t = clk.t.Add(30 * time.Second)
+	report1, ok1 := cb.acquire()
+	report2, ok2 := cb.acquire()
+	if !ok1 || !ok2 {
+		t.Fatalf("acquire() = %v, %v for the first two probes, want true, true", ok1, ok2)
+	}
+	if _, ok := cb.acquire(); ok {
+		t.Fatalf("acquire() = true for a third concurrent probe, want false")
+	}
+	// Probes that tell nothing about the health of remote execution let another probe run.
+	report1(localErr)
+	report3, ok := cb.acquire()
+	if !ok {
+		t.Fatalf("acquire() = false after a probe failed locally, want a probe")
+	}
+	report2(remoteOk)
+	report3(remoteOk)
+	for i := 0; i < 10; i++ {
+		if !run(cb, clk, remoteOk, 0) {
+			t.Fatalf("acquire() = false after successful probes, want true")
+		}
+	}
+}
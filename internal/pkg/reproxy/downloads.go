@@ -0,0 +1,88 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reproxy
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bazelbuild/reclient/internal/pkg/diskcas"
+	"github.com/bazelbuild/reclient/internal/pkg/event"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/client"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/filemetadata"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/rexec"
+
+	ppb "github.com/bazelbuild/reclient/api/proxy"
+	log "github.com/golang/glog"
+)
+
+// downloadOutputs downloads the given outputs of a remote action to outDir. If the disk CAS is
+// enabled, outputs whose blobs it holds are materialized from it, and only the remaining outputs
+// are downloaded from the remote CAS and then added to the disk CAS.
+func (a *action) downloadOutputs(ec *rexec.Context, outs map[string]*client.TreeOutput, outDir string) {
+	a.progress.setPhase(ppb.ActionPhase_DOWNLOAD)
+	if a.diskCAS == nil {
+		ec.DownloadSpecifiedOutputs(outs, outDir)
+		return
+	}
+	wd := filepath.Join(outDir, a.cmd.WorkingDir)
+	from := time.Now()
+	remaining := make(map[string]*client.TreeOutput)
+	var materialized int64
+	for p, out := range outs {
+		if out.IsEmptyDirectory || out.SymlinkTarget != "" {
+			remaining[p] = out
+			continue
+		}
+		path := filepath.Join(wd, out.Path)
+		if err := a.diskCAS.Materialize(out.Digest, out.IsExecutable, path); err != nil {
+			if !errors.Is(err, diskcas.ErrNotFound) {
+				log.Warningf("%v: Failed to materialize %v from the disk CAS: %v", a.cmd.Identifiers.ExecutionID, out.Path, err)
+			}
+			// Outputs might be hard links to blobs of the disk CAS, which must not be overwritten in
+			// place by the download.
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				log.Warningf("%v: Failed to remove %v before download: %v", a.cmd.Identifiers.ExecutionID, path, err)
+			}
+			remaining[p] = out
+			continue
+		}
+		materialized += out.Digest.Size
+		if a.fmc != nil {
+			if err := a.fmc.Update(path, &filemetadata.Metadata{Digest: out.Digest, IsExecutable: out.IsExecutable}); err != nil {
+				log.Warningf("%v: Failed to update file metadata cache for %v: %v", a.cmd.Identifiers.ExecutionID, path, err)
+			}
+		}
+	}
+	if materialized > 0 {
+		a.rec.RecordEventTime(event.DiskCASMaterialize, from)
+		log.V(2).Infof("%v: Materialized %d of %d outputs (%d bytes) from the disk CAS", a.cmd.Identifiers.ExecutionID, len(outs)-len(remaining), len(outs), materialized)
+	}
+	ec.DownloadSpecifiedOutputs(remaining, outDir)
+	if !ec.Result.IsOk() {
+		return
+	}
+	for _, out := range remaining {
+		if out.IsEmptyDirectory || out.SymlinkTarget != "" {
+			continue
+		}
+		if err := a.diskCAS.Add(out.Digest, out.IsExecutable, filepath.Join(wd, out.Path)); err != nil {
+			log.Warningf("%v: Failed to add %v to the disk CAS: %v", a.cmd.Identifiers.ExecutionID, out.Path, err)
+		}
+	}
+}
@@ -17,14 +17,20 @@ package reproxy
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
 	"sync"
 	"time"
 
+	"github.com/bazelbuild/reclient/internal/pkg/event"
 	"github.com/bazelbuild/reclient/internal/pkg/labels"
 
+	cpb "github.com/bazelbuild/remote-apis-sdks/go/api/command"
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
+	"google.golang.org/protobuf/proto"
 
+	ppb "github.com/bazelbuild/reclient/api/proxy"
 	log "github.com/golang/glog"
 )
 
@@ -32,13 +38,41 @@ const (
 	datasetSize             = 500
 	defaultMinSize          = 50
 	downloadResultMetricKey = "DownloadResults"
+	forecastFileName        = "reproxy.forecast"
+	// cacheHitProbabilityCutoff is the probability of cache hits above which racing does not start
+	// local execution of actions that hit the cache, as the outputs of these actions are almost
+	// always downloaded anyway.
+	cacheHitProbabilityCutoff = 0.95
+	// localPercentileCutoff is the percentile of local execution times that must be below the
+	// expected remote execution time for racing to start local execution right away.
+	localPercentileCutoff = 90
+)
+
+// racingMode is how racing runs an action, based on the forecast of its behavior.
+type racingMode int
+
+const (
+	// raceDefault starts local execution after the remote cache lookup, right away on cache misses
+	// and after a holdoff on cache hits.
+	raceDefault racingMode = iota
+	// raceLocalFirst starts local execution right away, without waiting for the remote cache
+	// lookup, for actions that are consistently faster locally.
+	raceLocalFirst
+	// raceRemoteOnCacheHit only downloads the outputs of cache hits without starting local
+	// execution, for actions that are almost always cache hits.
+	raceRemoteOnCacheHit
 )
 
 // Forecast is responsible for forecasting specific parameters of an action based on historical
-// data.
+// data. Data is kept per label key.
 type Forecast struct {
 	downloadLatencies sync.Map
-	minSizeForStats   int
+	// remoteDurations are the times to upload the inputs of and execute cache misses remotely.
+	remoteDurations sync.Map
+	localDurations  sync.Map
+	// cacheHits are the results of remote cache lookups, 1 for hits and 0 for misses.
+	cacheHits       sync.Map
+	minSizeForStats int
 }
 
 // Run starts the background computation of parameter forecasts. Should be run in a goroutine.
@@ -64,50 +98,259 @@ func (f *Forecast) Run(ctx context.Context) {
 				}
 				return true
 			})
+			for _, m := range []*sync.Map{&f.remoteDurations, &f.localDurations, &f.cacheHits} {
+				m.Range(func(k, v interface{}) bool {
+					if ds, ok := v.(*dataset); ok {
+						ds.sort()
+					}
+					return true
+				})
+			}
 			counter++
 		}
 	}
 }
 
-// RecordSample stores a sample from an action to be used to forecast future behavior.
+// RecordSample stores a sample from an action to be used to forecast future behavior. Remote
+// execution samples are recorded with RecordRemoteSample when the remote result is known, since
+// the remote execution of racing actions may finish after the action.
 func (f *Forecast) RecordSample(a *action) {
-	if a.rec == nil || a.rec.RemoteMetadata == nil {
+	if f == nil || a.rec == nil {
+		return
+	}
+	l := labels.ToKey(a.lbls)
+	if lm := a.rec.LocalMetadata; lm.GetExecutedLocally() && lm.GetResult().GetStatus() == cpb.CommandResultStatus_SUCCESS {
+		if v, ok := lm.EventTimes[event.LocalCommandExecution]; ok {
+			f.insert(&f.localDurations, l, interval(v))
+		}
+	}
+	if a.rec.RemoteMetadata == nil {
 		return
 	}
 	v, ok := a.rec.RemoteMetadata.EventTimes[downloadResultMetricKey]
 	if !ok {
 		return
 	}
+	f.insert(&f.downloadLatencies, l, interval(v))
+}
+
+// RecordRemoteSample stores the result of the remote cache lookup or execution of an action with
+// the given metadata. Results that are neither cache hits nor successful executions are ignored.
+func (f *Forecast) RecordRemoteSample(a *action, res *command.Result, md *command.Metadata) {
+	if f == nil || res == nil || md == nil {
+		return
+	}
 	l := labels.ToKey(a.lbls)
-	if f.minSizeForStats == 0 {
-		f.minSizeForStats = defaultMinSize
+	switch {
+	case res.Status == command.CacheHitResultStatus:
+		f.insert(&f.cacheHits, l, 1)
+	case res.Status == command.SuccessResultStatus || res.Status == command.NonZeroExitResultStatus:
+		ex, ok := md.EventTimes[command.EventExecuteRemotely]
+		if !ok || ex.To.IsZero() {
+			return
+		}
+		f.insert(&f.cacheHits, l, 0)
+		d := ex.To.Sub(ex.From)
+		if up, ok := md.EventTimes[command.EventUploadInputs]; ok && !up.To.IsZero() {
+			d += up.To.Sub(up.From)
+		}
+		f.insert(&f.remoteDurations, l, int(d.Milliseconds()))
+	}
+}
+
+func interval(v *cpb.TimeInterval) int {
+	ti := command.TimeIntervalFromProto(v)
+	return int(ti.To.Sub(ti.From).Milliseconds())
+}
+
+func (f *Forecast) insert(m *sync.Map, l string, v int) {
+	minSize := f.minSizeForStats
+	if minSize == 0 {
+		minSize = defaultMinSize
 	}
-	d, _ := f.downloadLatencies.LoadOrStore(l, &dataset{dataPoints: make([]int, datasetSize), minSizeForStats: f.minSizeForStats})
+	d, _ := m.LoadOrStore(l, &dataset{dataPoints: make([]int, datasetSize), minSizeForStats: minSize})
 	ds, ok := d.(*dataset)
 	if !ok {
-		log.Warningf("Unexpected type found in the downloadLatencies map")
+		log.Warningf("Unexpected type found in the forecast map")
 		return
 	}
-	ti := command.TimeIntervalFromProto(v)
-	ds.insert(int(ti.To.Sub(ti.From).Milliseconds()))
+	ds.insert(v)
 }
 
-// PercentileDownloadLatency returns the expected pth percentile download latency of the given
-// action.
-func (f *Forecast) PercentileDownloadLatency(a *action, p int) (time.Duration, error) {
-	l := labels.ToKey(a.lbls)
-	d, loaded := f.downloadLatencies.Load(l)
+func load(m *sync.Map, l string) (*dataset, error) {
+	d, loaded := m.Load(l)
 	if !loaded {
-		return 0, fmt.Errorf("couldn't find a dataset for labels: %v", a.lbls)
+		return nil, fmt.Errorf("couldn't find a dataset for labels: %v", l)
 	}
 	ds, ok := d.(*dataset)
 	if !ok {
-		return 0, fmt.Errorf("unexpected type found in the downloadLatencies map")
+		return nil, fmt.Errorf("unexpected type found in the forecast map")
+	}
+	return ds, nil
+}
+
+// PercentileDownloadLatency returns the expected pth percentile download latency of the given
+// action.
+func (f *Forecast) PercentileDownloadLatency(a *action, p int) (time.Duration, error) {
+	ds, err := load(&f.downloadLatencies, labels.ToKey(a.lbls))
+	if err != nil {
+		return 0, err
 	}
 	dlp, err := ds.percentile(p)
 	return time.Duration(dlp) * time.Millisecond, err
 }
 
+// racingMode returns how racing should run the given action. Actions are raced the default way
+// until enough samples of their labels were recorded.
+func (f *Forecast) racingMode(a *action) racingMode {
+	if f == nil {
+		return raceDefault
+	}
+	l := labels.ToKey(a.lbls)
+	hits, err := load(&f.cacheHits, l)
+	if err != nil {
+		return raceDefault
+	}
+	pHit, err := hits.mean()
+	if err != nil {
+		return raceDefault
+	}
+	if local, err := load(&f.localDurations, l); err == nil {
+		if lp, err := local.percentile(localPercentileCutoff); err == nil {
+			if remote, ok := f.expectedRemoteDuration(l, pHit); ok && lp < remote {
+				return raceLocalFirst
+			}
+		}
+	}
+	if pHit >= cacheHitProbabilityCutoff {
+		return raceRemoteOnCacheHit
+	}
+	return raceDefault
+}
+
+// expectedRemoteDuration returns the expected time in milliseconds to get the outputs of an action
+// with the given labels and cache hit probability remotely.
+func (f *Forecast) expectedRemoteDuration(l string, pHit float64) (int, bool) {
+	median := func(m *sync.Map) (int, bool) {
+		ds, err := load(m, l)
+		if err != nil {
+			return 0, false
+		}
+		v, err := ds.percentile(50)
+		return v, err == nil
+	}
+	dl, ok := median(&f.downloadLatencies)
+	if !ok {
+		return 0, false
+	}
+	if pHit >= 1 {
+		return dl, true
+	}
+	ex, ok := median(&f.remoteDurations)
+	if !ok {
+		return 0, false
+	}
+	return dl + int((1-pHit)*float64(ex)), true
+}
+
+// LoadFromDir loads the samples persisted by a previous reproxy from the given directory.
+func (f *Forecast) LoadFromDir(dir string) {
+	path := filepath.Join(dir, forecastFileName)
+	in, err := os.ReadFile(path)
+	if err != nil {
+		log.Infof("No forecast data found at %v", path)
+		return
+	}
+	db := &ppb.ForecastDatabase{}
+	if err := proto.Unmarshal(in, db); err != nil {
+		log.Errorf("Failed to parse forecast data: %v", err)
+		return
+	}
+	for l, s := range db.GetSamples() {
+		for _, v := range s.GetDownloadLatencies() {
+			f.insert(&f.downloadLatencies, l, int(v))
+		}
+		for _, v := range s.GetRemoteDurations() {
+			f.insert(&f.remoteDurations, l, int(v))
+		}
+		for _, v := range s.GetLocalDurations() {
+			f.insert(&f.localDurations, l, int(v))
+		}
+		for _, hit := range s.GetCacheHits() {
+			v := 0
+			if hit {
+				v = 1
+			}
+			f.insert(&f.cacheHits, l, v)
+		}
+	}
+	// Make the forecasts available before Run computes them.
+	for _, m := range []*sync.Map{&f.downloadLatencies, &f.remoteDurations, &f.localDurations, &f.cacheHits} {
+		m.Range(func(k, v interface{}) bool {
+			if ds, ok := v.(*dataset); ok {
+				ds.sort()
+			}
+			return true
+		})
+	}
+	log.Infof("Loaded forecast data for %v label keys from %v", len(db.GetSamples()), path)
+}
+
+// WriteToDisk persists the samples recorded so far to the given directory.
+func (f *Forecast) WriteToDisk(dir string) {
+	db := &ppb.ForecastDatabase{Samples: make(map[string]*ppb.ForecastSamples)}
+	samples := func(l interface{}) *ppb.ForecastSamples {
+		k, _ := l.(string)
+		s, ok := db.Samples[k]
+		if !ok {
+			s = &ppb.ForecastSamples{}
+			db.Samples[k] = s
+		}
+		return s
+	}
+	values := func(v interface{}) []int64 {
+		ds, ok := v.(*dataset)
+		if !ok {
+			return nil
+		}
+		var vs []int64
+		for _, dp := range ds.values() {
+			vs = append(vs, int64(dp))
+		}
+		return vs
+	}
+	f.downloadLatencies.Range(func(k, v interface{}) bool {
+		samples(k).DownloadLatencies = values(v)
+		return true
+	})
+	f.remoteDurations.Range(func(k, v interface{}) bool {
+		samples(k).RemoteDurations = values(v)
+		return true
+	})
+	f.localDurations.Range(func(k, v interface{}) bool {
+		samples(k).LocalDurations = values(v)
+		return true
+	})
+	f.cacheHits.Range(func(k, v interface{}) bool {
+		s := samples(k)
+		for _, dp := range values(v) {
+			s.CacheHits = append(s.CacheHits, dp == 1)
+		}
+		return true
+	})
+	out, err := proto.Marshal(db)
+	if err != nil {
+		log.Errorf("Failed to marshal forecast data: %v", err)
+		return
+	}
+	path := filepath.Join(dir, forecastFileName)
+	if err := os.WriteFile(path, out, 0644); err != nil {
+		log.Errorf("Failed to write forecast data to %v: %v", path, err)
+		return
+	}
+	log.Infof("Wrote forecast data to %v", path)
+}
+
 type dataset struct {
 	dataPoints      []int
 	dMu             sync.RWMutex
@@ -153,3 +396,32 @@ func (d *dataset) percentile(p int) (int, error) {
 	}
 	return d.sortedData[len(d.sortedData)*p/100], nil
 }
+
+// mean returns the mean of the data points as of the last sort.
+func (d *dataset) mean() (float64, error) {
+	d.sMu.RLock()
+	defer d.sMu.RUnlock()
+	if len(d.sortedData) == 0 {
+		return 0, fmt.Errorf("no computation ran on dataset yet")
+	}
+	sum := 0
+	for _, v := range d.sortedData {
+		sum += v
+	}
+	return float64(sum) / float64(len(d.sortedData)), nil
+}
+
+// values returns the data points, oldest first.
+func (d *dataset) values() []int {
+	d.dMu.RLock()
+	defer d.dMu.RUnlock()
+	vs := make([]int, 0, d.currSize)
+	start := 0
+	if d.currSize == len(d.dataPoints) {
+		start = d.head
+	}
+	for i := 0; i < d.currSize; i++ {
+		vs = append(vs, d.dataPoints[(start+i)%len(d.dataPoints)])
+	}
+	return vs
+}
@@ -16,13 +16,17 @@ package reproxy
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
 	lpb "github.com/bazelbuild/reclient/api/log"
+	"github.com/bazelbuild/reclient/internal/pkg/labels"
 	"github.com/bazelbuild/reclient/internal/pkg/logger"
 
 	cpb "github.com/bazelbuild/remote-apis-sdks/go/api/command"
+	"github.com/google/go-cmp/cmp"
 
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
 )
@@ -162,3 +166,155 @@ func TestMultipleLabels(t *testing.T) {
 		t.Errorf("PercentileDownloadLatency(%v,50) = %v, want 8ms", lbls2, m)
 	}
 }
+
+// forecastWith returns a forecast with the given samples for lbls.
+func forecastWith(lbls map[string]string, downloads, remotes, locals []int, hits []bool) *Forecast {
+	f := &Forecast{minSizeForStats: 1}
+	l := labels.ToKey(lbls)
+	for _, v := range downloads {
+		f.insert(&f.downloadLatencies, l, v)
+	}
+	for _, v := range remotes {
+		f.insert(&f.remoteDurations, l, v)
+	}
+	for _, v := range locals {
+		f.insert(&f.localDurations, l, v)
+	}
+	for _, hit := range hits {
+		v := 0
+		if hit {
+			v = 1
+		}
+		f.insert(&f.cacheHits, l, v)
+	}
+	for _, m := range []*sync.Map{&f.downloadLatencies, &f.remoteDurations, &f.localDurations, &f.cacheHits} {
+		m.Range(func(_, v interface{}) bool {
+			v.(*dataset).sort()
+			return true
+		})
+	}
+	return f
+}
+
+func TestRacingMode(t *testing.T) {
+	t.Parallel()
+	allHits := []bool{true, true, true, true}
+	allMisses := []bool{false, false, false, false}
+	tests := []struct {
+		name string
+		f    *Forecast
+		want racingMode
+	}{
+		{
+			name: "NoForecast",
+			want: raceDefault,
+		},
+		{
+			name: "NoSamples",
+			f:    &Forecast{},
+			want: raceDefault,
+		},
+		{
+			name: "AlmostAlwaysCacheHits",
+			f:    forecastWith(lbls, []int{100, 200}, nil, []int{1000, 2000}, allHits),
+			want: raceRemoteOnCacheHit,
+		},
+		{
+			name: "FasterLocally",
+			f:    forecastWith(lbls, []int{100, 200}, []int{5000, 6000}, []int{1000, 2000}, allMisses),
+			want: raceLocalFirst,
+		},
+		{
+			name: "FasterLocallyThanDownloads",
+			f:    forecastWith(lbls, []int{3000, 4000}, nil, []int{1000, 2000}, allHits),
+			want: raceLocalFirst,
+		},
+		{
+			name: "SometimesSlowerLocally",
+			f:    forecastWith(lbls, []int{100, 200}, []int{5000, 6000}, []int{1000, 2000, 3000, 4000, 5000, 6000, 7000, 8000, 9000, 10000}, allMisses),
+			want: raceDefault,
+		},
+		{
+			name: "NoLocalSamples",
+			f:    forecastWith(lbls, []int{100, 200}, []int{5000, 6000}, nil, []bool{true, false}),
+			want: raceDefault,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tc.f.racingMode(&action{lbls: lbls}); got != tc.want {
+				t.Errorf("racingMode() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRecordRemoteSample(t *testing.T) {
+	t.Parallel()
+	f := &Forecast{minSizeForStats: 1}
+	a := &action{lbls: lbls}
+	ts := time.Now()
+	f.RecordRemoteSample(a, &command.Result{Status: command.CacheHitResultStatus}, &command.Metadata{})
+	f.RecordRemoteSample(a, &command.Result{Status: command.SuccessResultStatus}, &command.Metadata{
+		EventTimes: map[string]*command.TimeInterval{
+			command.EventUploadInputs:    {From: ts, To: ts.Add(time.Second)},
+			command.EventExecuteRemotely: {From: ts.Add(time.Second), To: ts.Add(3 * time.Second)},
+		},
+	})
+	// Remote errors do not tell whether the action is a cache hit.
+	f.RecordRemoteSample(a, command.NewRemoteErrorResult(errors.New("unavailable")), &command.Metadata{})
+	got := map[string][]int{}
+	for name, m := range map[string]*sync.Map{"cacheHits": &f.cacheHits, "remoteDurations": &f.remoteDurations} {
+		ds, err := load(m, labels.ToKey(lbls))
+		if err != nil {
+			t.Fatalf("load(%v) failed: %v", name, err)
+		}
+		got[name] = ds.values()
+	}
+	want := map[string][]int{"cacheHits": {1, 0}, "remoteDurations": {3000}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("RecordRemoteSample() recorded diff in samples: (-want +got)\n%s", diff)
+	}
+}
+
+func TestForecastPersistence(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	var downloads []int
+	for i := 0; i < datasetSize+10; i++ {
+		downloads = append(downloads, i)
+	}
+	f := forecastWith(lbls, downloads, []int{5000}, []int{1000, 2000}, []bool{false, true})
+	f.WriteToDisk(dir)
+
+	loaded := &Forecast{minSizeForStats: 1}
+	loaded.LoadFromDir(dir)
+	for name, m := range map[string][2]*sync.Map{
+		"downloadLatencies": {&f.downloadLatencies, &loaded.downloadLatencies},
+		"remoteDurations":   {&f.remoteDurations, &loaded.remoteDurations},
+		"localDurations":    {&f.localDurations, &loaded.localDurations},
+		"cacheHits":         {&f.cacheHits, &loaded.cacheHits},
+	} {
+		want, err := load(m[0], labels.ToKey(lbls))
+		if err != nil {
+			t.Fatalf("load(%v) failed: %v", name, err)
+		}
+		got, err := load(m[1], labels.ToKey(lbls))
+		if err != nil {
+			t.Fatalf("load(%v) failed after LoadFromDir(): %v", name, err)
+		}
+		if diff := cmp.Diff(want.values(), got.values()); diff != "" {
+			t.Errorf("LoadFromDir() loaded diff in %v: (-want +got)\n%s", name, diff)
+		}
+	}
+	// Forecasts are available right after loading.
+	m, err := loaded.PercentileDownloadLatency(&action{lbls: lbls}, 50)
+	if err != nil {
+		t.Errorf("PercentileDownloadLatency(50) returned error: %v", err)
+	}
+	if want := time.Duration(10+datasetSize/2) * time.Millisecond; m != want {
+		t.Errorf("PercentileDownloadLatency(50) = %v, want %v", m, want)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reproxy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bazelbuild/reclient/internal/pkg/event"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/digest"
+
+	log "github.com/golang/glog"
+)
+
+// inFlightActions keeps track of the action digests that are currently being executed, so that
+// identical actions received concurrently are only executed once. The zero value is ready to use.
+//
+// Identical actions can only reuse each other's results through a cache, so only executions whose
+// results are cached are deduplicated: remote executions that accept cached results, LERC local
+// executions and local executions with the local action cache, unless caching is disabled for the
+// action. Racing executions are not deduplicated, as the results of local executions that win the
+// race are not cached, and neither are local executions without the local action cache.
+type inFlightActions struct {
+	mu      sync.Mutex
+	running map[digest.Digest]chan struct{}
+}
+
+// tryAcquire marks the action digest as in flight. If it is not yet in flight, it returns a
+// function that must be called once the action's result is available in the cache. Otherwise,
+// it returns a channel that is closed once the in flight action finishes.
+func (f *inFlightActions) tryAcquire(dg digest.Digest) (release func(), wait <-chan struct{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if ch, ok := f.running[dg]; ok {
+		return nil, ch
+	}
+	if f.running == nil {
+		f.running = make(map[digest.Digest]chan struct{})
+	}
+	ch := make(chan struct{})
+	f.running[dg] = ch
+	return func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.running, dg)
+		close(ch)
+	}, nil
+}
+
+// dedupInFlight waits for identical actions that are in flight to finish before the current
+// action gets executed. checkCache is called each time an identical action finishes and should
+// return true if a result for the action was found in the cache, in which case the action
+// doesn't need to be executed. Otherwise, the returned function must be called once the action's
+// result has been cached, unblocking identical actions waiting on it.
+func (a *action) dedupInFlight(ctx context.Context, dg digest.Digest, checkCache func() bool) (release func(), cached bool) {
+	if a.inFlight == nil || dg.Size == 0 {
+		return func() {}, false
+	}
+	from := time.Now()
+	waited := false
+	defer func() {
+		if waited {
+			a.rec.RecordEventTime(event.InFlightWait, from)
+		}
+	}()
+	for {
+		release, wait := a.inFlight.tryAcquire(dg)
+		if release != nil {
+			return release, false
+		}
+		waited = true
+		log.V(1).Infof("%v: Identical action %v is in flight, waiting for it to finish", a.cmd.Identifiers.ExecutionID, dg)
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return func() {}, false
+		}
+		if checkCache() {
+			log.V(1).Infof("%v: Reusing result of identical action %v", a.cmd.Identifiers.ExecutionID, dg)
+			return func() {}, true
+		}
+	}
+}
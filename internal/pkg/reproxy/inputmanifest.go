@@ -0,0 +1,131 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reproxy
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/digest"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/filemetadata"
+
+	lpb "github.com/bazelbuild/reclient/api/log"
+	log "github.com/golang/glog"
+)
+
+// inputManifest returns the digests of the inputs, arguments and environment variables of the
+// action. Input files that cannot be digested are skipped, as the SDK does when building the input
+// tree.
+func (a *action) inputManifest() *lpb.InputManifest {
+	fmc := a.fmc
+	if fmc == nil {
+		fmc = filemetadata.NewNoopCache()
+	}
+	m := &lpb.InputManifest{
+		FileDigests: make(map[string]string),
+		ArgsDigest:  digest.NewFromBlob([]byte(strings.Join(a.cmd.Args, "\x00"))).String(),
+	}
+	if a.cmd.InputSpec == nil {
+		m.EnvironmentDigest = digest.Empty.String()
+		return m
+	}
+	env := make([]string, 0, len(a.cmd.InputSpec.EnvironmentVariables))
+	for k, v := range a.cmd.InputSpec.EnvironmentVariables {
+		env = append(env, fmt.Sprintf("%v=%v", k, v))
+	}
+	sort.Strings(env)
+	m.EnvironmentDigest = digest.NewFromBlob([]byte(strings.Join(env, "\x00"))).String()
+	excl := compileExclusions(a.cmd.InputSpec.InputExclusions)
+	for _, in := range a.cmd.InputSpec.Inputs {
+		abs := filepath.Join(a.cmd.ExecRoot, in)
+		md := fmc.Get(abs)
+		if md.Err != nil {
+			continue
+		}
+		if !md.IsDirectory {
+			if !excluded(abs, command.FileInputType, excl) {
+				m.FileDigests[filepath.Clean(in)] = md.Digest.String()
+			}
+			continue
+		}
+		if excluded(abs, command.DirectoryInputType, excl) {
+			continue
+		}
+		err := filepath.WalkDir(abs, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || excluded(path, command.FileInputType, excl) {
+				return nil
+			}
+			rel, err := filepath.Rel(a.cmd.ExecRoot, path)
+			if err != nil {
+				return err
+			}
+			if md := fmc.Get(path); md.Err == nil && !md.IsDirectory {
+				m.FileDigests[rel] = md.Digest.String()
+			}
+			return nil
+		})
+		if err != nil {
+			log.Warningf("%v: Failed to digest input directory %v: %v", a.cmd.Identifiers.ExecutionID, in, err)
+		}
+	}
+	for _, vi := range a.cmd.InputSpec.VirtualInputs {
+		switch {
+		case vi.IsEmptyDirectory:
+		case vi.Digest != "":
+			m.FileDigests[filepath.Clean(vi.Path)] = vi.Digest
+		default:
+			m.FileDigests[filepath.Clean(vi.Path)] = digest.NewFromBlob(vi.Contents).String()
+		}
+	}
+	return m
+}
+
+// inputExclusion is an input exclusion with its regular expression compiled.
+type inputExclusion struct {
+	t  command.InputType
+	re *regexp.Regexp
+}
+
+// compileExclusions compiles the regular expressions of the exclusions once for all the inputs of
+// the action. Exclusions with invalid regular expressions do not match any input.
+func compileExclusions(excl []*command.InputExclusion) []inputExclusion {
+	res := make([]inputExclusion, 0, len(excl))
+	for _, e := range excl {
+		re, err := regexp.Compile(e.Regex)
+		if err != nil {
+			continue
+		}
+		res = append(res, inputExclusion{t: e.Type, re: re})
+	}
+	return res
+}
+
+// excluded returns whether the input at the given absolute path matches one of the exclusions.
+func excluded(path string, t command.InputType, excl []inputExclusion) bool {
+	for _, e := range excl {
+		if e.t != command.UnspecifiedInputType && e.t != t {
+			continue
+		}
+		if e.re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
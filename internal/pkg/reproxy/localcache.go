@@ -0,0 +1,222 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reproxy
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/bazelbuild/reclient/internal/pkg/actioncache"
+	"github.com/bazelbuild/reclient/internal/pkg/event"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/client"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/digest"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/filemetadata"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/outerr"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/uploadinfo"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	ppb "github.com/bazelbuild/reclient/api/proxy"
+	repb "github.com/bazelbuild/remote-apis/build/bazel/remote/execution/v2"
+	log "github.com/golang/glog"
+)
+
+// computeActionDigest computes the digest of the action without a connection to the remote
+// execution service, the same way the SDK computes it before executing the action remotely.
+func (a *action) computeActionDigest(ctx context.Context) (digest.Digest, error) {
+	// Computing the input root only depends on the symlink options of the client.
+	gc := &client.Client{}
+	cmdDg, err := digest.NewFromMessage(a.cmd.ToREProto(gc.SupportsCommandOutputPaths()))
+	if err != nil {
+		return digest.Empty, err
+	}
+	fmc := a.fmc
+	if fmc == nil {
+		fmc = filemetadata.NewNoopCache()
+	}
+	root, _, _, err := gc.ComputeMerkleTree(ctx, a.cmd.ExecRoot, a.cmd.WorkingDir, a.cmd.RemoteWorkingDir, a.cmd.InputSpec, fmc)
+	if err != nil {
+		return digest.Empty, err
+	}
+	acPb := &repb.Action{
+		CommandDigest:   cmdDg.ToProto(),
+		InputRootDigest: root.ToProto(),
+	}
+	if a.cmd.Timeout > 0 {
+		acPb.Timeout = durationpb.New(a.cmd.Timeout)
+	}
+	return digest.NewFromMessage(acPb)
+}
+
+// getLocalCachedResult looks up the result of the action in the local action cache. On a hit, the
+// outputs of the action are written to the exec root and true is returned.
+func (a *action) getLocalCachedResult(lc *actioncache.Cache, dg digest.Digest) bool {
+	from := time.Now()
+	defer a.rec.RecordEventTime(event.LocalCacheLookup, from)
+	ar, ok := lc.Get(dg)
+	if !ok {
+		return false
+	}
+	// The result is validated before its outputs are written, so that an invalid result does not
+	// overwrite the outputs in the exec root.
+	if !a.localCachedResultValid(lc, ar) {
+		log.V(1).Infof("%v: Local action cache hit failed deps validation", a.cmd.Identifiers.ExecutionID)
+		return false
+	}
+	stdout, err := readLocalCacheBlob(lc, ar.GetStdoutDigest())
+	if err != nil {
+		log.Warningf("%v: Failed to read stdout from the local action cache: %v", a.cmd.Identifiers.ExecutionID, err)
+		return false
+	}
+	stderr, err := readLocalCacheBlob(lc, ar.GetStderrDigest())
+	if err != nil {
+		log.Warningf("%v: Failed to read stderr from the local action cache: %v", a.cmd.Identifiers.ExecutionID, err)
+		return false
+	}
+	if err := lc.Materialize(ar, filepath.Join(a.cmd.ExecRoot, a.cmd.WorkingDir)); err != nil {
+		log.Warningf("%v: Failed to materialize outputs from the local action cache: %v", a.cmd.Identifiers.ExecutionID, err)
+		return false
+	}
+	a.clearOutputsCache()
+	a.oe.WriteOut(stdout)
+	a.oe.WriteErr(stderr)
+	a.res = &command.Result{Status: command.CacheHitResultStatus}
+	a.rec.LocalMetadata.LocalCacheHit = true
+	a.rec.LocalMetadata.Result = command.ResultToProto(a.res)
+	return true
+}
+
+// localCachedResultValid verifies the deps file of a result in the local action cache, as stored in
+// the cache, against the current inputs of the action.
+func (a *action) localCachedResultValid(lc *actioncache.Cache, ar *repb.ActionResult) bool {
+	if a.dFile == "" {
+		return true
+	}
+	for _, f := range ar.GetOutputFiles() {
+		if filepath.Clean(f.GetPath()) != filepath.Clean(a.depsFile) {
+			continue
+		}
+		buf, err := readLocalCacheBlob(lc, f.GetDigest())
+		if err != nil {
+			log.Warningf("%v: Failed to read deps file from the local action cache: %v", a.cmd.Identifiers.ExecutionID, err)
+			return false
+		}
+		a.createParser()
+		ok, err := a.parser.VerifyDeps(buf, a.rec)
+		if err != nil {
+			log.Errorf("%v:  Failed to verify deps file: %v", a.cmd.Identifiers.ExecutionID, err)
+			return false
+		}
+		return ok
+	}
+	log.Warningf("%v: No deps file %v in the local action cache", a.cmd.Identifiers.ExecutionID, a.depsFile)
+	return false
+}
+
+// readLocalCacheBlob reads a blob from the local action cache. Digests of empty stdout and stderr
+// are not set in cached results, so a nil digest is read as an empty blob.
+func readLocalCacheBlob(lc *actioncache.Cache, dg *repb.Digest) ([]byte, error) {
+	if dg == nil {
+		return nil, nil
+	}
+	return lc.ReadBlob(digest.NewFromProtoUnvalidated(dg))
+}
+
+// updateLocalCache stores the outputs of a successful local execution of the action in the local
+// action cache.
+func (a *action) updateLocalCache(lc *actioncache.Cache, dg digest.Digest) {
+	if !a.res.IsOk() || a.lOpt.GetDoNotCache() {
+		return
+	}
+	a.validateOutputs()
+	if !a.res.IsOk() || a.invalidOutputs() {
+		return
+	}
+	from := time.Now()
+	defer a.rec.RecordEventTime(event.LocalCacheUpdate, from)
+	var paths []string
+	paths = append(paths, a.cmd.OutputFiles...)
+	paths = append(paths, a.cmd.OutputDirs...)
+	blobs, ar, err := (&client.Client{}).ComputeOutputsToUpload(a.cmd.ExecRoot, a.cmd.WorkingDir, paths, filemetadata.NewSingleFlightCache(), a.cmd.InputSpec.SymlinkBehavior, a.cmd.InputSpec.InputNodeProperties)
+	if err != nil {
+		log.Warningf("%v: Failed to compute outputs for the local action cache: %v", a.cmd.Identifiers.ExecutionID, err)
+		return
+	}
+	if roe, ok := a.oe.(*outerr.RecordingOutErr); ok {
+		for _, s := range []struct {
+			blob []byte
+			dg   **repb.Digest
+		}{{roe.Stdout(), &ar.StdoutDigest}, {roe.Stderr(), &ar.StderrDigest}} {
+			if len(s.blob) == 0 {
+				continue
+			}
+			ue := uploadinfo.EntryFromBlob(s.blob)
+			blobs[ue.Digest] = ue
+			*s.dg = ue.Digest.ToProto()
+		}
+	}
+	if err := lc.Put(dg, ar, blobs); err != nil {
+		log.Warningf("%v: Failed to update the local action cache: %v", a.cmd.Identifiers.ExecutionID, err)
+		return
+	}
+	a.rec.LocalMetadata.UpdatedLocalCache = true
+}
+
+// runLocalCached runs the action locally when remote execution is disabled, reusing the result of
+// an identical action from the local action cache when available.
+func (s *Server) runLocalCached(ctx context.Context, a *action) {
+	if err := s.populateCommandIO(ctx, a); err != nil {
+		if a.lOpt.GetPlatform() == ppb.LocalExecutionOptions_SANDBOX {
+			return
+		}
+		log.Warningf("%v: Failed to process inputs, running without the local action cache: %v", a.cmd.Identifiers.ExecutionID, err)
+		a.runLocal(ctx, s.LocalPool)
+		return
+	}
+	a.addDepsFileOutput()
+	dg, err := a.computeActionDigest(ctx)
+	if err != nil {
+		log.Warningf("%v: Failed to compute action digest, running without the local action cache: %v", a.cmd.Identifiers.ExecutionID, err)
+		a.runLocal(ctx, s.LocalPool)
+		return
+	}
+	log.V(1).Infof("%v: Local action digest: %v", a.cmd.Identifiers.ExecutionID, dg)
+	if a.lOpt.GetAcceptCached() {
+		if a.getLocalCachedResult(s.LocalCache, dg) {
+			return
+		}
+		if !a.lOpt.GetDoNotCache() {
+			release, cached := a.dedupInFlight(ctx, dg, func() bool {
+				return a.getLocalCachedResult(s.LocalCache, dg)
+			})
+			defer release()
+			if cached {
+				return
+			}
+		}
+	}
+	a.runLocal(ctx, s.LocalPool)
+	if !a.res.IsOk() {
+		return
+	}
+	if err := a.generateDepsFile(); err != nil {
+		log.Warningf("%v: Failed to generate deps file: %v", a.cmd.Identifiers.ExecutionID, err)
+		return
+	}
+	a.clearOutputsCache()
+	a.updateLocalCache(s.LocalCache, dg)
+}
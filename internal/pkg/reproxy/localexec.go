@@ -16,17 +16,27 @@ package reproxy
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
 	"os/exec"
+	"sync/atomic"
 	"time"
 
+	"github.com/bazelbuild/reclient/internal/pkg/cgroups"
 	"github.com/bazelbuild/reclient/internal/pkg/event"
+	"github.com/bazelbuild/reclient/internal/pkg/filetrace"
 	"github.com/bazelbuild/reclient/internal/pkg/labels"
 	"github.com/bazelbuild/reclient/internal/pkg/localresources"
 	"github.com/bazelbuild/reclient/internal/pkg/logger"
+	"github.com/bazelbuild/reclient/internal/pkg/sandbox"
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/outerr"
+	"google.golang.org/protobuf/encoding/prototext"
 
 	lpb "github.com/bazelbuild/reclient/api/log"
+	ppb "github.com/bazelbuild/reclient/api/proxy"
+	log "github.com/golang/glog"
 )
 
 type requirements struct {
@@ -44,6 +54,11 @@ var (
 		labels.D8Labels():        {8, 4096},
 		labels.ClangLinkLabels(): {1, 8192},
 	}
+
+	errNoSandbox = errors.New("sandboxed local execution is not available")
+
+	// errLocalTimeout is returned when the local execution timeout of a command expires.
+	errLocalTimeout = errors.New("local execution timed out")
 )
 
 // Executor can run commands and retrieve their outputs.
@@ -55,46 +70,228 @@ type Executor interface {
 // LocalPool is responsible for executing commands locally.
 type LocalPool struct {
 	executor Executor
-	resMgr   *localresources.Manager
+	// sandbox executes commands with the SANDBOX local execution platform.
+	sandbox Executor
+	resMgr  *localresources.Manager
+	// cgroups, if set, is used to limit the memory of each command to its RAM requirements.
+	cgroups *cgroups.Manager
+	// traceInputs makes the pool trace the files accessed by commands to verify their inputs.
+	traceInputs bool
+	// defaultReqs and lblReqs are the requirements of actions set by a local resources config. The
+	// latter, keyed by labels.ToKey, take precedence over the built-in requirements.
+	defaultReqs requirements
+	lblReqs     map[string]requirements
+	// queued is the number of commands waiting for local resources.
+	queued atomic.Int64
 }
 
 // NewLocalPool creates a pool with the given args.
 func NewLocalPool(exec Executor, resMgr *localresources.Manager) *LocalPool {
 	return &LocalPool{
 		executor: exec,
+		sandbox:  &sandbox.Executor{},
 		resMgr:   resMgr,
 	}
 }
 
+// SetCgroups makes the pool run each command in its own cgroup created with the given manager,
+// whose memory is limited to the RAM reserved for the command.
+func (l *LocalPool) SetCgroups(m *cgroups.Manager) {
+	l.cgroups = m
+}
+
+// SetInputTracing makes the pool trace the files accessed by each command, and record in the log
+// record of successful commands how they differ from the declared inputs.
+func (l *LocalPool) SetInputTracing() {
+	l.traceInputs = true
+}
+
+// QueueLength returns the number of commands waiting for local resources to run.
+func (l *LocalPool) QueueLength() int64 {
+	return l.queued.Load()
+}
+
+// ReadLocalResourcesConfig reads a LocalResourcesConfig in text proto format from the given file.
+func ReadLocalResourcesConfig(path string) (*ppb.LocalResourcesConfig, error) {
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	conf := &ppb.LocalResourcesConfig{}
+	if err := prototext.Unmarshal(blob, conf); err != nil {
+		return nil, fmt.Errorf("failed to parse local resources config %v: %w", path, err)
+	}
+	return conf, nil
+}
+
+// SetResourcesConfig sets the resources reserved for locally executed actions according to the
+// given config.
+func (l *LocalPool) SetResourcesConfig(conf *ppb.LocalResourcesConfig) error {
+	def := defaultReqs
+	def.override(conf.GetDefaultResources())
+	reqs := make(map[string]requirements, len(conf.GetRules()))
+	for _, r := range conf.GetRules() {
+		if len(r.GetLabels()) == 0 {
+			return fmt.Errorf("local resources rule %v has no labels", r)
+		}
+		key := labels.ToKey(r.GetLabels())
+		if _, ok := reqs[key]; ok {
+			return fmt.Errorf("duplicate local resources rule for labels %v", key)
+		}
+		req, ok := lblReqs[labels.FromMap(r.GetLabels())]
+		if !ok {
+			req = def
+		}
+		req.override(r.GetResources())
+		reqs[key] = req
+	}
+	l.defaultReqs = def
+	l.lblReqs = reqs
+	return nil
+}
+
+// requirements returns the resources to reserve for an action with the given labels and options.
+func (l *LocalPool) requirements(lbls map[string]string, lOpt *ppb.LocalExecutionOptions) requirements {
+	req, ok := l.lblReqs[labels.ToKey(lbls)]
+	if !ok {
+		if req, ok = lblReqs[labels.FromMap(lbls)]; !ok {
+			req = l.defaultReqs
+			if req == (requirements{}) {
+				req = defaultReqs
+			}
+		}
+	}
+	req.override(&ppb.LocalResources{Cpus: lOpt.GetCpus(), RamMb: lOpt.GetRamMb()})
+	return req
+}
+
+// override replaces the requirements by the resources that are set.
+func (r *requirements) override(res *ppb.LocalResources) {
+	if res.GetCpus() > 0 {
+		r.cpus = res.GetCpus()
+	}
+	if res.GetRamMb() > 0 {
+		r.ramMBs = res.GetRamMb()
+	}
+}
+
 // Run runs a command locally. Returns the stdout, stderr, exit code, and error in case more
 // information about the failure is needed.
-func (l *LocalPool) Run(ctx, cCtx context.Context, cmd *command.Command, lbls map[string]string, oe outerr.OutErr, rec *logger.LogRecord) (int, error) {
-	var req requirements
-	var ok bool
-	if req, ok = lblReqs[labels.FromMap(lbls)]; !ok {
-		req = defaultReqs
+func (l *LocalPool) Run(ctx, cCtx context.Context, cmd *command.Command, lbls map[string]string, lOpt *ppb.LocalExecutionOptions, oe outerr.OutErr, rec *logger.LogRecord) (int, error) {
+	executor := l.executor
+	if lOpt.GetPlatform() == ppb.LocalExecutionOptions_SANDBOX {
+		if l.sandbox == nil {
+			return 0, errNoSandbox
+		}
+		executor = l.sandbox
 	}
+	req := l.requirements(lbls, lOpt)
 	if rec.GetLocalMetadata() == nil {
 		rec.LocalMetadata = &lpb.LocalMetadata{}
 	}
 
 	qt := time.Now()
+	setPhase(ctx, ppb.ActionPhase_LOCAL_QUEUE)
+	l.queued.Add(1)
 	release, err := l.resMgr.Lock(cCtx, req.cpus, req.ramMBs)
+	l.queued.Add(-1)
 	et := rec.RecordEventTime(event.LocalCommandQueued, qt)
 	if err != nil {
 		return 0, err
 	}
+	setPhase(ctx, ppb.ActionPhase_LOCAL_EXECUTION)
 	defer release()
+	// The timeout starts once resources are acquired, so that time spent waiting in the queue
+	// behind other actions does not count against it.
+	timeout := time.Duration(lOpt.GetExecutionTimeout()) * time.Second
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+	// expired returns whether an error was caused by the expiration of the local execution timeout.
+	expired := func(err error) bool {
+		return err != nil && !deadline.IsZero() && !time.Now().Before(deadline)
+	}
 	defer func() {
 		rec.RecordEventTime(event.LocalCommandExecution, et)
 	}()
 	if v := ctx.Value(testOnlyBlockLocalExecKey); v != nil {
 		v.(func())()
 	}
-	err = l.executor.ExecuteWithOutErr(ctx, cmd, oe)
+	var leaf *cgroups.Leaf
+	if l.cgroups != nil {
+		if leaf, err = l.cgroups.NewLeaf(cmd.Identifiers.ExecutionID, req.ramMBs*1024*1024); err != nil {
+			log.Warningf("%v: Failed to create cgroup, running without memory limit: %v", cmd.Identifiers.ExecutionID, err)
+		} else {
+			defer func() {
+				if err := leaf.Close(); err != nil {
+					log.Warningf("%v: %v", cmd.Identifiers.ExecutionID, err)
+				}
+			}()
+			ctx = cgroups.NewContext(ctx, leaf)
+		}
+	}
+	execCmd := cmd
+	var trace *filetrace.Trace
+	if l.traceInputs {
+		if execCmd, trace, err = filetrace.Wrap(cmd, ""); err != nil {
+			log.Warningf("%v: Failed to trace command, running it untraced: %v", cmd.Identifiers.ExecutionID, err)
+			execCmd = cmd
+		} else {
+			defer func() {
+				if err := trace.Close(); err != nil {
+					log.Warningf("%v: Failed to remove file trace: %v", cmd.Identifiers.ExecutionID, err)
+				}
+			}()
+		}
+	}
+	err = executor.ExecuteWithOutErr(ctx, execCmd, oe)
+	if trace != nil && err == nil {
+		recordInputTrace(cmd, trace, rec)
+	}
+	if leaf != nil && err != nil {
+		oom, oErr := leaf.OOMKilled()
+		if oErr != nil {
+			log.Warningf("%v: Failed to read memory events of cgroup: %v", cmd.Identifiers.ExecutionID, oErr)
+		}
+		rec.LocalMetadata.OomKilled = oom
+	}
+	if expired(err) {
+		// The partial output of the command was already written to oe.
+		return 0, localTimeoutError(cmd, rec, timeout, event.LocalCommandExecution)
+	}
 	exitCode := 0
 	if exitErr, _ := err.(*exec.ExitError); exitErr != nil {
 		exitCode = exitErr.ExitCode()
 	}
 	return exitCode, err
 }
+
+// recordInputTrace records how the declared inputs of a command differ from the files it accessed
+// according to its trace.
+func recordInputTrace(cmd *command.Command, trace *filetrace.Trace, rec *logger.LogRecord) {
+	files, err := trace.Files()
+	if err != nil {
+		log.Warningf("%v: %v", cmd.Identifiers.ExecutionID, err)
+		return
+	}
+	under, over := filetrace.Diff(cmd, files)
+	if len(under) > 0 {
+		log.Warningf("%v: Command read undeclared inputs: %v", cmd.Identifiers.ExecutionID, under)
+	}
+	rec.LocalMetadata.InputTrace = &lpb.InputTrace{
+		UnderdeclaredInputs: under,
+		OverdeclaredInputs:  over,
+	}
+}
+
+// localTimeoutError records that the local execution of a command timed out during the given phase
+// and returns the error to report.
+func localTimeoutError(cmd *command.Command, rec *logger.LogRecord, timeout time.Duration, phase string) error {
+	log.Warningf("%v: Local execution timed out after %v during %v", cmd.Identifiers.ExecutionID, timeout, phase)
+	rec.LocalMetadata.TimeoutPhase = phase
+	return fmt.Errorf("%w after %v", errLocalTimeout, timeout)
+}
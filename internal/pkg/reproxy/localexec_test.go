@@ -17,20 +17,36 @@ package reproxy
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/bazelbuild/reclient/internal/pkg/cgroups"
+	"github.com/bazelbuild/reclient/internal/pkg/event"
+	"github.com/bazelbuild/reclient/internal/pkg/filetrace"
 	"github.com/bazelbuild/reclient/internal/pkg/labels"
 	"github.com/bazelbuild/reclient/internal/pkg/localresources"
 	"github.com/bazelbuild/reclient/internal/pkg/logger"
+	"github.com/bazelbuild/reclient/internal/pkg/subprocess"
 
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/outerr"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
 
 	lpb "github.com/bazelbuild/reclient/api/log"
+	ppb "github.com/bazelbuild/reclient/api/proxy"
 )
 
+func TestMain(m *testing.M) {
+	// Commands are traced by re-executing the test binary.
+	filetrace.Init()
+	os.Exit(m.Run())
+}
+
 func TestLocalPoolMaxParallelism(t *testing.T) {
 	t.Parallel()
 	exec := &stubExecutor{
@@ -49,7 +65,7 @@ func TestLocalPoolMaxParallelism(t *testing.T) {
 		go func() {
 			defer wg.Done()
 			oe := outerr.NewRecordingOutErr()
-			exitCode, err := pool.Run(ctx, ctx, &command.Command{}, nil, oe, &logger.LogRecord{LogRecord: &lpb.LogRecord{}})
+			exitCode, err := pool.Run(ctx, ctx, &command.Command{}, nil, nil, oe, &logger.LogRecord{LogRecord: &lpb.LogRecord{}})
 			stdout := string(oe.Stdout())
 			stderr := string(oe.Stderr())
 			if stdout != exec.stdout || stderr != exec.stderr || exitCode != 0 || err != exec.err {
@@ -84,7 +100,7 @@ func TestLocalPoolCancellation(t *testing.T) {
 	go func() {
 		defer wg.Done()
 		oe := outerr.NewRecordingOutErr()
-		exitCode, err := pool.Run(ctx, cCtx, &command.Command{}, nil, oe, &logger.LogRecord{LogRecord: &lpb.LogRecord{}})
+		exitCode, err := pool.Run(ctx, cCtx, &command.Command{}, nil, nil, oe, &logger.LogRecord{LogRecord: &lpb.LogRecord{}})
 		if exitCode != 0 || err == nil || !errors.Is(err, context.Canceled) {
 			t.Errorf("Run() = %v,%v, want context canceled error", exitCode, err)
 		}
@@ -115,7 +131,7 @@ func TestLocalPool_InsufficientSystemResources(t *testing.T) {
 		go func() {
 			defer wg.Done()
 			oe := outerr.NewRecordingOutErr()
-			exitCode, err := pool.Run(ctx, ctx, &command.Command{}, labels.ToMap(labels.MetalavaLabels()), oe, &logger.LogRecord{LogRecord: &lpb.LogRecord{}})
+			exitCode, err := pool.Run(ctx, ctx, &command.Command{}, labels.ToMap(labels.MetalavaLabels()), nil, oe, &logger.LogRecord{LogRecord: &lpb.LogRecord{}})
 			stdout := string(oe.Stdout())
 			stderr := string(oe.Stderr())
 			if stdout != exec.stdout || stderr != exec.stderr || exitCode != 0 || err != exec.err {
@@ -146,7 +162,7 @@ func TestLocalPoolHighMem(t *testing.T) {
 		go func() {
 			defer wg.Done()
 			oe := outerr.NewRecordingOutErr()
-			exitCode, err := pool.Run(ctx, ctx, &command.Command{}, labels.ToMap(labels.MetalavaLabels()), oe, &logger.LogRecord{LogRecord: &lpb.LogRecord{}})
+			exitCode, err := pool.Run(ctx, ctx, &command.Command{}, labels.ToMap(labels.MetalavaLabels()), nil, oe, &logger.LogRecord{LogRecord: &lpb.LogRecord{}})
 			stdout := string(oe.Stdout())
 			stderr := string(oe.Stderr())
 			if stdout != exec.stdout || stderr != exec.stderr || exitCode != 0 || err != exec.err {
@@ -186,7 +202,7 @@ func TestLocalPoolMixed(t *testing.T) {
 				lbls = labels.ClangCppLabels()
 			}
 			oe := outerr.NewRecordingOutErr()
-			exitCode, err := pool.Run(ctx, ctx, &command.Command{}, labels.ToMap(lbls), oe, &logger.LogRecord{LogRecord: &lpb.LogRecord{}})
+			exitCode, err := pool.Run(ctx, ctx, &command.Command{}, labels.ToMap(lbls), nil, oe, &logger.LogRecord{LogRecord: &lpb.LogRecord{}})
 			stdout := string(oe.Stdout())
 			stderr := string(oe.Stderr())
 			if stdout != exec.stdout || stderr != exec.stderr || exitCode != 0 || err != exec.err {
@@ -203,7 +219,7 @@ func TestLocalPoolMixed(t *testing.T) {
 	go func() {
 		defer wg.Done()
 		oe := outerr.NewRecordingOutErr()
-		exitCode, err := pool.Run(ctx, ctx, &command.Command{}, labels.ToMap(labels.ClangCppLabels()), oe, &logger.LogRecord{LogRecord: &lpb.LogRecord{}})
+		exitCode, err := pool.Run(ctx, ctx, &command.Command{}, labels.ToMap(labels.ClangCppLabels()), nil, oe, &logger.LogRecord{LogRecord: &lpb.LogRecord{}})
 		stdout := string(oe.Stdout())
 		stderr := string(oe.Stderr())
 		if stdout != exec.stdout || stderr != exec.stderr || exitCode != 0 || err != exec.err {
@@ -245,7 +261,7 @@ func TestLocalPoolEventTimes(t *testing.T) {
 			defer wg.Done()
 			oe := outerr.NewRecordingOutErr()
 			rec := &logger.LogRecord{LogRecord: &lpb.LogRecord{}}
-			pool.Run(ctx, ctx, &command.Command{}, nil, oe, rec)
+			pool.Run(ctx, ctx, &command.Command{}, nil, nil, oe, rec)
 			tiLCQ, okLCQ := rec.LocalMetadata.EventTimes["LocalCommandQueued"]
 			tiLCE, okLCE := rec.LocalMetadata.EventTimes["LocalCommandExecution"]
 			if !okLCQ {
@@ -264,6 +280,334 @@ func TestLocalPoolEventTimes(t *testing.T) {
 	wg.Wait()
 }
 
+func TestLocalPoolSandbox(t *testing.T) {
+	t.Parallel()
+	exec := &stubExecutor{stdout: "plain"}
+	sb := &stubExecutor{stdout: "sandboxed"}
+	pool := &LocalPool{
+		executor: exec,
+		sandbox:  sb,
+		resMgr:   localresources.NewManager(1, 512),
+	}
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		platform ppb.LocalExecutionOptions_LocalExecutionPlatform
+		want     string
+	}{
+		{name: "Unspecified", platform: ppb.LocalExecutionOptions_UNSPECIFIED, want: "plain"},
+		{name: "Sandbox", platform: ppb.LocalExecutionOptions_SANDBOX, want: "sandboxed"},
+	}
+	for _, tc := range tests {
+		oe := outerr.NewRecordingOutErr()
+		lOpt := &ppb.LocalExecutionOptions{Platform: tc.platform}
+		if _, err := pool.Run(ctx, ctx, &command.Command{}, nil, lOpt, oe, &logger.LogRecord{LogRecord: &lpb.LogRecord{}}); err != nil {
+			t.Errorf("%v: Run() returned error: %v", tc.name, err)
+		}
+		if got := string(oe.Stdout()); got != tc.want {
+			t.Errorf("%v: Run() stdout = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+
+	pool.sandbox = nil
+	lOpt := &ppb.LocalExecutionOptions{Platform: ppb.LocalExecutionOptions_SANDBOX}
+	if _, err := pool.Run(ctx, ctx, &command.Command{}, nil, lOpt, outerr.NewRecordingOutErr(), &logger.LogRecord{LogRecord: &lpb.LogRecord{}}); !errors.Is(err, errNoSandbox) {
+		t.Errorf("Run() without a sandbox returned error %v, want %v", err, errNoSandbox)
+	}
+}
+
+func TestLocalPoolMemoryLimits(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "cgroup.controllers"), []byte("cpu memory pids"), 0644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	cg, err := cgroups.New(root)
+	if errors.Is(err, cgroups.ErrUnsupported) {
+		t.Skip("cgroups are not supported on this platform")
+	}
+	if err != nil {
+		t.Fatalf("cgroups.New(%v) returned error: %v", root, err)
+	}
+	pool := NewLocalPool(nil, localresources.NewManager(4, 4096))
+	pool.SetCgroups(cg)
+	ctx := context.Background()
+	tests := []struct {
+		name          string
+		events        string
+		err           error
+		wantMemoryMax string
+		wantOOMKilled bool
+	}{
+		{
+			name:          "Success",
+			events:        "oom 0\noom_kill 0\n",
+			wantMemoryMax: "1073741824",
+		},
+		{
+			name:          "Crashed",
+			events:        "oom 0\noom_kill 0\n",
+			err:           errors.New("crashed"),
+			wantMemoryMax: "1073741824",
+		},
+		{
+			name:          "OOMKilled",
+			events:        "oom 1\noom_kill 1\n",
+			err:           errors.New("killed"),
+			wantMemoryMax: "1073741824",
+			wantOOMKilled: true,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var gotMemoryMax string
+			pool.executor = &cgroupExecutor{f: func(l *cgroups.Leaf) error {
+				if l == nil {
+					t.Fatalf("Command was executed without a cgroup")
+				}
+				b, err := os.ReadFile(filepath.Join(l.Path(), "memory.max"))
+				if err != nil {
+					t.Fatalf("ReadFile(memory.max) failed: %v", err)
+				}
+				gotMemoryMax = string(b)
+				if err := os.WriteFile(filepath.Join(l.Path(), "memory.events"), []byte(tc.events), 0644); err != nil {
+					t.Fatalf("WriteFile(memory.events) failed: %v", err)
+				}
+				return tc.err
+			}}
+			rec := &logger.LogRecord{LogRecord: &lpb.LogRecord{}}
+			cmd := &command.Command{Identifiers: &command.Identifiers{ExecutionID: "exec-id"}}
+			lOpt := &ppb.LocalExecutionOptions{RamMb: 1024}
+			if _, err := pool.Run(ctx, ctx, cmd, nil, lOpt, outerr.NewRecordingOutErr(), rec); err != tc.err {
+				t.Errorf("Run() returned error %v, want %v", err, tc.err)
+			}
+			if gotMemoryMax != tc.wantMemoryMax {
+				t.Errorf("Run() executed the command with memory.max = %q, want %q", gotMemoryMax, tc.wantMemoryMax)
+			}
+			if got := rec.GetLocalMetadata().GetOomKilled(); got != tc.wantOOMKilled {
+				t.Errorf("Run() set OomKilled = %v, want %v", got, tc.wantOOMKilled)
+			}
+		})
+	}
+}
+
+func TestLocalPoolInputTracing(t *testing.T) {
+	if runtime.GOOS != "linux" || runtime.GOARCH != "amd64" && runtime.GOARCH != "arm64" {
+		t.Skipf("File tracing is not supported on %v/%v", runtime.GOOS, runtime.GOARCH)
+	}
+	t.Parallel()
+	execRoot := t.TempDir()
+	for _, f := range []string{"src/in.txt", "src/undeclared.txt", "src/unused.txt"} {
+		p := filepath.Join(execRoot, f)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("MkdirAll(%v) failed: %v", filepath.Dir(p), err)
+		}
+		if err := os.WriteFile(p, []byte(f), 0644); err != nil {
+			t.Fatalf("WriteFile(%v) failed: %v", p, err)
+		}
+	}
+	pool := NewLocalPool(subprocess.SystemExecutor{}, localresources.NewManager(1, 512))
+	pool.SetInputTracing()
+	ctx := context.Background()
+	tests := []struct {
+		name   string
+		script string
+		want   *lpb.InputTrace
+	}{
+		{
+			name:   "Success",
+			script: "cat src/in.txt src/undeclared.txt > out.txt",
+			want: &lpb.InputTrace{
+				UnderdeclaredInputs: []string{"src/undeclared.txt"},
+				OverdeclaredInputs:  []string{"src/unused.txt"},
+			},
+		},
+		{
+			// The inputs of failed commands are not verified since they might have stopped early.
+			name:   "Failure",
+			script: "cat src/in.txt; exit 1",
+		},
+	}
+	for _, tc := range tests {
+		cmd := &command.Command{
+			Identifiers: &command.Identifiers{ExecutionID: "exec-id"},
+			Args:        []string{"/bin/sh", "-c", tc.script},
+			ExecRoot:    execRoot,
+			InputSpec:   &command.InputSpec{Inputs: []string{"src/in.txt", "src/unused.txt"}},
+			OutputFiles: []string{"out.txt"},
+		}
+		rec := &logger.LogRecord{LogRecord: &lpb.LogRecord{}}
+		oe := outerr.NewRecordingOutErr()
+		if _, err := pool.Run(ctx, ctx, cmd, nil, &ppb.LocalExecutionOptions{}, oe, rec); err != nil && tc.want != nil {
+			t.Errorf("%v: Run() returned error: %v", tc.name, err)
+		}
+		if diff := cmp.Diff(tc.want, rec.GetLocalMetadata().GetInputTrace(), protocmp.Transform()); diff != "" {
+			t.Errorf("%v: Run() recorded diff in input trace: (-want +got)\n%s", tc.name, diff)
+		}
+		if len(cmd.Args) != 3 {
+			t.Errorf("%v: Run() modified the args of the command: %v", tc.name, cmd.Args)
+		}
+	}
+}
+
+func TestLocalPoolTimeout(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	pool := NewLocalPool(&blockingExecStub{started: make(chan struct{})}, localresources.NewManager(1, 512))
+	lOpt := &ppb.LocalExecutionOptions{ExecutionTimeout: 1}
+	cmd := &command.Command{Identifiers: &command.Identifiers{ExecutionID: "exec-id"}}
+
+	rec := &logger.LogRecord{LogRecord: &lpb.LogRecord{}}
+	if _, err := pool.Run(ctx, ctx, cmd, nil, lOpt, outerr.NewRecordingOutErr(), rec); !errors.Is(err, errLocalTimeout) {
+		t.Errorf("Run() returned error %v, want %v", err, errLocalTimeout)
+	}
+	if got := rec.GetLocalMetadata().GetTimeoutPhase(); got != event.LocalCommandExecution {
+		t.Errorf("Run() recorded timeout phase %q, want %q", got, event.LocalCommandExecution)
+	}
+
+	// Time spent waiting for resources does not count against the timeout.
+	queuedPool := NewLocalPool(&cmdExecStub{localExec: func(*command.Command) {}}, localresources.NewManager(1, 512))
+	release, err := queuedPool.resMgr.Lock(ctx, 1, 512)
+	if err != nil {
+		t.Fatalf("Lock() returned error: %v", err)
+	}
+	time.AfterFunc(1500*time.Millisecond, release)
+	rec = &logger.LogRecord{LogRecord: &lpb.LogRecord{}}
+	if _, err := queuedPool.Run(ctx, ctx, cmd, nil, lOpt, outerr.NewRecordingOutErr(), rec); err != nil {
+		t.Errorf("Run() after waiting for resources returned error: %v", err)
+	}
+	if got := rec.GetLocalMetadata().GetTimeoutPhase(); got != "" {
+		t.Errorf("Run() after waiting for resources recorded timeout phase %q, want none", got)
+	}
+
+	release, err = pool.resMgr.Lock(ctx, 1, 512)
+	if err != nil {
+		t.Fatalf("Lock() returned error: %v", err)
+	}
+	defer release()
+	cCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := pool.Run(ctx, cCtx, cmd, nil, lOpt, outerr.NewRecordingOutErr(), &logger.LogRecord{LogRecord: &lpb.LogRecord{}}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() with canceled context returned error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestLocalPoolResourcesConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "local_resources.textproto")
+	conf := `
+default_resources { ram_mb: 1024 }
+rules {
+  labels { key: "type" value: "tool" }
+  labels { key: "tool" value: "kotlinc" }
+  resources { cpus: 4 ram_mb: 6144 }
+}
+rules {
+  labels { key: "type" value: "compile" }
+  labels { key: "compiler" value: "javac" }
+  labels { key: "lang" value: "java" }
+  resources { ram_mb: 2048 }
+}
+`
+	if err := os.WriteFile(path, []byte(conf), 0644); err != nil {
+		t.Fatalf("WriteFile(%v) failed: %v", path, err)
+	}
+	c, err := ReadLocalResourcesConfig(path)
+	if err != nil {
+		t.Fatalf("ReadLocalResourcesConfig(%v) failed: %v", path, err)
+	}
+	pool := &LocalPool{}
+	if err := pool.SetResourcesConfig(c); err != nil {
+		t.Fatalf("SetResourcesConfig() failed: %v", err)
+	}
+	tests := []struct {
+		name string
+		lbls map[string]string
+		lOpt *ppb.LocalExecutionOptions
+		want requirements
+	}{
+		{
+			name: "Default",
+			lbls: labels.ToMap(labels.ToolLabels()),
+			want: requirements{1, 1024},
+		},
+		{
+			name: "Rule",
+			lbls: map[string]string{"type": "tool", "tool": "kotlinc"},
+			want: requirements{4, 6144},
+		},
+		{
+			name: "PartialRuleKeepsBuiltin",
+			lbls: labels.ToMap(labels.JavacLabels()),
+			want: requirements{8, 2048},
+		},
+		{
+			name: "Builtin",
+			lbls: labels.ToMap(labels.MetalavaLabels()),
+			want: requirements{8, 8192},
+		},
+		{
+			name: "Options",
+			lbls: map[string]string{"type": "tool", "tool": "kotlinc"},
+			lOpt: &ppb.LocalExecutionOptions{RamMb: 12288},
+			want: requirements{4, 12288},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := pool.requirements(tc.lbls, tc.lOpt); got != tc.want {
+				t.Errorf("requirements(%v, %v) = %+v, want %+v", tc.lbls, tc.lOpt, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLocalPoolRequirementsWithoutConfig(t *testing.T) {
+	pool := &LocalPool{}
+	if got := pool.requirements(labels.ToMap(labels.ToolLabels()), nil); got != defaultReqs {
+		t.Errorf("requirements() = %+v, want %+v", got, defaultReqs)
+	}
+	lOpt := &ppb.LocalExecutionOptions{Cpus: 2, RamMb: 4096}
+	if got, want := pool.requirements(labels.ToMap(labels.ToolLabels()), lOpt), (requirements{2, 4096}); got != want {
+		t.Errorf("requirements() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLocalPoolInvalidResourcesConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		conf *ppb.LocalResourcesConfig
+	}{
+		{
+			name: "NoLabels",
+			conf: &ppb.LocalResourcesConfig{Rules: []*ppb.LocalResourcesRule{{Resources: &ppb.LocalResources{Cpus: 2}}}},
+		},
+		{
+			name: "DuplicateLabels",
+			conf: &ppb.LocalResourcesConfig{Rules: []*ppb.LocalResourcesRule{
+				{Labels: map[string]string{"type": "tool"}, Resources: &ppb.LocalResources{Cpus: 2}},
+				{Labels: map[string]string{"type": "tool"}, Resources: &ppb.LocalResources{Cpus: 4}},
+			}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := (&LocalPool{}).SetResourcesConfig(tc.conf); err == nil {
+				t.Errorf("SetResourcesConfig(%v) succeeded, want error", tc.conf)
+			}
+		})
+	}
+}
+
+// cgroupExecutor calls f with the cgroup the command is executed in.
+type cgroupExecutor struct {
+	f func(l *cgroups.Leaf) error
+}
+
+func (e *cgroupExecutor) ExecuteWithOutErr(ctx context.Context, cmd *command.Command, oe outerr.OutErr) error {
+	return e.f(cgroups.FromContext(ctx))
+}
+
 type stubExecutor struct {
 	numParallel int64
 	maxParallel int64
@@ -0,0 +1,176 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reproxy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
+
+	lpb "github.com/bazelbuild/reclient/api/log"
+	ppb "github.com/bazelbuild/reclient/api/proxy"
+	log "github.com/golang/glog"
+)
+
+// mtimeSlack is subtracted from the start time of an action when looking for files it wrote, since
+// file timestamps are coarser than the clock on most filesystems.
+const mtimeSlack = time.Second
+
+// outputChecks are the checks of the outputs of successful actions, set from the flags of the
+// server.
+type outputChecks struct {
+	// validate enables reporting declared outputs missing from results.
+	validate bool
+	// detectUndeclared enables reporting undeclared outputs of local executions.
+	detectUndeclared bool
+	// failOnInvalid fails actions with missing or undeclared outputs.
+	failOnInvalid bool
+	// remoteDisabled is set when all actions run locally.
+	remoteDisabled bool
+}
+
+// validateOutputs checks the outputs of a successful action against its declared outputs, and
+// records the problems found in the log record of the action. If failOnInvalid is set, the action
+// fails when problems are found. The outputs are only checked once, before the result of the action
+// is cached, so that results with missing outputs are never cached.
+func (a *action) validateOutputs() {
+	c := a.outputChecks
+	if !c.validate && !c.detectUndeclared || a.outputsValidated || a.res == nil || !a.res.IsOk() {
+		return
+	}
+	a.outputsValidated = true
+	// Outputs of remote results are only in the exec root if they were downloaded, so they are
+	// validated against the action result instead.
+	local := c.remoteDisabled || a.execStrategy == ppb.ExecutionStrategy_LOCAL || a.rec.GetLocalMetadata().GetExecutedLocally()
+	v := &lpb.OutputValidation{}
+	if c.validate {
+		v.MissingOutputs = a.missingOutputs(local)
+	}
+	// Only declared outputs of remote executions are returned, so undeclared outputs can only be
+	// detected for local executions.
+	if c.detectUndeclared && local {
+		v.UndeclaredOutputs = a.undeclaredOutputs(a.start)
+	}
+	if len(v.GetMissingOutputs()) == 0 && len(v.GetUndeclaredOutputs()) == 0 {
+		return
+	}
+	a.rec.OutputValidation = v
+	log.Warningf("%v: Invalid outputs, missing: %v, undeclared: %v", a.cmd.Identifiers.ExecutionID, v.GetMissingOutputs(), v.GetUndeclaredOutputs())
+	if !c.failOnInvalid {
+		return
+	}
+	err := fmt.Errorf("invalid outputs, missing: %v, undeclared: %v", v.GetMissingOutputs(), v.GetUndeclaredOutputs())
+	a.res = command.NewLocalErrorResult(err)
+	a.oe.WriteErr([]byte(fmt.Sprintf("reclient[%v]: %v\n", a.cmd.Identifiers.ExecutionID, err)))
+}
+
+// invalidOutputs returns whether declared outputs of the action were found missing, in which case
+// its result must not be cached. Undeclared outputs alone do not prevent caching, since the declared
+// outputs are complete; they only fail the action if failOnInvalid is set.
+func (a *action) invalidOutputs() bool {
+	return len(a.rec.GetOutputValidation().GetMissingOutputs()) > 0
+}
+
+// missingOutputs returns the declared outputs of the action that it did not produce. If local is
+// false, outputs are looked up in the remote action result only, since files in the exec root might
+// be left from a previous build.
+func (a *action) missingOutputs(local bool) []string {
+	var missing []string
+	wd := filepath.Join(a.cmd.ExecRoot, a.cmd.WorkingDir)
+	for _, outs := range []struct {
+		paths  []string
+		remote map[string]string
+	}{
+		{a.cmd.OutputFiles, a.rec.GetRemoteMetadata().GetOutputFileDigests()},
+		{a.cmd.OutputDirs, a.rec.GetRemoteMetadata().GetOutputDirectoryDigests()},
+	} {
+		for _, out := range outs.paths {
+			if !local {
+				if _, ok := outs.remote[out]; !ok {
+					missing = append(missing, out)
+				}
+				continue
+			}
+			if _, err := os.Lstat(filepath.Join(wd, out)); err != nil {
+				missing = append(missing, out)
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// undeclaredOutputs returns the files written since start next to the declared output files of the
+// action, which are named after one of them but are not declared outputs or inputs. These are
+// typically side outputs of the tool, e.g. foo.dwo next to foo.o. Files not named after an output
+// are ignored since they might have been written by other actions in the same directory.
+func (a *action) undeclaredOutputs(start time.Time) []string {
+	declared := make(map[string]bool)
+	for _, out := range a.cmd.OutputFiles {
+		declared[filepath.Clean(out)] = true
+	}
+	for _, out := range a.cmd.OutputDirs {
+		declared[filepath.Clean(out)] = true
+	}
+	inputs := make(map[string]bool)
+	if a.cmd.InputSpec != nil {
+		for _, in := range a.cmd.InputSpec.Inputs {
+			inputs[filepath.Clean(in)] = true
+		}
+	}
+	// Stems of the declared output files, by directory relative to the working directory.
+	stems := make(map[string]map[string]bool)
+	for _, out := range a.cmd.OutputFiles {
+		dir, name := filepath.Split(filepath.Clean(out))
+		stem, _, _ := strings.Cut(name, ".")
+		if stem == "" {
+			continue
+		}
+		if stems[dir] == nil {
+			stems[dir] = make(map[string]bool)
+		}
+		stems[dir][stem] = true
+	}
+	since := start.Add(-mtimeSlack)
+	var undeclared []string
+	for dir, dirStems := range stems {
+		entries, err := os.ReadDir(filepath.Join(a.cmd.ExecRoot, a.cmd.WorkingDir, dir))
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if e.IsDir() {
+				continue
+			}
+			rel := filepath.Join(dir, e.Name())
+			stem, _, _ := strings.Cut(e.Name(), ".")
+			if !dirStems[stem] || declared[rel] || inputs[filepath.Join(a.cmd.WorkingDir, rel)] {
+				continue
+			}
+			info, err := e.Info()
+			if err != nil || info.ModTime().Before(since) {
+				continue
+			}
+			undeclared = append(undeclared, rel)
+		}
+	}
+	sort.Strings(undeclared)
+	return undeclared
+}
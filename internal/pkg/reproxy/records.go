@@ -0,0 +1,155 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reproxy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bazelbuild/reclient/internal/pkg/event"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	lpb "github.com/bazelbuild/reclient/api/log"
+	ppb "github.com/bazelbuild/reclient/api/proxy"
+)
+
+// recordFilter selects the records returned by GetRecords.
+type recordFilter struct {
+	statuses       map[lpb.CompletionStatus]bool
+	labels         map[string]string
+	invocationID   string
+	after, before  time.Time
+	minDuration    time.Duration
+	needsExecution bool
+}
+
+func newRecordFilter(req *ppb.GetRecordsRequest) (*recordFilter, error) {
+	f := &recordFilter{
+		labels:       req.GetLabels(),
+		invocationID: req.GetInvocationId(),
+	}
+	if len(req.GetCompletionStatuses()) > 0 {
+		f.statuses = make(map[lpb.CompletionStatus]bool)
+		for _, st := range req.GetCompletionStatuses() {
+			f.statuses[st] = true
+		}
+	}
+	if ts := req.GetCompletedAfter(); ts != nil {
+		if err := ts.CheckValid(); err != nil {
+			return nil, fmt.Errorf("invalid completed_after: %w", err)
+		}
+		f.after = ts.AsTime()
+		f.needsExecution = true
+	}
+	if ts := req.GetCompletedBefore(); ts != nil {
+		if err := ts.CheckValid(); err != nil {
+			return nil, fmt.Errorf("invalid completed_before: %w", err)
+		}
+		f.before = ts.AsTime()
+		f.needsExecution = true
+	}
+	if d := req.GetMinDuration(); d != nil {
+		if err := d.CheckValid(); err != nil {
+			return nil, fmt.Errorf("invalid min_duration: %w", err)
+		}
+		f.minDuration = d.AsDuration()
+		f.needsExecution = true
+	}
+	return f, nil
+}
+
+func (f *recordFilter) matches(rec *lpb.LogRecord) bool {
+	if f.statuses != nil && !f.statuses[rec.GetCompletionStatus()] {
+		return false
+	}
+	if f.invocationID != "" && rec.GetCommand().GetIdentifiers().GetInvocationId() != f.invocationID {
+		return false
+	}
+	lbls := rec.GetLocalMetadata().GetLabels()
+	for k, v := range f.labels {
+		if lv, ok := lbls[k]; !ok || lv != v {
+			return false
+		}
+	}
+	if !f.needsExecution {
+		return true
+	}
+	ex := rec.GetLocalMetadata().GetEventTimes()[event.ProxyExecution]
+	if ex.GetFrom() == nil || ex.GetTo() == nil {
+		return false
+	}
+	from, to := ex.GetFrom().AsTime(), ex.GetTo().AsTime()
+	if !f.after.IsZero() && to.Before(f.after) {
+		return false
+	}
+	if !f.before.IsZero() && !to.Before(f.before) {
+		return false
+	}
+	return to.Sub(from) >= f.minDuration
+}
+
+// GetRecords returns the saved LogRecords matching the filters of the request, a page at a time if
+// the request has a page size.
+func (s *Server) GetRecords(ctx context.Context, req *ppb.GetRecordsRequest) (*ppb.GetRecordsResponse, error) {
+	if req.GetPageSize() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid page_size: %v, want >= 0", req.GetPageSize())
+	}
+	f, err := newRecordFilter(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	s.rmu.Lock()
+	// Records are only ever appended to and dropped from the front of s.records, so the records of
+	// this slice do not change.
+	recs := s.records
+	// Records are identified in page tokens by their position among all the records ever saved,
+	// which unlike their index in s.records does not change as older records are dropped.
+	first := s.numRecords - int64(len(recs))
+	s.rmu.Unlock()
+
+	step, i := 1, 0
+	if req.GetMostRecentFirst() {
+		step, i = -1, len(recs)-1
+	}
+	if tok := req.GetPageToken(); tok != "" {
+		pos, err := strconv.ParseInt(tok, 10, 64)
+		if err != nil || pos < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token: %q", tok)
+		}
+		// Records of the page that were dropped since are skipped.
+		switch j := pos - first; {
+		case step > 0 && j > 0:
+			i = int(j)
+		case step < 0 && j < int64(len(recs)):
+			i = int(j)
+		}
+	}
+	resp := &ppb.GetRecordsResponse{}
+	for ; i >= 0 && i < len(recs); i += step {
+		if !f.matches(recs[i]) {
+			continue
+		}
+		if req.GetPageSize() > 0 && len(resp.Records) == int(req.GetPageSize()) {
+			resp.NextPageToken = strconv.FormatInt(first+int64(i), 10)
+			break
+		}
+		resp.Records = append(resp.Records, recs[i])
+	}
+	return resp, nil
+}
@@ -0,0 +1,231 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reproxy
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/bazelbuild/reclient/internal/pkg/event"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	dpb "google.golang.org/protobuf/types/known/durationpb"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+
+	lpb "github.com/bazelbuild/reclient/api/log"
+	ppb "github.com/bazelbuild/reclient/api/proxy"
+
+	cpb "github.com/bazelbuild/remote-apis-sdks/go/api/command"
+)
+
+var recordsStart = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// testRecord returns a record of an action with the given command ID that ran for dur seconds and
+// completed n minutes after recordsStart.
+func testRecord(id string, st lpb.CompletionStatus, invocationID string, lbls map[string]string, n, dur int) *lpb.LogRecord {
+	to := recordsStart.Add(time.Duration(n) * time.Minute)
+	return &lpb.LogRecord{
+		Command: &cpb.Command{
+			Identifiers: &cpb.Identifiers{CommandId: id, InvocationId: invocationID},
+		},
+		CompletionStatus: st,
+		LocalMetadata: &lpb.LocalMetadata{
+			Labels: lbls,
+			EventTimes: map[string]*cpb.TimeInterval{
+				event.ProxyExecution: {
+					From: tspb.New(to.Add(-time.Duration(dur) * time.Second)),
+					To:   tspb.New(to),
+				},
+			},
+		},
+	}
+}
+
+func recordIDs(recs []*lpb.LogRecord) []string {
+	var ids []string
+	for _, r := range recs {
+		ids = append(ids, r.GetCommand().GetIdentifiers().GetCommandId())
+	}
+	return ids
+}
+
+func TestGetRecordsFilters(t *testing.T) {
+	cpp := map[string]string{"type": "compile", "lang": "cpp"}
+	java := map[string]string{"type": "compile", "lang": "java"}
+	server := &Server{}
+	server.records = []*lpb.LogRecord{
+		testRecord("a", lpb.CompletionStatus_STATUS_CACHE_HIT, "inv1", cpp, 1, 1),
+		testRecord("b", lpb.CompletionStatus_STATUS_REMOTE_FAILURE, "inv1", java, 2, 30),
+		testRecord("c", lpb.CompletionStatus_STATUS_LOCAL_FALLBACK, "inv2", cpp, 3, 10),
+		testRecord("d", lpb.CompletionStatus_STATUS_REMOTE_FAILURE, "inv2", cpp, 4, 5),
+	}
+	server.numRecords = 4
+	tests := []struct {
+		name string
+		req  *ppb.GetRecordsRequest
+		want []string
+	}{
+		{
+			name: "NoFilter",
+			req:  &ppb.GetRecordsRequest{},
+			want: []string{"a", "b", "c", "d"},
+		},
+		{
+			name: "CompletionStatuses",
+			req: &ppb.GetRecordsRequest{CompletionStatuses: []lpb.CompletionStatus{
+				lpb.CompletionStatus_STATUS_REMOTE_FAILURE,
+				lpb.CompletionStatus_STATUS_LOCAL_FALLBACK,
+			}},
+			want: []string{"b", "c", "d"},
+		},
+		{
+			name: "Labels",
+			req:  &ppb.GetRecordsRequest{Labels: map[string]string{"lang": "cpp"}},
+			want: []string{"a", "c", "d"},
+		},
+		{
+			name: "InvocationID",
+			req:  &ppb.GetRecordsRequest{InvocationId: "inv2"},
+			want: []string{"c", "d"},
+		},
+		{
+			name: "TimeWindow",
+			req: &ppb.GetRecordsRequest{
+				CompletedAfter:  tspb.New(recordsStart.Add(2 * time.Minute)),
+				CompletedBefore: tspb.New(recordsStart.Add(4 * time.Minute)),
+			},
+			want: []string{"b", "c"},
+		},
+		{
+			name: "MinDuration",
+			req:  &ppb.GetRecordsRequest{MinDuration: dpb.New(10 * time.Second)},
+			want: []string{"b", "c"},
+		},
+		{
+			name: "MostRecentFirst",
+			req: &ppb.GetRecordsRequest{
+				CompletionStatuses: []lpb.CompletionStatus{lpb.CompletionStatus_STATUS_REMOTE_FAILURE},
+				MostRecentFirst:    true,
+			},
+			want: []string{"d", "b"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := server.GetRecords(context.Background(), tc.req)
+			if err != nil {
+				t.Fatalf("GetRecords(%v) returned error: %v", tc.req, err)
+			}
+			if diff := cmp.Diff(tc.want, recordIDs(resp.GetRecords())); diff != "" {
+				t.Errorf("GetRecords(%v) returned diff in records: (-want +got)\n%s", tc.req, diff)
+			}
+			if resp.GetNextPageToken() != "" {
+				t.Errorf("GetRecords(%v) returned next page token %q, want none", tc.req, resp.GetNextPageToken())
+			}
+		})
+	}
+}
+
+func TestGetRecordsPages(t *testing.T) {
+	tests := []struct {
+		name            string
+		mostRecentFirst bool
+		// savedAfterFirst is the number of records saved after the first page was returned.
+		savedAfterFirst int
+		want            [][]string
+	}{
+		{
+			name: "OldestFirst",
+			want: [][]string{{"r0", "r2"}, {"r4", "r6"}, {"r8"}},
+		},
+		{
+			name:            "MostRecentFirst",
+			mostRecentFirst: true,
+			want:            [][]string{{"r8", "r6"}, {"r4", "r2"}, {"r0"}},
+		},
+		{
+			name:            "OldestFirstRecordsDropped",
+			savedAfterFirst: 5,
+			want:            [][]string{{"r0", "r2"}, {"r6", "r8"}, {"r10", "r12"}},
+		},
+		{
+			name:            "MostRecentFirstRecordsDropped",
+			mostRecentFirst: true,
+			savedAfterFirst: 5,
+			want:            [][]string{{"r8", "r6"}, nil},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := &Server{KeepLastRecords: 9}
+			n := 0
+			save := func(cnt int) {
+				for i := 0; i < cnt; i++ {
+					st := lpb.CompletionStatus_STATUS_REMOTE_FAILURE
+					if n%2 == 1 {
+						st = lpb.CompletionStatus_STATUS_CACHE_HIT
+					}
+					server.records = append(server.records, testRecord(fmt.Sprintf("r%d", n), st, "", nil, n, 1))
+					server.numRecords++
+					n++
+					if len(server.records) > server.KeepLastRecords {
+						server.records = server.records[1:]
+					}
+				}
+			}
+			save(9)
+			req := &ppb.GetRecordsRequest{
+				CompletionStatuses: []lpb.CompletionStatus{lpb.CompletionStatus_STATUS_REMOTE_FAILURE},
+				MostRecentFirst:    tc.mostRecentFirst,
+				PageSize:           2,
+			}
+			var got [][]string
+			for {
+				resp, err := server.GetRecords(context.Background(), req)
+				if err != nil {
+					t.Fatalf("GetRecords(%v) returned error: %v", req, err)
+				}
+				got = append(got, recordIDs(resp.GetRecords()))
+				if len(got) == 1 {
+					save(tc.savedAfterFirst)
+				}
+				if resp.GetNextPageToken() == "" || len(got) > 10 {
+					break
+				}
+				req.PageToken = resp.GetNextPageToken()
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GetRecords() returned diff in pages: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetRecordsInvalidRequest(t *testing.T) {
+	server := &Server{}
+	for _, req := range []*ppb.GetRecordsRequest{
+		{PageSize: -1},
+		{PageToken: "not-a-token"},
+		{MinDuration: &dpb.Duration{Seconds: 1, Nanos: -1}},
+	} {
+		if _, err := server.GetRecords(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("GetRecords(%v) returned error %v, want code %v", req, err, codes.InvalidArgument)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reproxy
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/rexec"
+
+	log "github.com/golang/glog"
+)
+
+// compareInBackground runs the compare mode reruns of an action whose result was already returned
+// to the client, and logs its record once they are done. The record must have been finalized with
+// finalizeRecord before. At most one action per CPU is rerun at a time, the others wait for their
+// turn.
+func (s *Server) compareInBackground(a *action) {
+	s.wgShutdown.Add(1)
+	s.wgCompare.Add(1)
+	go func() {
+		defer s.wgShutdown.Done()
+		defer s.wgCompare.Done()
+		defer a.removeInOutSnapshot()
+		// The reruns are not tied to the RPC, which has already returned, but they still block the
+		// shutdown of the server so that their verification results are not lost.
+		ctx := context.Background()
+		// Acquiring a slot only fails when the context is done, which never happens here.
+		s.compareSlots.Acquire(ctx, 1)
+		defer s.compareSlots.Release(1)
+		s.rerunAction(ctx, a, true)
+		s.emitRecord(a)
+	}()
+}
+
+// createScratchDir creates a new directory with a unique name in the directory used for atomic
+// downloads.
+func (a *action) createScratchDir(pattern string) (string, error) {
+	base := a.downloadTmp
+	if base == "" {
+		base = filepath.Join(a.cmd.ExecRoot, a.cmd.WorkingDir, tmpBaseDir)
+	}
+	if err := os.MkdirAll(base, os.ModePerm); err != nil {
+		return "", err
+	}
+	return os.MkdirTemp(base, a.cmd.Identifiers.ExecutionID+"-"+pattern)
+}
+
+// snapshotInOutFiles copies the files that are both inputs and outputs of the action before they
+// are overwritten by its execution, so that reruns in a scratch exec root start from the same
+// inputs.
+func (a *action) snapshotInOutFiles() {
+	files := a.inOutFiles()
+	if len(files) == 0 {
+		return
+	}
+	dir, err := a.createScratchDir("inout-")
+	if err != nil {
+		log.Warningf("%v: Failed to create directory for in-out files: %v", a.cmd.Identifiers.ExecutionID, err)
+		return
+	}
+	a.inOutSnapshot = dir
+	for _, f := range files {
+		rel, err := filepath.Rel(a.cmd.ExecRoot, f)
+		if err != nil {
+			continue
+		}
+		if err := copyFile(f, filepath.Join(dir, rel)); err != nil {
+			log.Warningf("%v: Failed to snapshot in-out file %v: %v", a.cmd.Identifiers.ExecutionID, f, err)
+		}
+	}
+}
+
+// removeInOutSnapshot removes the in-out files copied by snapshotInOutFiles, if any.
+func (a *action) removeInOutSnapshot() {
+	if a.inOutSnapshot == "" {
+		return
+	}
+	if err := os.RemoveAll(a.inOutSnapshot); err != nil {
+		log.Warningf("%v: Failed to remove in-out files snapshot: %v", a.cmd.Identifiers.ExecutionID, err)
+	}
+	a.inOutSnapshot = ""
+}
+
+// useScratchExecRoot moves the action to a new exec root containing its inputs, so that it can be
+// rerun without modifying the exec root of the build. Inputs are hard linked when possible and
+// copied otherwise, in-out files being copied from the snapshot taken before the first execution.
+// Returns a function removing the scratch exec root.
+func (a *action) useScratchExecRoot(ctx context.Context, client *rexec.Client) (func(), error) {
+	dir, err := a.createScratchDir("rerun-")
+	if err != nil {
+		return nil, err
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(dir); err != nil {
+			log.Warningf("%v: Failed to remove scratch exec root %v: %v", a.cmd.Identifiers.ExecutionID, dir, err)
+		}
+	}
+	if err := a.populateScratchExecRoot(dir); err != nil {
+		cleanup()
+		return nil, err
+	}
+	a.cmd.ExecRoot = dir
+	if err := a.downloadVirtualInputs(ctx, client); err != nil {
+		log.Warningf("%v: Failed to download virtual inputs before local rerun: %v", a.cmd.Identifiers.ExecutionID, err)
+	}
+	return cleanup, nil
+}
+
+func (a *action) populateScratchExecRoot(dir string) error {
+	if err := os.MkdirAll(filepath.Join(dir, a.cmd.WorkingDir), os.ModePerm); err != nil {
+		return err
+	}
+	for _, out := range append(append([]string{}, a.cmd.OutputFiles...), a.cmd.OutputDirs...) {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, a.cmd.WorkingDir, out)), os.ModePerm); err != nil {
+			return err
+		}
+	}
+	if a.cmd.InputSpec == nil {
+		return nil
+	}
+	inOut := make(map[string]bool)
+	for _, f := range a.inOutFiles() {
+		if rel, err := filepath.Rel(a.cmd.ExecRoot, f); err == nil {
+			inOut[rel] = true
+		}
+	}
+	for _, inp := range a.cmd.InputSpec.Inputs {
+		if filepath.IsAbs(inp) {
+			continue
+		}
+		src := filepath.Join(a.cmd.ExecRoot, inp)
+		if !inOut[filepath.Clean(inp)] {
+			if err := linkTree(src, filepath.Join(dir, inp)); err != nil {
+				return err
+			}
+			continue
+		}
+		// In-out files are always copied since the rerun overwrites them.
+		if a.inOutSnapshot != "" {
+			src = filepath.Join(a.inOutSnapshot, inp)
+		}
+		if err := copyFile(src, filepath.Join(dir, inp)); err != nil {
+			return err
+		}
+	}
+	for _, vi := range a.cmd.InputSpec.VirtualInputs {
+		path := filepath.Join(dir, vi.Path)
+		if vi.IsEmptyDirectory {
+			if err := os.MkdirAll(path, os.ModePerm); err != nil {
+				return err
+			}
+			continue
+		}
+		if vi.Digest != "" && len(vi.Contents) == 0 {
+			// Downloaded from the CAS once the exec root is in use.
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			return err
+		}
+		mode := os.FileMode(0644)
+		if vi.IsExecutable {
+			mode = 0755
+		}
+		if err := os.WriteFile(path, vi.Contents, mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// linkTree recreates the file or directory at src at dst, hard linking regular files when possible
+// and copying them otherwise.
+func linkTree(src, dst string) error {
+	return filepath.Walk(src, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case fi.IsDir():
+			if fi.Name() == tmpBaseDir {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(target, os.ModePerm)
+		case fi.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		default:
+			if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+				return err
+			}
+			if err := os.Link(path, target); err == nil {
+				return nil
+			}
+			return copyFile(path, target)
+		}
+	})
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	fi, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, fi.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
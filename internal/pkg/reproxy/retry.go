@@ -0,0 +1,183 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reproxy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/outerr"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/prototext"
+
+	lpb "github.com/bazelbuild/reclient/api/log"
+	ppb "github.com/bazelbuild/reclient/api/proxy"
+	log "github.com/golang/glog"
+)
+
+// sigkillExitCode is the exit code of commands killed by SIGKILL, which is how workers kill
+// commands that run out of memory.
+const sigkillExitCode = 137
+
+// ReadRemoteRetryPolicy reads a RemoteRetryPolicy text proto from the given path.
+func ReadRemoteRetryPolicy(path string) (*ppb.RemoteRetryPolicy, error) {
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p := &ppb.RemoteRetryPolicy{}
+	if err := prototext.Unmarshal(blob, p); err != nil {
+		return nil, fmt.Errorf("failed to parse remote retry policy %v: %w", path, err)
+	}
+	for _, r := range []*ppb.RemoteRetryRule{p.GetTransient(), p.GetWorkerLost(), p.GetDeadlineExceeded(), p.GetNonZeroExit()} {
+		if r.GetMaxRetries() < 0 || r.GetBackoffMs() < 0 || r.GetMaxBackoffMs() < 0 {
+			return nil, fmt.Errorf("remote retry rule %v has negative values", r)
+		}
+	}
+	return p, nil
+}
+
+// classifyRemoteError returns the class of the error of a remote execution result, which is
+// ERROR_CLASS_NONE for successful results and errors that are not retryable.
+func classifyRemoteError(res *command.Result) lpb.RemoteErrorClass {
+	if res == nil {
+		return lpb.RemoteErrorClass_ERROR_CLASS_NONE
+	}
+	switch res.Status {
+	case command.TimeoutResultStatus:
+		return lpb.RemoteErrorClass_ERROR_CLASS_DEADLINE_EXCEEDED
+	case command.NonZeroExitResultStatus:
+		if res.ExitCode == sigkillExitCode {
+			return lpb.RemoteErrorClass_ERROR_CLASS_WORKER_LOST
+		}
+		return lpb.RemoteErrorClass_ERROR_CLASS_NON_ZERO_EXIT
+	case command.RemoteErrorResultStatus:
+		// Errors without a gRPC status, e.g. context cancellations, are not retryable.
+		st, ok := status.FromError(res.Err)
+		if !ok {
+			return lpb.RemoteErrorClass_ERROR_CLASS_NONE
+		}
+		switch st.Code() {
+		case codes.Unavailable, codes.Internal, codes.Unknown:
+			return lpb.RemoteErrorClass_ERROR_CLASS_TRANSIENT
+		case codes.ResourceExhausted, codes.Aborted:
+			return lpb.RemoteErrorClass_ERROR_CLASS_WORKER_LOST
+		case codes.DeadlineExceeded:
+			return lpb.RemoteErrorClass_ERROR_CLASS_DEADLINE_EXCEEDED
+		}
+	}
+	return lpb.RemoteErrorClass_ERROR_CLASS_NONE
+}
+
+// retryRule returns the rule of the policy for the given class of errors, or nil if they are not
+// retried.
+func retryRule(p *ppb.RemoteRetryPolicy, class lpb.RemoteErrorClass) *ppb.RemoteRetryRule {
+	switch class {
+	case lpb.RemoteErrorClass_ERROR_CLASS_TRANSIENT:
+		return p.GetTransient()
+	case lpb.RemoteErrorClass_ERROR_CLASS_WORKER_LOST:
+		return p.GetWorkerLost()
+	case lpb.RemoteErrorClass_ERROR_CLASS_DEADLINE_EXCEEDED:
+		return p.GetDeadlineExceeded()
+	case lpb.RemoteErrorClass_ERROR_CLASS_NON_ZERO_EXIT:
+		return p.GetNonZeroExit()
+	}
+	return nil
+}
+
+// retryBackoff returns the delay before the given retry, starting at 1, of an error the rule
+// applies to.
+func retryBackoff(r *ppb.RemoteRetryRule, retry int) time.Duration {
+	backoff := time.Duration(r.GetBackoffMs()) * time.Millisecond
+	max := time.Duration(r.GetMaxBackoffMs()) * time.Millisecond
+	for i := 1; i < retry; i++ {
+		if max > 0 && backoff >= max {
+			break
+		}
+		backoff *= 2
+	}
+	if max > 0 && backoff > max {
+		backoff = max
+	}
+	return backoff
+}
+
+// runRemoteWithRetries executes the action remotely by calling attempt, and retries failed attempts
+// according to the remote retry policy of the server. Each class of errors has its own number of
+// retries. Once an error escalated the platform of the action, later attempts keep the escalated
+// platform.
+func (s *Server) runRemoteWithRetries(ctx context.Context, a *action, attempt func(ctx context.Context)) {
+	attempt(ctx)
+	if s.RetryPolicy == nil {
+		return
+	}
+	var attempts []*lpb.RemoteAttempt
+	var overrides map[string]string
+	retries := make(map[lpb.RemoteErrorClass]int32)
+	for {
+		class := classifyRemoteError(a.res)
+		att := &lpb.RemoteAttempt{
+			Attempt:           int64(len(attempts) + 1),
+			Result:            command.ResultToProto(a.res),
+			ErrorClass:        class,
+			ActionDigest:      a.rec.RemoteMetadata.GetActionDigest(),
+			PlatformOverrides: overrides,
+		}
+		attempts = append(attempts, att)
+		rule := retryRule(s.RetryPolicy, class)
+		if rule == nil || retries[class] >= rule.GetMaxRetries() || ctx.Err() != nil {
+			break
+		}
+		retries[class]++
+		backoff := retryBackoff(rule, int(retries[class]))
+		att.BackoffMs = backoff.Milliseconds()
+		log.Warningf("%v: Remote execution attempt %v failed with %v error %+v, retrying in %v.",
+			a.cmd.Identifiers.ExecutionID, att.Attempt, class, a.res, backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if len(rule.GetEscalatePlatform()) > 0 {
+			escalated := make(map[string]string, len(overrides)+len(rule.GetEscalatePlatform()))
+			for k, v := range overrides {
+				escalated[k] = v
+			}
+			for k, v := range rule.GetEscalatePlatform() {
+				escalated[k] = v
+			}
+			overrides = escalated
+			platform := make(map[string]string, len(a.cmd.Platform)+len(overrides))
+			for k, v := range a.cmd.Platform {
+				platform[k] = v
+			}
+			for k, v := range overrides {
+				platform[k] = v
+			}
+			a.cmd.Platform = platform
+		}
+		a.oe = outerr.NewRecordingOutErr()
+		attempt(ctx)
+	}
+	if len(attempts) > 1 {
+		a.rec.RemoteMetadata.Attempts = attempts
+	}
+}
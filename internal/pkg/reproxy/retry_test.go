@@ -0,0 +1,139 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reproxy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	lpb "github.com/bazelbuild/reclient/api/log"
+	ppb "github.com/bazelbuild/reclient/api/proxy"
+)
+
+func TestClassifyRemoteError(t *testing.T) {
+	tests := []struct {
+		name string
+		res  *command.Result
+		want lpb.RemoteErrorClass
+	}{
+		{
+			name: "Success",
+			res:  command.NewResultFromExitCode(0),
+			want: lpb.RemoteErrorClass_ERROR_CLASS_NONE,
+		},
+		{
+			name: "Unavailable",
+			res:  command.NewRemoteErrorResult(status.Error(codes.Unavailable, "unavailable")),
+			want: lpb.RemoteErrorClass_ERROR_CLASS_TRANSIENT,
+		},
+		{
+			name: "WrappedInternal",
+			res:  command.NewRemoteErrorResult(fmt.Errorf("execute: %w", status.Error(codes.Internal, "internal"))),
+			want: lpb.RemoteErrorClass_ERROR_CLASS_TRANSIENT,
+		},
+		{
+			name: "ResourceExhausted",
+			res:  command.NewRemoteErrorResult(status.Error(codes.ResourceExhausted, "out of memory")),
+			want: lpb.RemoteErrorClass_ERROR_CLASS_WORKER_LOST,
+		},
+		{
+			name: "Evicted",
+			res:  command.NewRemoteErrorResult(status.Error(codes.Aborted, "worker evicted")),
+			want: lpb.RemoteErrorClass_ERROR_CLASS_WORKER_LOST,
+		},
+		{
+			name: "Killed",
+			res:  command.NewResultFromExitCode(sigkillExitCode),
+			want: lpb.RemoteErrorClass_ERROR_CLASS_WORKER_LOST,
+		},
+		{
+			name: "DeadlineExceeded",
+			res:  command.NewRemoteErrorResult(status.Error(codes.DeadlineExceeded, "deadline exceeded")),
+			want: lpb.RemoteErrorClass_ERROR_CLASS_DEADLINE_EXCEEDED,
+		},
+		{
+			name: "Timeout",
+			res:  command.NewTimeoutResult(),
+			want: lpb.RemoteErrorClass_ERROR_CLASS_DEADLINE_EXCEEDED,
+		},
+		{
+			name: "NonZeroExit",
+			res:  command.NewResultFromExitCode(1),
+			want: lpb.RemoteErrorClass_ERROR_CLASS_NON_ZERO_EXIT,
+		},
+		{
+			name: "PermissionDenied",
+			res:  command.NewRemoteErrorResult(status.Error(codes.PermissionDenied, "denied")),
+			want: lpb.RemoteErrorClass_ERROR_CLASS_NONE,
+		},
+		{
+			name: "NoStatus",
+			res:  command.NewRemoteErrorResult(errors.New("context canceled")),
+			want: lpb.RemoteErrorClass_ERROR_CLASS_NONE,
+		},
+		{
+			name: "LocalError",
+			res:  command.NewLocalErrorResult(errors.New("input processing failed")),
+			want: lpb.RemoteErrorClass_ERROR_CLASS_NONE,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := classifyRemoteError(tc.res); got != tc.want {
+				t.Errorf("classifyRemoteError(%+v) = %v, want %v", tc.res, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	r := &ppb.RemoteRetryRule{BackoffMs: 100, MaxBackoffMs: 300}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+	for i, w := range want {
+		if got := retryBackoff(r, i+1); got != w {
+			t.Errorf("retryBackoff(%v) = %v, want %v", i+1, got, w)
+		}
+	}
+}
+
+func TestReadRemoteRetryPolicy(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.textproto")
+	if err := os.WriteFile(valid, []byte(`worker_lost { max_retries: 1 escalate_platform { key: "machine-type" value: "large" } }`), 0644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	p, err := ReadRemoteRetryPolicy(valid)
+	if err != nil {
+		t.Fatalf("ReadRemoteRetryPolicy() returned error: %v", err)
+	}
+	if got := p.GetWorkerLost().GetEscalatePlatform()["machine-type"]; got != "large" {
+		t.Errorf("ReadRemoteRetryPolicy() escalated machine-type to %q, want large", got)
+	}
+	invalid := filepath.Join(dir, "invalid.textproto")
+	if err := os.WriteFile(invalid, []byte(`transient { max_retries: -1 }`), 0644); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	if _, err := ReadRemoteRetryPolicy(invalid); err == nil {
+		t.Errorf("ReadRemoteRetryPolicy() returned no error for negative max_retries")
+	}
+}
@@ -30,11 +30,14 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/bazelbuild/reclient/internal/pkg/actioncache"
+	"github.com/bazelbuild/reclient/internal/pkg/diskcas"
 	"github.com/bazelbuild/reclient/internal/pkg/event"
 	"github.com/bazelbuild/reclient/internal/pkg/features"
 	"github.com/bazelbuild/reclient/internal/pkg/interceptors"
 	"github.com/bazelbuild/reclient/internal/pkg/labels"
 	"github.com/bazelbuild/reclient/internal/pkg/logger"
+	"github.com/bazelbuild/reclient/internal/pkg/otlptrace"
 	"github.com/bazelbuild/reclient/internal/pkg/pathtranslator"
 	"github.com/bazelbuild/reclient/internal/pkg/protoencoding"
 	"github.com/bazelbuild/reclient/internal/pkg/version"
@@ -47,8 +50,10 @@ import (
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/rexec"
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/uploadinfo"
 	"github.com/google/uuid"
+	"golang.org/x/sync/semaphore"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 
 	lpb "github.com/bazelbuild/reclient/api/log"
 	ppb "github.com/bazelbuild/reclient/api/proxy"
@@ -75,6 +80,9 @@ const (
 const (
 	// ReclientTimeoutExitCode is an exit code corresponding to a timeout within reproxy
 	ReclientTimeoutExitCode = command.RemoteErrorExitCode + /*SIGALRM=*/ 14
+	// LocalTimeoutExitCode is an exit code corresponding to the expiration of the local execution
+	// timeout of a command
+	LocalTimeoutExitCode = command.LocalErrorExitCode + /*SIGALRM=*/ 14
 )
 
 var (
@@ -86,6 +94,8 @@ var (
 		"windows": "Windows",
 	}
 	pollTime = time.Second * 10
+	// errCommandCanceled is the cause of the cancellation of actions canceled by CancelCommand.
+	errCommandCanceled = errors.New("command canceled by the client")
 	// AllowedIPTimeouts is the max number of IP timeouts before failing the build
 	AllowedIPTimeouts = int64(7)
 )
@@ -96,6 +106,9 @@ type Server struct {
 	FileMetadataStore         filemetadata.Cache
 	REClient                  *rexec.Client
 	LocalPool                 *LocalPool
+	LocalCache                *actioncache.Cache  // Persistent local action cache, nil if disabled.
+	DiskCAS                   *diskcas.CAS        // Local CAS in front of remote downloads, nil if disabled.
+	TraceExporter             *otlptrace.Exporter // Exporter of the actions as OpenTelemetry traces, nil if disabled.
 	Logger                    *logger.Logger
 	KeepLastRecords           int
 	CacheSilo                 string
@@ -107,21 +120,41 @@ type Server struct {
 	FailEarlyMinActionCount   int64
 	FailEarlyMinFallbackRatio float64
 	FailEarlyWindow           time.Duration
+	BreakerErrorRatio         float64       // Ratio of unhealthy remote executions above which remote_local_fallback and racing actions run locally, 0 to disable.
+	BreakerMinActions         int64         // Minimum number of remote executions in the window before the breaker can trip.
+	BreakerWindow             time.Duration // Window of remote executions to consider, 0 for all of them.
+	BreakerSlowThreshold      time.Duration // Latency above which remote executions count as unhealthy, 0 to ignore latency.
+	BreakerOpenDuration       time.Duration // Time remote execution is skipped for before probing it.
+	BreakerProbes             int           // Number of successful probes after which remote execution resumes.
+	RetryPolicy               *ppb.RemoteRetryPolicy
 	RacingBias                float64
 	DownloadTmp               string
 	MaxHoldoff                time.Duration // Maximum amount of time to wait for downloads before starting racing.
+	ValidateOutputs           bool          // Whether to report declared outputs missing from successful results.
+	DetectUndeclaredOutputs   bool          // Whether to report undeclared side outputs of local executions.
+	FailOnInvalidOutputs      bool          // Whether to fail actions with missing or undeclared outputs.
+	LogInputManifest          bool          // Whether to log the digests of the inputs of each action.
 	StartupCancelFn           func()
+	ReloadConfigFn            func() (map[string]string, error)
+	tmu                       sync.RWMutex
+	startFailBuildMonitor     func() // Set while fail build conditions are not monitored because fail early is disabled.
 	numActions                *windowedCount
 	numFallbacks              *windowedCount
+	breaker                   *circuitBreaker
 	numIPTimeouts             *atomic.Int64
 	numActiveActions          *atomic.Int32
 	failBuild                 bool
 	failBuildMu               sync.RWMutex
 	failBuildErr              error
 	activeActions             sync.Map
+	inFlight                  inFlightActions
+	watchers                  actionWatchers
 	records                   []*lpb.LogRecord
+	numRecords                int64
 	rmu                       sync.Mutex
 	wgShutdown                sync.WaitGroup
+	wgCompare                 sync.WaitGroup // Compare mode reruns running in the background.
+	compareSlots              *semaphore.Weighted
 	shutdownCmd               chan bool
 	shutdownOnce              sync.Once
 	drain                     chan bool
@@ -135,9 +168,9 @@ type Server struct {
 	maxThreads                int32
 }
 
+// ctxWithCause is a context whose Err returns the cause of its cancellation.
 type ctxWithCause struct {
 	context.Context
-	cause error
 }
 
 var (
@@ -146,19 +179,15 @@ var (
 )
 
 func (c ctxWithCause) Err() error {
-	if c.cause != nil {
-		return c.cause
+	if c.Context.Err() == nil {
+		return nil
 	}
-	return c.Context.Err()
+	return context.Cause(c.Context)
 }
 
 func cancelWithCause(ctx context.Context) (context.Context, func(error)) {
-	cancelCtx, cancel := context.WithCancel(ctx)
-	withCause := &ctxWithCause{Context: cancelCtx}
-	return withCause, func(cause error) {
-		withCause.cause = cause
-		cancel()
-	}
+	cancelCtx, cancel := context.WithCancelCause(ctx)
+	return ctxWithCause{Context: cancelCtx}, cancel
 }
 
 // Init initializes internal state and should only be called once.
@@ -169,8 +198,10 @@ func (s *Server) Init() {
 	s.cleanupDone = make(chan bool)
 	s.numActions = &windowedCount{window: s.FailEarlyWindow}
 	s.numFallbacks = &windowedCount{window: s.FailEarlyWindow}
+	s.breaker = newCircuitBreaker(s)
 	s.numIPTimeouts = &atomic.Int64{}
 	s.numActiveActions = &atomic.Int32{}
+	s.compareSlots = semaphore.NewWeighted(int64(runtime.NumCPU()))
 	prevMaxThreads := debug.SetMaxThreads(10000)
 	if prevMaxThreads != 10000 {
 		debug.SetMaxThreads(prevMaxThreads)
@@ -235,16 +266,20 @@ func (s *Server) maybeUnblockStartup() {
 // MonitorFailBuildConditions monitors fail early conditions. If conditions such as
 // ratio of fallbacks to total actions periodically or number of IP timeouts are exceeded,
 // it sets failBuild flag and failBuildErr.
-// If fail early is disabled the function returns immediately.
-// Should be run in a goroutine.
+// Returns right away if fail early is disabled, in which case monitoring starts once SetFailEarly
+// enables it. Should be run in a goroutine.
 func (s *Server) MonitorFailBuildConditions(ctx context.Context) {
 	s.monitorFailBuildConditions(ctx, pollTime)
 }
 
 func (s *Server) monitorFailBuildConditions(ctx context.Context, pollTime time.Duration) {
+	s.tmu.Lock()
 	if s.FailEarlyMinActionCount == 0 || s.FailEarlyMinFallbackRatio == 0 {
+		s.startFailBuildMonitor = func() { go s.monitorFailBuildConditions(ctx, pollTime) }
+		s.tmu.Unlock()
 		return
 	}
+	s.tmu.Unlock()
 	ticker := time.NewTicker(pollTime)
 	for {
 		select {
@@ -258,35 +293,52 @@ func (s *Server) monitorFailBuildConditions(ctx context.Context, pollTime time.D
 
 type windowedCount struct {
 	cnt    atomic.Int64
+	mu     sync.Mutex
 	window time.Duration
 }
 
 func (wc *windowedCount) Add(inc int64) {
 	wc.cnt.Add(inc)
-	if wc.window != 0 {
-		time.AfterFunc(wc.window, func() {
+	wc.mu.Lock()
+	window := wc.window
+	wc.mu.Unlock()
+	if window != 0 {
+		time.AfterFunc(window, func() {
 			wc.cnt.Add(-inc)
 		})
 	}
 }
 
+// setWindow changes the window of the counts added from now on.
+func (wc *windowedCount) setWindow(window time.Duration) {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+	wc.window = window
+}
+
 func (wc *windowedCount) Load() int64 {
 	return wc.cnt.Load()
 }
 
 func (s *Server) checkFailBuild() {
+	s.tmu.RLock()
+	minActionCount, minFallbackRatio := s.FailEarlyMinActionCount, s.FailEarlyMinFallbackRatio
+	s.tmu.RUnlock()
+	if minActionCount == 0 || minFallbackRatio == 0 {
+		return
+	}
 	nf := s.numFallbacks.Load()
 	na := s.numActions.Load()
 	nt := s.numIPTimeouts.Load()
-	if nt <= AllowedIPTimeouts && na < s.FailEarlyMinActionCount {
+	if nt <= AllowedIPTimeouts && na < minActionCount {
 		return
 	}
-	if nt > AllowedIPTimeouts || float64(nf)/float64(na) >= s.FailEarlyMinFallbackRatio {
+	if nt > AllowedIPTimeouts || float64(nf)/float64(na) >= minFallbackRatio {
 		s.failBuildMu.Lock()
 		defer s.failBuildMu.Unlock()
 		// Set the switch to fail all the new actions...
 		s.failBuild = true
-		s.failBuildErr = s.getFailBuildErr(nf, na, nt)
+		s.failBuildErr = s.getFailBuildErr(nf, na, nt, minFallbackRatio)
 		// .. and cancel the actions that are already started.
 		s.activeActions.Range(func(key, val any) bool {
 			if action, ok := val.(*action); ok {
@@ -304,19 +356,66 @@ func (s *Server) shouldFailBuild() (bool, error) {
 	return s.failBuild, s.failBuildErr
 }
 
-func (s *Server) getFailBuildErr(nf, na, nt int64) error {
+func (s *Server) getFailBuildErr(nf, na, nt int64, minFallbackRatio float64) error {
 	if nt > AllowedIPTimeouts {
 		return fmt.Errorf("this build has encountered too many action input processing timeouts. Number of timeouts %v > %v",
 			nt, AllowedIPTimeouts)
 	}
-	if float64(nf)/float64(na) >= s.FailEarlyMinFallbackRatio {
+	if float64(nf)/float64(na) >= minFallbackRatio {
 		return fmt.Errorf(
 			"this build has encountered too many local fallbacks. This means that the ratio of actions that failed remotely is equal to or above the preconfigured threshold of %.1f%%",
-			s.FailEarlyMinFallbackRatio*100)
+			minFallbackRatio*100)
 	}
 	return nil
 }
 
+// NumActiveActions returns the number of actions currently being processed.
+func (s *Server) NumActiveActions() int32 {
+	return s.numActiveActions.Load()
+}
+
+// SetRacingBias changes the racing bias of the actions started from now on.
+func (s *Server) SetRacingBias(bias float64) {
+	s.tmu.Lock()
+	defer s.tmu.Unlock()
+	s.RacingBias = bias
+}
+
+func (s *Server) racingBias() float64 {
+	s.tmu.RLock()
+	defer s.tmu.RUnlock()
+	return s.RacingBias
+}
+
+// SetFailEarly changes the fail early thresholds. Setting minActionCount or minFallbackRatio to 0
+// disables fail early. The window only applies to the actions started from now on.
+func (s *Server) SetFailEarly(minActionCount int64, minFallbackRatio float64, window time.Duration) {
+	s.tmu.Lock()
+	defer s.tmu.Unlock()
+	s.FailEarlyMinActionCount = minActionCount
+	s.FailEarlyMinFallbackRatio = minFallbackRatio
+	s.FailEarlyWindow = window
+	s.numActions.setWindow(window)
+	s.numFallbacks.setWindow(window)
+	if minActionCount != 0 && minFallbackRatio != 0 && s.startFailBuildMonitor != nil {
+		s.startFailBuildMonitor()
+		s.startFailBuildMonitor = nil
+	}
+}
+
+// ReloadConfig re-reads the configuration of the server and applies the changes that can be made
+// while it is running.
+func (s *Server) ReloadConfig(ctx context.Context, req *ppb.ReloadConfigRequest) (*ppb.ReloadConfigResponse, error) {
+	if s.ReloadConfigFn == nil {
+		return nil, status.Error(codes.Unimplemented, "reloading the configuration is not supported by this server")
+	}
+	changed, err := s.ReloadConfigFn()
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to reload the configuration: %v", err)
+	}
+	return &ppb.ReloadConfigResponse{ChangedFlags: changed}, nil
+}
+
 // WaitForShutdownCommand returns a channel that is closed when a shutdown command is recieved.
 func (s *Server) WaitForShutdownCommand() <-chan bool {
 	return s.shutdownCmd
@@ -397,6 +496,35 @@ func (s *Server) waitForStarted(ctx context.Context) error {
 
 // RunCommand runs a command according to the parameters defined in the RunRequest.
 func (s *Server) RunCommand(ctx context.Context, req *ppb.RunRequest) (*ppb.RunResponse, error) {
+	return s.runCommand(ctx, req, nil)
+}
+
+// RunCommandStream runs a command according to the parameters defined in the RunRequest, streaming
+// the output of local execution as it is produced and the remaining output in chunks.
+func (s *Server) RunCommandStream(req *ppb.RunRequest, srv ppb.Commands_RunCommandStreamServer) error {
+	stream := &outputStream{send: srv.Send}
+	resp, err := s.runCommand(srv.Context(), req, stream)
+	if err != nil {
+		return err
+	}
+	return stream.sendFinal(resp)
+}
+
+// CancelCommand cancels the running command with the given execution ID.
+func (s *Server) CancelCommand(ctx context.Context, req *ppb.CancelCommandRequest) (*ppb.CancelCommandResponse, error) {
+	if req.GetExecutionId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "no execution_id provided in the request")
+	}
+	val, ok := s.activeActions.Load(req.GetExecutionId())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no running command with execution_id %v", req.GetExecutionId())
+	}
+	log.Infof("%v: Canceling command at the request of the client", req.GetExecutionId())
+	val.(*action).cancelFunc(errCommandCanceled)
+	return &ppb.CancelCommandResponse{}, nil
+}
+
+func (s *Server) runCommand(ctx context.Context, req *ppb.RunRequest, stream *outputStream) (*ppb.RunResponse, error) {
 	log.V(1).Infof("Received RunRequest:\n%s", protoencoding.TextWithIndent.Format(req))
 	// Intentionally overwriting ctx so that all function calls below will be canceled at server shutdown.
 	ctx = s.withServerDrainCancel(ctx)
@@ -451,7 +579,7 @@ func (s *Server) RunCommand(ctx context.Context, req *ppb.RunRequest) (*ppb.RunR
 	if req.GetExecutionOptions().GetLogEnvironment() {
 		localMetadata.Environment = sliceToMap(cmdEnv, "=")
 	}
-	rec := s.Logger.LogActionStart()
+	rec := s.Logger.LogActionStartForInvocation(cmd.Identifiers.InvocationID)
 	rec.LocalMetadata = localMetadata
 	for k, t := range req.GetMetadata().GetEventTimes() {
 		if t.To == nil {
@@ -505,10 +633,24 @@ func (s *Server) RunCommand(ctx context.Context, req *ppb.RunRequest) (*ppb.RunR
 		toolchainInputs: ti,
 		cmdEnvironment:  cmdEnv,
 		cancelFunc:      cancel,
-		racingBias:      s.RacingBias,
+		racingBias:      s.racingBias(),
 		downloadRegex:   req.GetExecutionOptions().GetDownloadRegex(),
 		downloadTmp:     s.DownloadTmp,
 		atomicDownloads: req.GetExecutionOptions().GetEnableAtomicDownloads(),
+		inFlight:        &s.inFlight,
+		diskCAS:         s.DiskCAS,
+		stream:          stream,
+		start:           start,
+		outputChecks: outputChecks{
+			validate:         s.ValidateOutputs,
+			detectUndeclared: s.DetectUndeclaredOutputs,
+			failOnInvalid:    s.FailOnInvalidOutputs,
+			remoteDisabled:   s.RemoteDisabled,
+		},
+	}
+	a.progress = newActionProgress(&s.watchers, cmd, req.Labels, a.execStrategy)
+	if stream != nil {
+		stream.executionID = executionID
 	}
 	if s.numActiveActions.Load() >= s.maxThreads {
 		// By default, go runtime only hold maximum 10K M (machines), Once the num
@@ -520,27 +662,59 @@ func (s *Server) RunCommand(ctx context.Context, req *ppb.RunRequest) (*ppb.RunR
 		// See: https://github.com/bazelbuild/remote-apis-sdks/blob/8a36686a6350d32b9f40c213290b107a59e2ab00/go/pkg/retry/retry.go#L89
 		return nil, status.Error(codes.Unavailable, fmt.Sprintf("%v: Reproxy currently has high num of in-flight actions. Please retry with a backoff.", executionID))
 	}
+	a.progress.start()
 	s.activeActions.Store(executionID, a)
 	s.numActiveActions.Add(1)
 	defer s.numActiveActions.Add(-1)
 	defer s.activeActions.Delete(executionID)
+	if stream != nil {
+		// Let the client know the execution ID early, so that it can cancel the command.
+		stream.sendStarted()
+	}
 
 	if rand.Intn(100) < features.GetConfig().ExperimentalCacheMissRate {
 		a.rOpt.AcceptCached = false
 	}
 
 	s.runAction(aCtx, a)
-	if a.compare {
-		s.rerunAction(aCtx, a)
+	// Outputs of results that were not cached by reproxy are validated here.
+	a.validateOutputs()
+	// When the result comes from remote execution, the reruns only affect the verification results
+	// and are run in the background to keep them off the critical path of the build.
+	compareInBackground := a.compare && !s.RemoteDisabled &&
+		(a.execStrategy == ppb.ExecutionStrategy_REMOTE || a.execStrategy == ppb.ExecutionStrategy_REMOTE_LOCAL_FALLBACK)
+	if a.compare && !compareInBackground {
+		s.rerunAction(aCtx, a, false)
+	}
+	if errors.Is(aCtx.Err(), errCommandCanceled) && !a.res.IsOk() {
+		a.res = &command.Result{
+			Status:   command.InterruptedResultStatus,
+			ExitCode: command.InterruptedExitCode,
+			Err:      errCommandCanceled,
+		}
 	}
 
-	s.logRecord(a, start)
-	oe := a.oe.(*outerr.RecordingOutErr)
-	fallbackOE := a.fallbackOE.(*outerr.RecordingOutErr)
 	var logRecord *lpb.LogRecord
-	if req.GetExecutionOptions().GetIncludeActionLog() {
-		logRecord = a.rec.LogRecord
+	if compareInBackground && aCtx.Err() == nil {
+		s.finalizeRecord(a, start)
+		// The action is complete for the client, so it is reported as such right away; only its
+		// record is logged once the reruns are done.
+		s.Logger.EndAction(a.rec)
+		a.progress.complete(a.rec)
+		if req.GetExecutionOptions().GetIncludeActionLog() {
+			// The record is still updated by the reruns after the response is sent.
+			logRecord = proto.Clone(a.rec.LogRecord).(*lpb.LogRecord)
+		}
+		s.compareInBackground(a)
+	} else {
+		a.removeInOutSnapshot()
+		s.logRecord(a, start)
+		if req.GetExecutionOptions().GetIncludeActionLog() {
+			logRecord = a.rec.LogRecord
+		}
 	}
+	oe := a.oe.(*outerr.RecordingOutErr)
+	fallbackOE := a.fallbackOE.(*outerr.RecordingOutErr)
 	if !a.res.IsOk() {
 		log.Errorf("%v: Execution failed with %+v", executionID, a.res)
 		log.Flush()
@@ -559,6 +733,9 @@ func (s *Server) RunCommand(ctx context.Context, req *ppb.RunRequest) (*ppb.RunR
 	if oe != nil {
 		oeStdout = oe.Stdout()
 		oeStderr = oe.Stderr()
+		if stream != nil {
+			oeStdout, oeStderr = stream.unsent(oe)
+		}
 	}
 	if fallbackOE != nil {
 		fallbackStdout = fallbackOE.Stdout()
@@ -622,11 +799,6 @@ func (s *Server) addLabelDigestToCommandID(cmd *command.Command, req *ppb.RunReq
 	return nil
 }
 
-// GetRecords returns the latest saved LogRecords.
-func (s *Server) GetRecords(ctx context.Context, req *ppb.GetRecordsRequest) (*ppb.GetRecordsResponse, error) {
-	return &ppb.GetRecordsResponse{Records: s.records}, nil
-}
-
 // AddProxyEvents saves the provided event times to the proxy level metrics.
 // This method returns immediately and event times are added asynchronously to not block startup.
 func (s *Server) AddProxyEvents(ctx context.Context, req *ppb.AddProxyEventsRequest) (*ppb.AddProxyEventsResponse, error) {
@@ -640,6 +812,13 @@ func (s *Server) AddProxyEvents(ctx context.Context, req *ppb.AddProxyEventsRequ
 }
 
 func (s *Server) logRecord(a *action, start time.Time) {
+	s.finalizeRecord(a, start)
+	s.emitRecord(a)
+	a.progress.complete(a.rec)
+}
+
+// finalizeRecord fills in the final command, result and completion status of the action record.
+func (s *Server) finalizeRecord(a *action, start time.Time) {
 	a.rec.Command = command.ToProto(a.cmd)
 	if a.res != nil {
 		a.rec.Result = command.ResultToProto(a.res)
@@ -650,11 +829,17 @@ func (s *Server) logRecord(a *action, start time.Time) {
 	}
 	a.rec.RecordEventTime(event.ProxyExecution, start)
 	logger.AddCompletionStatus(a.rec, a.execStrategy)
+}
+
+// emitRecord logs the finalized record of the action and keeps it for GetRecords. The completion
+// of the action is reported to WatchActions streams separately.
+func (s *Server) emitRecord(a *action) {
 	s.Logger.Log(a.rec)
 	if s.KeepLastRecords > 0 {
 		s.rmu.Lock()
 		defer s.rmu.Unlock()
 		s.records = append(s.records, a.rec.LogRecord)
+		s.numRecords++
 		if len(s.records) > s.KeepLastRecords {
 			s.records = s.records[1:len(s.records)]
 		}
@@ -662,12 +847,17 @@ func (s *Server) logRecord(a *action, start time.Time) {
 	if s.Forecast != nil {
 		s.Forecast.RecordSample(a)
 	}
+	s.TraceExporter.ExportRecord(a.rec.LogRecord)
 }
 
 func (s *Server) populateCommandIO(ctx context.Context, a *action) (err error) {
+	a.progress.setPhase(ppb.ActionPhase_INPUT_PROCESSING)
 	if err = a.populateCommandIO(ctx, s.InputProcessor); errors.Is(err, inputprocessor.ErrIPTimeout) {
 		s.numIPTimeouts.Add(1)
 	}
+	if err == nil && s.LogInputManifest {
+		a.rec.InputManifest = a.inputManifest()
+	}
 	return err
 }
 
@@ -681,6 +871,17 @@ func (s *Server) runAction(ctx context.Context, a *action) {
 	}
 	defer s.numActions.Add(1)
 	if s.RemoteDisabled {
+		if s.LocalCache != nil {
+			s.runLocalCached(ctx, a)
+			return
+		}
+		// The sandbox only exposes the inputs of the action, so they have to be computed
+		// even though nothing is executed remotely.
+		if a.lOpt.GetPlatform() == ppb.LocalExecutionOptions_SANDBOX {
+			if err := s.populateCommandIO(ctx, a); err != nil {
+				return
+			}
+		}
 		a.runLocal(ctx, s.LocalPool)
 		return
 	}
@@ -707,11 +908,16 @@ func (s *Server) runAction(ctx context.Context, a *action) {
 		}
 		return
 	case ppb.ExecutionStrategy_REMOTE:
-		// TODO: add support for compare mode in remote execution.
 		s.runRemote(ctx, a)
 		return
 	case ppb.ExecutionStrategy_REMOTE_LOCAL_FALLBACK:
+		report, ok := s.breaker.acquire()
+		if !ok {
+			s.runLocalBypassingRemote(ctx, a)
+			return
+		}
 		s.runRemote(ctx, a)
+		report(a.res)
 		if !a.res.IsOk() {
 			roe := a.oe.(*outerr.RecordingOutErr)
 			log.Warningf("%v: Remote execution failed with %+v, falling back to local.\n stdout: %s\n stderr: %s",
@@ -734,7 +940,10 @@ func (s *Server) runAction(ctx context.Context, a *action) {
 	a.res = command.NewLocalErrorResult(fmt.Errorf("%v: invalid execution strategy %v", a.cmd.Identifiers.ExecutionID, a.execStrategy))
 }
 
-func (s *Server) rerunAction(ctx context.Context, a *action) {
+// rerunAction reruns the action locally and remotely for compare mode. Reruns in the background,
+// which happen after the result of the action was returned, are run in a scratch exec root so that
+// they do not modify the exec root in use by the build.
+func (s *Server) rerunAction(ctx context.Context, a *action, background bool) {
 	local := []*lpb.RerunMetadata{}
 	remote := []*lpb.RerunMetadata{}
 	var rerunWaiter sync.WaitGroup
@@ -748,9 +957,21 @@ func (s *Server) rerunAction(ctx context.Context, a *action) {
 		act.rOpt.AcceptCached = false
 		act.rOpt.DoNotCache = true
 		act.rOpt.DownloadOutputs = false
+		act.downloadRegex = ""
+		act.atomicDownloads = false
 		attemptNum := i + 1
 		go func() {
 			defer rerunWaiter.Done()
+			if background && a.inOutSnapshot != "" {
+				// The in-out files in the exec root were already overwritten by the outputs.
+				cleanup, err := act.useScratchExecRoot(ctx, s.REClient)
+				if err != nil {
+					log.Warningf("%v: Failed to set up exec root for remote rerun attempt:%v: %v", act.cmd.Identifiers.ExecutionID, attemptNum, err)
+					c <- &lpb.RerunMetadata{Attempt: int64(attemptNum), Result: command.ResultToProto(command.NewLocalErrorResult(err))}
+					return
+				}
+				defer cleanup()
+			}
 			act.runRemote(ctx, s.REClient)
 			if !act.res.IsOk() {
 				log.Warningf("%v: Execution failed during remote rerun attempt:%v with %v", act.cmd.Identifiers.ExecutionID, attemptNum, act.res)
@@ -780,12 +1001,23 @@ func (s *Server) rerunAction(ctx context.Context, a *action) {
 		return remote[i].Attempt < remote[j].Attempt
 	})
 
-	restoreInOutFiles := a.stashInputOutputFiles()
+	restoreInOutFiles := func() {}
+	if !background {
+		restoreInOutFiles = a.stashInputOutputFiles()
+	}
 	for i := 0; i < a.numLocalReruns; i++ {
 		act := localActionDupes[i]
 		attemptNum := i + 1
-		act.clearOutputsCache()
-		if a.compare {
+		cleanup := func() {}
+		if background {
+			var err error
+			if cleanup, err = act.useScratchExecRoot(ctx, s.REClient); err != nil {
+				log.Warningf("%v: Failed to set up exec root for local rerun attempt:%v: %v", act.cmd.Identifiers.ExecutionID, attemptNum, err)
+				local = append(local, &lpb.RerunMetadata{Attempt: int64(attemptNum), Result: command.ResultToProto(command.NewLocalErrorResult(err))})
+				continue
+			}
+		} else {
+			act.clearOutputsCache()
 			restoreInOutFiles()
 			restoreInOutFiles = a.stashInputOutputFiles()
 		}
@@ -811,6 +1043,7 @@ func (s *Server) rerunAction(ctx context.Context, a *action) {
 			toUpload = append(toUpload, ch)
 		}
 		_, _, err = s.REClient.GrpcClient.UploadIfMissing(ctx, toUpload...)
+		cleanup()
 		if err != nil {
 			log.Warningf("Error uploading artifacts during local rerun attempt:%v with %v", attemptNum, err)
 		}
@@ -862,27 +1095,63 @@ func (s *Server) runLERC(ctx context.Context, a *action) {
 	if !a.lOpt.GetAcceptCached() {
 		a.runLocal(ctx, s.LocalPool)
 		a.cacheLocal()
+		s.updateLocalCacheLERC(a)
 		return
 	}
 	a.getCachedResult(ctx)
 	if a.res == nil || !a.res.IsOk() {
+		if !a.lOpt.GetDoNotCache() && a.execContext.Metadata != nil {
+			release, cached := a.dedupInFlight(ctx, a.execContext.Metadata.ActionDigest, func() bool {
+				a.getCachedResult(ctx)
+				return a.res != nil && a.res.IsOk()
+			})
+			defer release()
+			if cached {
+				return
+			}
+		}
+		// The remote cache might be unreachable or might not have the result yet, so fall back to
+		// the local action cache before executing the action.
+		if s.LocalCache != nil && a.execContext.Metadata != nil && a.execContext.Metadata.ActionDigest.Size > 0 {
+			if a.getLocalCachedResult(s.LocalCache, a.execContext.Metadata.ActionDigest) {
+				return
+			}
+		}
 		a.runLocal(ctx, s.LocalPool)
 		a.cacheLocal()
+		s.updateLocalCacheLERC(a)
+		return
+	}
+}
+
+// updateLocalCacheLERC stores the result of a local execution in LERC mode in the local action
+// cache, keyed by the action digest used for the remote cache.
+func (s *Server) updateLocalCacheLERC(a *action) {
+	if s.LocalCache == nil || a.execContext == nil || a.execContext.Metadata == nil {
 		return
 	}
+	if dg := a.execContext.Metadata.ActionDigest; dg.Size > 0 {
+		a.updateLocalCache(s.LocalCache, dg)
+	}
 }
 
 func (s *Server) runRacing(ctx context.Context, a *action) {
+	report, ok := s.breaker.acquire()
+	if !ok {
+		s.runLocalBypassingRemote(ctx, a)
+		return
+	}
 	if features.GetConfig().CleanIncludePaths {
 		a.cmd.Args = cleanIncludePaths(a.cmd)
 	}
 
 	if err := s.populateCommandIO(ctx, a); err != nil {
+		report(nil)
 		return
 	}
 	log.V(1).Infof("%v: Inputs: %v, Outputs: %+v", a.cmd.Identifiers.ExecutionID, a.cmd.InputSpec.Inputs, a.cmd.OutputFiles)
 
-	a.race(ctx, s.REClient, s.LocalPool, s.numFallbacks, s.MaxHoldoff)
+	a.race(ctx, s.REClient, s.LocalPool, s.numFallbacks, s.MaxHoldoff, report)
 	if a.res == nil {
 		a.res = command.NewLocalErrorResult(fmt.Errorf("racing did not produce a result"))
 	}
@@ -892,12 +1161,59 @@ func (s *Server) runRacing(ctx context.Context, a *action) {
 	}
 }
 
+// runLocalBypassingRemote runs an action locally without attempting remote execution, while the
+// circuit breaker is open. It does not count as a local fallback for the fail early mechanism, so
+// that an unhealthy remote execution service slows the build down rather than failing it.
+func (s *Server) runLocalBypassingRemote(ctx context.Context, a *action) {
+	log.V(1).Infof("%v: Circuit breaker is open, executing locally.", a.cmd.Identifiers.ExecutionID)
+	a.rec.LocalMetadata.RemoteBypassed = true
+	// The sandbox only exposes the inputs of the action.
+	if a.lOpt.GetPlatform() == ppb.LocalExecutionOptions_SANDBOX {
+		if err := s.populateCommandIO(ctx, a); err != nil {
+			return
+		}
+	}
+	a.runLocal(ctx, s.LocalPool)
+}
+
 func (s *Server) runRemote(ctx context.Context, a *action) {
+	if features.GetConfig().CleanIncludePaths {
+		a.cmd.Args = cleanIncludePaths(a.cmd)
+	}
+	prepared := false
+	// Each attempt has its own reclient timeout, so that attempts timed out by it can be retried.
+	// The timeout of the first attempt also covers input processing.
+	s.runRemoteWithRetries(ctx, a, func(ctx context.Context) {
+		s.withReclientTimeout(ctx, a, func(rCtx context.Context) {
+			if !prepared {
+				if err := s.populateCommandIO(rCtx, a); err != nil {
+					return
+				}
+				prepared = true
+				log.V(1).Infof("%v: Inputs: %v", a.cmd.Identifiers.ExecutionID, a.cmd.InputSpec.Inputs)
+				if a.compare {
+					a.snapshotInOutFiles()
+				}
+			}
+			if v := rCtx.Value(testOnlyRemoteAttemptKey); v != nil {
+				v.(func(context.Context))(rCtx)
+			}
+			a.runRemote(rCtx, s.REClient)
+		})
+	})
+}
+
+// withReclientTimeout calls f with a context that is canceled once the reclient timeout of the
+// action expires, in which case the result of the action is replaced by a timeout.
+func (s *Server) withReclientTimeout(ctx context.Context, a *action, f func(rCtx context.Context)) {
 	rCtx, cancel := cancelWithCause(ctx)
 	done := make(chan struct{})
 	defer close(done)
 	var errReclientTimeout = errors.New("remote action timed out by reclient timeout")
 	defer func() {
+		if a.res == nil {
+			return
+		}
 		if errors.Is(a.res.Err, errReclientTimeout) || (errors.Is(a.res.Err, context.Canceled) && errors.Is(rCtx.Err(), errReclientTimeout)) {
 			a.res = &command.Result{
 				Status:   command.TimeoutResultStatus,
@@ -931,28 +1247,7 @@ func (s *Server) runRemote(ctx context.Context, a *action) {
 		case <-done:
 		}
 	}()
-
-	if features.GetConfig().CleanIncludePaths {
-		a.cmd.Args = cleanIncludePaths(a.cmd)
-	}
-	if err := s.populateCommandIO(rCtx, a); err != nil {
-		return
-	}
-	log.V(1).Infof("%v: Inputs: %v", a.cmd.Identifiers.ExecutionID, a.cmd.InputSpec.Inputs)
-	var restoreInOutFiles restoreInOutFilesFn
-	if a.compare {
-		restoreInOutFiles = a.stashInputOutputFiles()
-	}
-	a.runRemote(rCtx, s.REClient)
-
-	// We need not run compare mode if the remote result is already a failure.
-	if !a.res.IsOk() || !a.compare {
-		return
-	}
-	if a.compare {
-		a.removeAllOutputs()
-		restoreInOutFiles()
-	}
+	f(rCtx)
 }
 
 // fileList returns the absolute path of all the files in the given list
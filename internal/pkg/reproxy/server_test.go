@@ -25,14 +25,18 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/bazelbuild/reclient/internal/pkg/actioncache"
 	"github.com/bazelbuild/reclient/internal/pkg/deps"
+	"github.com/bazelbuild/reclient/internal/pkg/diskcas"
 	"github.com/bazelbuild/reclient/internal/pkg/event"
 	"github.com/bazelbuild/reclient/internal/pkg/execroot"
 	"github.com/bazelbuild/reclient/internal/pkg/localresources"
 	"github.com/bazelbuild/reclient/internal/pkg/logger"
+	"github.com/bazelbuild/reclient/internal/pkg/otlptrace"
 	"github.com/bazelbuild/reclient/internal/pkg/stats"
 	"github.com/bazelbuild/reclient/internal/pkg/subprocess"
 	"github.com/bazelbuild/reclient/internal/pkg/version"
@@ -44,8 +48,10 @@ import (
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/filemetadata"
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/outerr"
 	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 
 	lpb "github.com/bazelbuild/reclient/api/log"
@@ -241,12 +247,218 @@ func TestRemote(t *testing.T) {
 			lpb.CompletionStatus_STATUS_CACHE_HIT.String(): 2,
 		},
 		RunningActions: 0,
+		InvocationIds:  []string{invocationID},
 	}
 	if diff := cmp.Diff(wantSummary, gotSummary, protocmp.Transform()); diff != "" {
 		t.Errorf("Status summary returned diff in result: (-want +got)\n%s", diff)
 	}
 }
 
+// TestRemote_DedupInFlight tests that an action waits for an identical in-flight action and reuses
+// its result instead of being executed remotely again.
+func TestRemote_DedupInFlight(t *testing.T) {
+	env, cleanup := fakes.NewTestEnv(t)
+	fmc := filemetadata.NewSingleFlightCache()
+	env.Client.FileMetadataCache = fmc
+	t.Cleanup(cleanup)
+	files := []string{"foo.h", "bar.h", executablePath}
+	execroot.AddFiles(t, env.ExecRoot, files)
+	ds := &stubCPPDependencyScanner{
+		processInputsReturnValue: []string{
+			"foo.h",
+			"bar.h",
+		},
+	}
+	resMgr := localresources.NewDefaultManager()
+	server := &Server{
+		MaxHoldoff:        time.Minute,
+		DownloadTmp:       t.TempDir(),
+		FileMetadataStore: fmc,
+	}
+	server.Init()
+	server.SetInputProcessor(inputprocessor.NewInputProcessorWithStubDependencyScanner(ds, false, nil, resMgr), func() {})
+	server.SetREClient(env.Client, func() {})
+	lg, err := logger.New(logger.TextFormat, env.ExecRoot, stats.New(), nil, nil, nil)
+	if err != nil {
+		t.Errorf("error initializing logger: %v", err)
+	}
+	server.Logger = lg
+	ctx := context.Background()
+	req := &ppb.RunRequest{
+		Command: &cpb.Command{
+			Args:     []string{executablePath, "-c", "c"},
+			ExecRoot: env.ExecRoot,
+			Output: &cpb.OutputSpec{
+				OutputFiles: []string{abOutPath},
+			},
+		},
+		Labels: map[string]string{"type": "compile", "lang": "cpp", "compiler": "clang"},
+		ExecutionOptions: &ppb.ProxyExecutionOptions{
+			ExecutionStrategy: ppb.ExecutionStrategy_REMOTE,
+			RemoteExecutionOptions: &ppb.RemoteExecutionOptions{
+				AcceptCached:    true,
+				DownloadOutputs: true,
+			},
+			ReclientTimeout:  3600,
+			IncludeActionLog: true,
+		},
+	}
+	wantCmd := &command.Command{
+		Identifiers: &command.Identifiers{},
+		Args:        []string{executablePath, "-c", "c"},
+		ExecRoot:    env.ExecRoot,
+		InputSpec: &command.InputSpec{
+			Inputs: []string{"foo.h", "bar.h", executablePath},
+		},
+		OutputFiles: []string{abOutPath},
+	}
+	setPlatformOSFamily(wantCmd)
+	wantStdErr := []byte("stderr")
+	_, acDg, _, _ := env.Set(wantCmd, command.DefaultExecutionOptions(), &command.Result{Status: command.SuccessResultStatus}, fakes.StdErr(wantStdErr), &fakes.OutputFile{abOutPath, "output"})
+
+	// Pretend that an identical action is in flight.
+	release, _ := server.inFlight.tryAcquire(acDg)
+	type result struct {
+		resp *ppb.RunResponse
+		err  error
+	}
+	resCh := make(chan result)
+	go func() {
+		resp, err := server.RunCommand(ctx, req)
+		resCh <- result{resp, err}
+	}()
+	select {
+	case <-resCh:
+		t.Fatalf("RunCommand() returned while an identical action was in flight")
+	case <-time.After(500 * time.Millisecond):
+	}
+	// The in-flight action finishes and caches its result.
+	env.Set(wantCmd, command.DefaultExecutionOptions(), &command.Result{Status: command.CacheHitResultStatus}, fakes.StdErr(wantStdErr), &fakes.OutputFile{abOutPath, "output"})
+	release()
+	got := <-resCh
+	if got.err != nil {
+		t.Fatalf("RunCommand() returned error: %v", got.err)
+	}
+	want := &ppb.RunResponse{
+		Result: &cpb.CommandResult{
+			Status:   cpb.CommandResultStatus_CACHE_HIT,
+			ExitCode: 0,
+		},
+		Stderr: wantStdErr,
+	}
+	if diff := cmp.Diff(want, got.resp, protocmp.IgnoreFields(&ppb.RunResponse{}, "execution_id", "action_log"), protocmp.Transform()); diff != "" {
+		t.Errorf("RunCommand() returned diff in result: (-want +got)\n%s", diff)
+	}
+	if _, ok := got.resp.GetActionLog().GetLocalMetadata().GetEventTimes()[event.InFlightWait]; !ok {
+		t.Errorf("RunCommand() action log has no %v event time", event.InFlightWait)
+	}
+	if n := env.Server.Exec.ExecuteCalls(); n != 0 {
+		t.Errorf("Execute was called %v times, want 0", n)
+	}
+	path := filepath.Join(env.ExecRoot, abOutPath)
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("Error reading from %s: %v", path, err)
+	}
+	if !bytes.Equal(contents, []byte("output")) {
+		t.Errorf("Expected %s to contain \"output\", got %v", path, contents)
+	}
+	server.DrainAndReleaseResources()
+}
+
+func TestRemote_DiskCAS(t *testing.T) {
+	env, cleanup := fakes.NewTestEnv(t)
+	fmc := filemetadata.NewSingleFlightCache()
+	env.Client.FileMetadataCache = fmc
+	t.Cleanup(cleanup)
+	files := []string{"foo.h", "bar.h", executablePath}
+	execroot.AddFiles(t, env.ExecRoot, files)
+	ds := &stubCPPDependencyScanner{
+		processInputsReturnValue: []string{
+			"foo.h",
+			"bar.h",
+		},
+	}
+	dc, err := diskcas.New(t.TempDir(), 1<<20)
+	if err != nil {
+		t.Fatalf("diskcas.New() failed: %v", err)
+	}
+	resMgr := localresources.NewDefaultManager()
+	server := &Server{
+		MaxHoldoff:        time.Minute,
+		DownloadTmp:       t.TempDir(),
+		FileMetadataStore: fmc,
+		DiskCAS:           dc,
+	}
+	server.Init()
+	server.SetInputProcessor(inputprocessor.NewInputProcessorWithStubDependencyScanner(ds, false, nil, resMgr), func() {})
+	server.SetREClient(env.Client, func() {})
+	lg, err := logger.New(logger.TextFormat, env.ExecRoot, stats.New(), nil, nil, nil)
+	if err != nil {
+		t.Errorf("error initializing logger: %v", err)
+	}
+	server.Logger = lg
+	ctx := context.Background()
+	req := &ppb.RunRequest{
+		Command: &cpb.Command{
+			Args:     []string{executablePath, "-c", "c"},
+			ExecRoot: env.ExecRoot,
+			Output: &cpb.OutputSpec{
+				OutputFiles: []string{abOutPath},
+			},
+		},
+		Labels: map[string]string{"type": "compile", "lang": "cpp", "compiler": "clang"},
+		ExecutionOptions: &ppb.ProxyExecutionOptions{
+			ExecutionStrategy: ppb.ExecutionStrategy_REMOTE,
+			RemoteExecutionOptions: &ppb.RemoteExecutionOptions{
+				AcceptCached:    true,
+				DownloadOutputs: true,
+			},
+			ReclientTimeout:  3600,
+			IncludeActionLog: true,
+		},
+	}
+	wantCmd := &command.Command{
+		Identifiers: &command.Identifiers{},
+		Args:        []string{executablePath, "-c", "c"},
+		ExecRoot:    env.ExecRoot,
+		InputSpec: &command.InputSpec{
+			Inputs: []string{"foo.h", "bar.h", executablePath},
+		},
+		OutputFiles: []string{abOutPath},
+	}
+	setPlatformOSFamily(wantCmd)
+	env.Set(wantCmd, command.DefaultExecutionOptions(), &command.Result{Status: command.CacheHitResultStatus}, &fakes.OutputFile{Path: abOutPath, Contents: "output"})
+	path := filepath.Join(env.ExecRoot, abOutPath)
+	for _, tc := range []struct {
+		name            string
+		wantDownloaded  bool
+		wantMaterialize bool
+	}{
+		{name: "download", wantDownloaded: true},
+		{name: "materialize", wantMaterialize: true},
+	} {
+		os.Remove(path)
+		got, err := server.RunCommand(ctx, req)
+		if err != nil {
+			t.Fatalf("%v: RunCommand() returned error: %v", tc.name, err)
+		}
+		if got.GetResult().GetStatus() != cpb.CommandResultStatus_CACHE_HIT {
+			t.Errorf("%v: RunCommand() returned status %v, want %v", tc.name, got.GetResult().GetStatus(), cpb.CommandResultStatus_CACHE_HIT)
+		}
+		if contents, err := os.ReadFile(path); err != nil || string(contents) != "output" {
+			t.Errorf("%v: ReadFile(%v) = %q, %v, want %q", tc.name, path, contents, err, "output")
+		}
+		rm := got.GetActionLog().GetRemoteMetadata()
+		if gotDownloaded := rm.GetRealBytesDownloaded() > 0; gotDownloaded != tc.wantDownloaded {
+			t.Errorf("%v: RealBytesDownloaded = %v, want downloaded = %v", tc.name, rm.GetRealBytesDownloaded(), tc.wantDownloaded)
+		}
+		if _, gotMaterialize := got.GetActionLog().GetLocalMetadata().GetEventTimes()[event.DiskCASMaterialize]; gotMaterialize != tc.wantMaterialize {
+			t.Errorf("%v: action log has %v event time = %v, want %v", tc.name, event.DiskCASMaterialize, gotMaterialize, tc.wantMaterialize)
+		}
+	}
+}
+
 func TestRemote_CanonicalWorkingDir(t *testing.T) {
 	// Setup exec root and working directory
 	env, cleanup := fakes.NewTestEnv(t)
@@ -401,6 +613,7 @@ func TestRemote_CanonicalWorkingDir(t *testing.T) {
 			lpb.CompletionStatus_STATUS_CACHE_HIT.String(): 2,
 		},
 		RunningActions: 0,
+		InvocationIds:  []string{invocationID},
 	}
 	if diff := cmp.Diff(wantSummary, gotSummary, protocmp.Transform()); diff != "" {
 		t.Errorf("Status summary returned diff in result: (-want +got)\n%s", diff)
@@ -2768,13 +2981,12 @@ func TestCompareStashRestore(t *testing.T) {
 		"inout": []byte("foo"),
 	}
 	execroot.AddFilesWithContent(t, env.ExecRoot, fileContents)
-	executor := &execStub{localExec: func() {
-		time.Sleep(time.Second) // Hack to let mtime catch up.
-		content, err := os.ReadFile(filepath.Join(env.ExecRoot, "inout"))
+	executor := &cmdExecStub{localExec: func(cmd *command.Command) {
+		content, err := os.ReadFile(filepath.Join(cmd.ExecRoot, "inout"))
 		if err != nil {
-			t.Errorf("execStub read input: %v", err)
+			t.Errorf("cmdExecStub read input: %v", err)
 		}
-		execroot.AddFileWithContent(t, filepath.Join(env.ExecRoot, "inout"), append(content, []byte("baz")...))
+		execroot.AddFileWithContent(t, filepath.Join(cmd.ExecRoot, "inout"), append(content, []byte("baz")...))
 	}}
 	resMgr := localresources.NewDefaultManager()
 	server := &Server{
@@ -2782,6 +2994,7 @@ func TestCompareStashRestore(t *testing.T) {
 		LocalPool:         NewLocalPool(executor, resMgr),
 		MaxHoldoff:        time.Minute,
 		DownloadTmp:       t.TempDir(),
+		KeepLastRecords:   1,
 	}
 	server.Init()
 	server.SetInputProcessor(inputprocessor.NewInputProcessorWithStubDependencyScanner(nil, false, nil, resMgr), func() {})
@@ -2797,7 +3010,7 @@ func TestCompareStashRestore(t *testing.T) {
 		"inout",
 	}
 	ctx := context.Background()
-	remoteExecOptions := &ppb.RemoteExecutionOptions{AcceptCached: false, DoNotCache: true}
+	remoteExecOptions := &ppb.RemoteExecutionOptions{AcceptCached: false, DoNotCache: true, DownloadOutputs: true}
 	req := &ppb.RunRequest{
 		Command: &cpb.Command{
 			Args:     cmdArgs,
@@ -2848,15 +3061,149 @@ func TestCompareStashRestore(t *testing.T) {
 	if diff := cmp.Diff(want, got, protocmp.IgnoreFields(&ppb.RunResponse{}, "execution_id"), protocmp.Transform()); diff != "" {
 		t.Errorf("RunCommand() returned diff in result: (-want +got)\n%s", diff)
 	}
+	// The output comes from remote execution, local reruns run in the background in a scratch
+	// exec root, starting from the original contents of the in-out file.
+	server.wgCompare.Wait()
 	path := filepath.Join(env.ExecRoot, "inout")
 	contents, err := os.ReadFile(path)
 	if err != nil {
 		t.Errorf("error reading from %s: %v", path, err)
 	}
-	wantContents := "foobaz"
+	wantContents := "bar"
 	if !bytes.Equal(contents, []byte(wantContents)) {
 		t.Errorf("RunCommand output %s: %q; want %q", path, contents, wantContents)
 	}
+	recs, err := server.GetRecords(ctx, &ppb.GetRecordsRequest{})
+	if err != nil || len(recs.GetRecords()) != 1 {
+		t.Fatalf("GetRecords() = %v, %v, want 1 record", recs, err)
+	}
+	reruns := recs.GetRecords()[0].GetLocalMetadata().GetRerunMetadata()
+	if len(reruns) != 2 {
+		t.Fatalf("RerunMetadata = %v, want 2 local reruns", reruns)
+	}
+	wantDg := digest.NewFromBlob([]byte("foobaz")).String()
+	for _, r := range reruns {
+		if got := r.GetOutputFileDigests()["inout"]; got != wantDg {
+			t.Errorf("Local rerun attempt %v produced inout with digest %v, want %v", r.GetAttempt(), got, wantDg)
+		}
+	}
+}
+
+func TestCompareRemoteInBackground(t *testing.T) {
+	env, cleanup := fakes.NewTestEnv(t)
+	fmc := filemetadata.NewSingleFlightCache()
+	env.Client.FileMetadataCache = fmc
+	t.Cleanup(cleanup)
+	execroot.AddFileWithContent(t, filepath.Join(env.ExecRoot, "tool"), []byte("fake"))
+	release := make(chan struct{})
+	executor := &cmdExecStub{localExec: func(cmd *command.Command) {
+		<-release
+		execroot.AddFileWithContent(t, filepath.Join(cmd.ExecRoot, "out"), []byte("baz"))
+	}}
+	resMgr := localresources.NewDefaultManager()
+	server := &Server{
+		FileMetadataStore: fmc,
+		LocalPool:         NewLocalPool(executor, resMgr),
+		MaxHoldoff:        time.Minute,
+		DownloadTmp:       t.TempDir(),
+		KeepLastRecords:   1,
+	}
+	server.Init()
+	server.SetInputProcessor(inputprocessor.NewInputProcessorWithStubDependencyScanner(nil, false, nil, resMgr), func() {})
+	server.SetREClient(env.Client, func() {})
+	t.Cleanup(server.DrainAndReleaseResources)
+	lg, err := logger.New(logger.TextFormat, env.ExecRoot, stats.New(), nil, nil, nil)
+	if err != nil {
+		t.Errorf("error initializing logger: %v", err)
+	}
+	server.Logger = lg
+	ctx := context.Background()
+	req := &ppb.RunRequest{
+		Command: &cpb.Command{
+			Args:     []string{"tool"},
+			ExecRoot: env.ExecRoot,
+			Output: &cpb.OutputSpec{
+				OutputFiles: []string{"out"},
+			},
+		},
+		Labels: map[string]string{"type": "tool"},
+		ExecutionOptions: &ppb.ProxyExecutionOptions{
+			ExecutionStrategy:      ppb.ExecutionStrategy_REMOTE,
+			RemoteExecutionOptions: &ppb.RemoteExecutionOptions{DoNotCache: true, DownloadOutputs: true},
+			CompareWithLocal:       true,
+			NumLocalReruns:         1,
+			NumRemoteReruns:        1,
+			ReclientTimeout:        3600,
+		},
+	}
+	wantCmd := &command.Command{
+		Identifiers: &command.Identifiers{},
+		Args:        []string{"tool"},
+		ExecRoot:    env.ExecRoot,
+		InputSpec: &command.InputSpec{
+			Inputs: []string{"tool"},
+		},
+		OutputFiles: []string{"out"},
+	}
+	setPlatformOSFamily(wantCmd)
+	executionOptions := command.DefaultExecutionOptions()
+	executionOptions.DoNotCache = true
+	env.Set(wantCmd, executionOptions, &command.Result{Status: command.SuccessResultStatus}, &fakes.OutputFile{Path: "out", Contents: "bar"})
+
+	// The local rerun is blocked, so the response must not wait for it.
+	got, err := server.RunCommand(ctx, req)
+	if err != nil {
+		t.Fatalf("RunCommand() returned error: %v", err)
+	}
+	want := &ppb.RunResponse{Result: &cpb.CommandResult{Status: cpb.CommandResultStatus_SUCCESS}}
+	if diff := cmp.Diff(want, got, protocmp.IgnoreFields(&ppb.RunResponse{}, "execution_id"), protocmp.Transform()); diff != "" {
+		t.Errorf("RunCommand() returned diff in result: (-want +got)\n%s", diff)
+	}
+	path := filepath.Join(env.ExecRoot, "out")
+	if contents, err := os.ReadFile(path); err != nil || string(contents) != "bar" {
+		t.Errorf("ReadFile(%v) = %q, %v, want %q", path, contents, err, "bar")
+	}
+	if recs, _ := server.GetRecords(ctx, &ppb.GetRecordsRequest{}); len(recs.GetRecords()) != 0 {
+		t.Errorf("GetRecords() returned %v before the reruns finished, want no records", recs.GetRecords())
+	}
+	// The action is reported as completed as soon as its response is sent.
+	summary, err := lg.GetStatusSummary(ctx, &ppb.GetStatusSummaryRequest{})
+	if err != nil {
+		t.Fatalf("GetStatusSummary() returned error: %v", err)
+	}
+	if summary.GetRunningActions() != 0 || summary.GetCompletedActionStats()[lpb.CompletionStatus_STATUS_REMOTE_EXECUTION.String()] != 1 {
+		t.Errorf("GetStatusSummary() before the reruns finished = %v, want 0 running and 1 completed action", summary)
+	}
+	if running, _ := server.GetRunningActions(ctx, &ppb.GetRunningActionsRequest{}); len(running.GetActions()) != 0 {
+		t.Errorf("GetRunningActions() before the reruns finished = %v, want no actions", running.GetActions())
+	}
+
+	close(release)
+	server.wgCompare.Wait()
+	if contents, err := os.ReadFile(path); err != nil || string(contents) != "bar" {
+		t.Errorf("ReadFile(%v) after reruns = %q, %v, want %q", path, contents, err, "bar")
+	}
+	recs, err := server.GetRecords(ctx, &ppb.GetRecordsRequest{})
+	if err != nil || len(recs.GetRecords()) != 1 {
+		t.Fatalf("GetRecords() = %v, %v, want 1 record", recs, err)
+	}
+	rec := recs.GetRecords()[0]
+	if rec.GetCompletionStatus() != lpb.CompletionStatus_STATUS_REMOTE_EXECUTION {
+		t.Errorf("CompletionStatus = %v, want %v", rec.GetCompletionStatus(), lpb.CompletionStatus_STATUS_REMOTE_EXECUTION)
+	}
+	wantVerification := &lpb.Verification{
+		TotalMismatches: 1,
+		TotalVerified:   1,
+		Mismatches: []*lpb.Verification_Mismatch{{
+			Path:          "out",
+			RemoteDigests: []string{digest.NewFromBlob([]byte("bar")).String()},
+			LocalDigests:  []string{digest.NewFromBlob([]byte("baz")).String()},
+			Determinism:   lpb.DeterminismStatus_UNKNOWN,
+		}},
+	}
+	if diff := cmp.Diff(wantVerification, rec.GetLocalMetadata().GetVerification(), protocmp.IgnoreFields(&lpb.Verification_Mismatch{}, "action_digest"), protocmp.Transform()); diff != "" {
+		t.Errorf("Verification returned diff (-want +got)\n%s", diff)
+	}
 }
 
 func TestNumRetriesIfMismatched(t *testing.T) {
@@ -2881,19 +3228,16 @@ func TestNumRetriesIfMismatched(t *testing.T) {
 	}
 	server.Logger = lg
 	var cmdArgs []string
-	var wantOutput []byte
 	var wantFileDg string
 	var wantDirDg string
 	var wantActionDigest []string
 	if runtime.GOOS == "windows" {
 		cmdArgs = []string{"cmd", "/c", fmt.Sprintf("(if not exist %s mkdir %s) && echo hello>%s", abPath, abPath, abOutPath)}
-		wantOutput = []byte("hello\r\n")
 		wantFileDg = "cd2eca3535741f27a8ae40c31b0c41d4057a7a7b912b33b9aed86485d1c84676/7"
 		wantDirDg = "e04c31681c3dae2046b8caacda5a17160bca360461ba71b62951e9c514d4746d/79"
 		wantActionDigest = []string{"a5c9f92241a0522ef34a6d3edd63c44eb7cc930bec8a44c0eff661732745c3f5/140"}
 	} else {
 		cmdArgs = []string{"/bin/bash", "-c", fmt.Sprintf("mkdir -p %s && echo hello > %s", abPath, abOutPath)}
-		wantOutput = []byte("hello\n")
 		wantFileDg = "5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03/6"
 		wantDirDg = "48bd723bc0791eeeda990c6ca2fc14133a73cb23e1c0aee08aa0a8728d37da93/79"
 		// Action digests can differ between linux and mac.
@@ -2948,13 +3292,10 @@ func TestNumRetriesIfMismatched(t *testing.T) {
 	if diff := cmp.Diff(want, got, protocmp.IgnoreFields(&ppb.RunResponse{}, "execution_id"), protocmp.Transform()); diff != "" {
 		t.Errorf("RunCommand() returned diff in result: gotErr %s, (-want +got)\n%s", got.Stderr, diff)
 	}
+	// Outputs are not downloaded, and local reruns do not write to the exec root.
 	path := filepath.Join(env.ExecRoot, abOutPath)
-	contents, err := os.ReadFile(path)
-	if err != nil {
-		t.Errorf("error reading from %s: %v", path, err)
-	}
-	if !bytes.Equal(contents, wantOutput) {
-		t.Errorf("RunCommand output %s: %q; want %q", path, contents, wantOutput)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Stat(%v) = %v, want the output to not exist", path, err)
 	}
 
 	server.DrainAndReleaseResources()
@@ -3277,19 +3618,16 @@ func TestCompareWithReruns(t *testing.T) {
 	}
 	server.Logger = lg
 	var cmdArgs []string
-	var wantOutput []byte
 	var wantFileDg string
 	var wantDirDg string
 	var wantActionDigest []string
 	if runtime.GOOS == "windows" {
 		cmdArgs = []string{"cmd", "/c", fmt.Sprintf("(if not exist %s mkdir %s) && echo hello>%s", abPath, abPath, abOutPath)}
-		wantOutput = []byte("hello\r\n")
 		wantFileDg = "cd2eca3535741f27a8ae40c31b0c41d4057a7a7b912b33b9aed86485d1c84676/7"
 		wantDirDg = "e04c31681c3dae2046b8caacda5a17160bca360461ba71b62951e9c514d4746d/79"
 		wantActionDigest = []string{"a5c9f92241a0522ef34a6d3edd63c44eb7cc930bec8a44c0eff661732745c3f5/140"}
 	} else {
 		cmdArgs = []string{"/bin/bash", "-c", fmt.Sprintf("mkdir -p %s && echo hello > %s", abPath, abOutPath)}
-		wantOutput = []byte("hello\n")
 		wantFileDg = "5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03/6"
 		wantDirDg = "48bd723bc0791eeeda990c6ca2fc14133a73cb23e1c0aee08aa0a8728d37da93/79"
 		// Action digests can differ between linux and mac.
@@ -3345,15 +3683,13 @@ func TestCompareWithReruns(t *testing.T) {
 	if diff := cmp.Diff(want, got, protocmp.IgnoreFields(&ppb.RunResponse{}, "execution_id"), protocmp.Transform()); diff != "" {
 		t.Errorf("RunCommand() returned diff in result: gotErr %s, (-want +got)\n%s", got.Stderr, diff)
 	}
+	// Outputs are not downloaded, and local reruns do not write to the exec root.
 	path := filepath.Join(env.ExecRoot, abOutPath)
-	contents, err := os.ReadFile(path)
-	if err != nil {
-		t.Errorf("error reading from %s: %v", path, err)
-	}
-	if !bytes.Equal(contents, wantOutput) {
-		t.Errorf("RunCommand output %s: %q; want %q", path, contents, wantOutput)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Stat(%v) = %v, want the output to not exist", path, err)
 	}
 
+	server.wgCompare.Wait()
 	gotSummary, _ := lg.GetStatusSummary(ctx, &ppb.GetStatusSummaryRequest{})
 	server.DrainAndReleaseResources()
 	recs, _, err := logger.ParseFromLogDirs(logger.TextFormat, []string{env.ExecRoot})
@@ -3504,6 +3840,7 @@ func TestCompareWithReruns(t *testing.T) {
 			lpb.CompletionStatus_STATUS_REMOTE_EXECUTION.String(): 1,
 		},
 		RunningActions: 0,
+		InvocationIds:  []string{invocationID},
 	}
 	if diff := cmp.Diff(wantSummary, gotSummary, protocmp.Transform()); diff != "" {
 		t.Errorf("Status summary returned diff in result: (-want +got)\n%s", diff)
@@ -6609,15 +6946,318 @@ func TestRemoteDisabled(t *testing.T) {
 	}
 }
 
-func TestProxyInfoUptime(t *testing.T) {
+func TestRemoteDisabled_LocalCache(t *testing.T) {
 	env, cleanup := fakes.NewTestEnv(t)
 	fmc := filemetadata.NewSingleFlightCache()
 	env.Client.FileMetadataCache = fmc
 	t.Cleanup(cleanup)
-	ds := &stubCPPDependencyScanner{}
-	executor := &execStub{}
+	execroot.AddFiles(t, env.ExecRoot, []string{"in.txt"})
+	numExecs := 0
+	executor := &execStub{
+		localExec: func() {
+			numExecs++
+			execroot.AddFileWithContent(t, filepath.Join(env.ExecRoot, abOutPath), []byte("output"))
+		},
+		stdOut: []byte("stdout"),
+		stdErr: []byte("stderr"),
+	}
+	lc, err := actioncache.New(t.TempDir(), 1<<20)
+	if err != nil {
+		t.Fatalf("actioncache.New() failed: %v", err)
+	}
 	resMgr := localresources.NewDefaultManager()
-	st := time.Now()
+	server := &Server{
+		LocalPool:         NewLocalPool(executor, resMgr),
+		LocalCache:        lc,
+		RemoteDisabled:    true,
+		MaxHoldoff:        time.Minute,
+		DownloadTmp:       t.TempDir(),
+		FileMetadataStore: fmc,
+	}
+	server.Init()
+	server.SetInputProcessor(inputprocessor.NewInputProcessorWithStubDependencyScanner(&stubCPPDependencyScanner{}, false, nil, resMgr), func() {})
+	server.SetREClient(env.Client, func() {})
+	lg, err := logger.New(logger.TextFormat, env.ExecRoot, stats.New(), nil, nil, nil)
+	if err != nil {
+		t.Errorf("error initializing logger: %v", err)
+	}
+	server.Logger = lg
+	ctx := context.Background()
+	req := &ppb.RunRequest{
+		Command: &cpb.Command{
+			Args:     []string{"tool", "in.txt"},
+			ExecRoot: env.ExecRoot,
+			Input:    &cpb.InputSpec{Inputs: []string{"in.txt"}},
+			Output:   &cpb.OutputSpec{OutputFiles: []string{abOutPath}},
+		},
+		Labels: map[string]string{"type": "tool"},
+		ExecutionOptions: &ppb.ProxyExecutionOptions{
+			ExecutionStrategy:     ppb.ExecutionStrategy_LOCAL,
+			LocalExecutionOptions: &ppb.LocalExecutionOptions{AcceptCached: true},
+			ReclientTimeout:       3600,
+		},
+	}
+	outPath := filepath.Join(env.ExecRoot, abOutPath)
+	for _, tc := range []struct {
+		name       string
+		wantStatus cpb.CommandResultStatus_Value
+		wantExecs  int
+	}{
+		{name: "miss", wantStatus: cpb.CommandResultStatus_SUCCESS, wantExecs: 1},
+		{name: "hit", wantStatus: cpb.CommandResultStatus_CACHE_HIT, wantExecs: 1},
+	} {
+		os.Remove(outPath)
+		got, err := server.RunCommand(ctx, req)
+		if err != nil {
+			t.Fatalf("%v: RunCommand() returned error: %v", tc.name, err)
+		}
+		want := &ppb.RunResponse{
+			Result: &cpb.CommandResult{Status: tc.wantStatus},
+			Stdout: []byte("stdout"),
+			Stderr: []byte("stderr"),
+		}
+		if diff := cmp.Diff(want, got, protocmp.IgnoreFields(&ppb.RunResponse{}, "execution_id"), protocmp.Transform()); diff != "" {
+			t.Errorf("%v: RunCommand() returned diff in result: (-want +got)\n%s", tc.name, diff)
+		}
+		if numExecs != tc.wantExecs {
+			t.Errorf("%v: number of local executions = %v, want %v", tc.name, numExecs, tc.wantExecs)
+		}
+		if contents, err := os.ReadFile(outPath); err != nil || string(contents) != "output" {
+			t.Errorf("%v: ReadFile(%v) = %q, %v, want %q", tc.name, outPath, contents, err, "output")
+		}
+	}
+
+	// Changing an input must invalidate the cached result.
+	execroot.AddFileWithContent(t, filepath.Join(env.ExecRoot, "in.txt"), []byte("changed"))
+	fmc.Delete(filepath.Join(env.ExecRoot, "in.txt"))
+	if _, err := server.RunCommand(ctx, req); err != nil {
+		t.Fatalf("RunCommand() returned error: %v", err)
+	}
+	if numExecs != 2 {
+		t.Errorf("number of local executions after changing an input = %v, want 2", numExecs)
+	}
+
+	server.DrainAndReleaseResources()
+	recs, _, err := logger.ParseFromLogDirs(logger.TextFormat, []string{env.ExecRoot})
+	if err != nil {
+		t.Errorf("logger.ParseFromLogDirs failed: %v", err)
+	}
+	var gotHits, gotUpdates []bool
+	for _, rec := range recs {
+		gotHits = append(gotHits, rec.GetLocalMetadata().GetLocalCacheHit())
+		gotUpdates = append(gotUpdates, rec.GetLocalMetadata().GetUpdatedLocalCache())
+	}
+	if diff := cmp.Diff([]bool{false, true, false}, gotHits); diff != "" {
+		t.Errorf("LocalCacheHit in logs returned diff: (-want +got)\n%s", diff)
+	}
+	if diff := cmp.Diff([]bool{true, false, true}, gotUpdates); diff != "" {
+		t.Errorf("UpdatedLocalCache in logs returned diff: (-want +got)\n%s", diff)
+	}
+}
+
+type runCommandStreamStub struct {
+	grpc.ServerStream
+	ctx   context.Context
+	resps []*ppb.RunResponse
+}
+
+func (s *runCommandStreamStub) Context() context.Context {
+	return s.ctx
+}
+
+func (s *runCommandStreamStub) Send(resp *ppb.RunResponse) error {
+	s.resps = append(s.resps, resp)
+	return nil
+}
+
+func TestRunCommandStream_LocalOutput(t *testing.T) {
+	env, cleanup := fakes.NewTestEnv(t)
+	t.Cleanup(cleanup)
+	executor := &execStub{
+		localExec: func() {},
+		stdOut:    []byte("stdout"),
+		stdErr:    []byte("stderr"),
+	}
+	resMgr := localresources.NewDefaultManager()
+	server := &Server{
+		LocalPool:      NewLocalPool(executor, resMgr),
+		RemoteDisabled: true,
+		MaxHoldoff:     time.Minute,
+		DownloadTmp:    t.TempDir(),
+	}
+	server.Init()
+	server.SetInputProcessor(inputprocessor.NewInputProcessorWithStubDependencyScanner(&stubCPPDependencyScanner{}, false, nil, resMgr), func() {})
+	server.SetREClient(env.Client, func() {})
+	lg, err := logger.New(logger.TextFormat, env.ExecRoot, stats.New(), nil, nil, nil)
+	if err != nil {
+		t.Errorf("error initializing logger: %v", err)
+	}
+	server.Logger = lg
+	req := &ppb.RunRequest{
+		Command: &cpb.Command{
+			Args:     []string{"tool"},
+			ExecRoot: env.ExecRoot,
+		},
+		Labels: map[string]string{"type": "tool"},
+		ExecutionOptions: &ppb.ProxyExecutionOptions{
+			ExecutionStrategy: ppb.ExecutionStrategy_LOCAL,
+			ReclientTimeout:   3600,
+		},
+	}
+	stream := &runCommandStreamStub{ctx: context.Background()}
+	if err := server.RunCommandStream(req, stream); err != nil {
+		t.Fatalf("RunCommandStream() returned error: %v", err)
+	}
+	want := []*ppb.RunResponse{
+		{},
+		{Stdout: []byte("stdout")},
+		{Stderr: []byte("stderr")},
+		{Result: &cpb.CommandResult{Status: cpb.CommandResultStatus_SUCCESS}},
+	}
+	if diff := cmp.Diff(want, stream.resps, protocmp.IgnoreFields(&ppb.RunResponse{}, "execution_id"), protocmp.Transform()); diff != "" {
+		t.Errorf("RunCommandStream() sent diff in responses: (-want +got)\n%s", diff)
+	}
+	for _, resp := range stream.resps {
+		if resp.GetExecutionId() == "" {
+			t.Errorf("RunCommandStream() sent response without execution ID: %v", resp)
+		}
+	}
+}
+
+// blockingExecStub blocks local executions until they are canceled.
+type blockingExecStub struct {
+	started chan struct{}
+}
+
+func (e *blockingExecStub) ExecuteWithOutErr(ctx context.Context, cmd *command.Command, oe outerr.OutErr) error {
+	close(e.started)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// startedStreamStub reports the execution ID of the first response of a stream.
+type startedStreamStub struct {
+	grpc.ServerStream
+	ctx         context.Context
+	executionID chan string
+	last        *ppb.RunResponse
+}
+
+func (s *startedStreamStub) Context() context.Context {
+	return s.ctx
+}
+
+func (s *startedStreamStub) Send(resp *ppb.RunResponse) error {
+	if s.last == nil {
+		s.executionID <- resp.GetExecutionId()
+	}
+	s.last = resp
+	return nil
+}
+
+func TestCancelCommand(t *testing.T) {
+	env, cleanup := fakes.NewTestEnv(t)
+	t.Cleanup(cleanup)
+	executor := &blockingExecStub{started: make(chan struct{})}
+	resMgr := localresources.NewDefaultManager()
+	server := &Server{
+		LocalPool:       NewLocalPool(executor, resMgr),
+		RemoteDisabled:  true,
+		KeepLastRecords: 1,
+		MaxHoldoff:      time.Minute,
+		DownloadTmp:     t.TempDir(),
+	}
+	server.Init()
+	server.SetInputProcessor(inputprocessor.NewInputProcessorWithStubDependencyScanner(&stubCPPDependencyScanner{}, false, nil, resMgr), func() {})
+	server.SetREClient(env.Client, func() {})
+	lg, err := logger.New(logger.TextFormat, env.ExecRoot, stats.New(), nil, nil, nil)
+	if err != nil {
+		t.Errorf("error initializing logger: %v", err)
+	}
+	server.Logger = lg
+	ctx := context.Background()
+	if _, err := server.CancelCommand(ctx, &ppb.CancelCommandRequest{ExecutionId: "unknown"}); status.Code(err) != codes.NotFound {
+		t.Errorf("CancelCommand(unknown) returned error %v, want code %v", err, codes.NotFound)
+	}
+	req := &ppb.RunRequest{
+		Command: &cpb.Command{
+			Args:     []string{"tool"},
+			ExecRoot: env.ExecRoot,
+		},
+		Labels: map[string]string{"type": "tool"},
+		ExecutionOptions: &ppb.ProxyExecutionOptions{
+			ExecutionStrategy: ppb.ExecutionStrategy_LOCAL,
+			ReclientTimeout:   3600,
+		},
+	}
+	stream := &startedStreamStub{ctx: ctx, executionID: make(chan string, 1)}
+	done := make(chan error)
+	go func() {
+		done <- server.RunCommandStream(req, stream)
+	}()
+	executionID := <-stream.executionID
+	<-executor.started
+	if _, err := server.CancelCommand(ctx, &ppb.CancelCommandRequest{ExecutionId: executionID}); err != nil {
+		t.Fatalf("CancelCommand(%v) returned error: %v", executionID, err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("RunCommandStream() returned error: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("RunCommandStream() did not return after CancelCommand()")
+	}
+	if got := stream.last.GetResult().GetStatus(); got != cpb.CommandResultStatus_INTERRUPTED {
+		t.Errorf("RunCommandStream() returned status %v, want %v", got, cpb.CommandResultStatus_INTERRUPTED)
+	}
+	recs, err := server.GetRecords(ctx, &ppb.GetRecordsRequest{})
+	if err != nil {
+		t.Fatalf("GetRecords() returned error: %v", err)
+	}
+	if len(recs.GetRecords()) != 1 || recs.GetRecords()[0].GetCompletionStatus() != lpb.CompletionStatus_STATUS_INTERRUPTED {
+		t.Errorf("GetRecords() = %v, want a single record with status %v", recs.GetRecords(), lpb.CompletionStatus_STATUS_INTERRUPTED)
+	}
+}
+
+func TestOutputStreamSendFinal(t *testing.T) {
+	var resps []*ppb.RunResponse
+	stream := &outputStream{send: func(resp *ppb.RunResponse) error {
+		resps = append(resps, resp)
+		return nil
+	}}
+	stdout := bytes.Repeat([]byte("o"), 2*outputChunkSize+1)
+	stderr := []byte("e")
+	res := &cpb.CommandResult{Status: cpb.CommandResultStatus_SUCCESS}
+	if err := stream.sendFinal(&ppb.RunResponse{ExecutionId: "id", Stdout: stdout, Stderr: stderr, Result: res}); err != nil {
+		t.Fatalf("sendFinal() returned error: %v", err)
+	}
+	want := []*ppb.RunResponse{
+		{ExecutionId: "id", Stdout: stdout[:outputChunkSize], Stderr: stderr},
+		{ExecutionId: "id", Stdout: stdout[outputChunkSize : 2*outputChunkSize]},
+		{ExecutionId: "id", Stdout: stdout[2*outputChunkSize:], Result: res},
+	}
+	if len(resps) != len(want) {
+		t.Fatalf("sendFinal() sent %d responses, want %d", len(resps), len(want))
+	}
+	for i, resp := range resps {
+		// Comparing the large output with cmp.Diff is slow.
+		if !proto.Equal(resp, want[i]) {
+			t.Errorf("sendFinal() sent response %d with stdout size %d, stderr %q, result %v, want stdout size %d, stderr %q, result %v",
+				i, len(resp.GetStdout()), resp.GetStderr(), resp.GetResult(), len(want[i].GetStdout()), want[i].GetStderr(), want[i].GetResult())
+		}
+	}
+}
+
+func TestProxyInfoUptime(t *testing.T) {
+	env, cleanup := fakes.NewTestEnv(t)
+	fmc := filemetadata.NewSingleFlightCache()
+	env.Client.FileMetadataCache = fmc
+	t.Cleanup(cleanup)
+	ds := &stubCPPDependencyScanner{}
+	executor := &execStub{}
+	resMgr := localresources.NewDefaultManager()
+	st := time.Now()
 	server := &Server{
 		LocalPool:         NewLocalPool(executor, resMgr),
 		RemoteDisabled:    true,
@@ -6775,6 +7415,16 @@ func (e *execStub) ExecuteWithOutErr(ctx context.Context, cmd *command.Command,
 	return nil
 }
 
+// cmdExecStub is a local executor that runs localExec with the executed command.
+type cmdExecStub struct {
+	localExec func(cmd *command.Command)
+}
+
+func (e *cmdExecStub) ExecuteWithOutErr(ctx context.Context, cmd *command.Command, oe outerr.OutErr) error {
+	e.localExec(cmd)
+	return nil
+}
+
 type stubCPPDependencyScanner struct {
 	processInputsReturnValue []string
 	processInputsError       error
@@ -6946,6 +7596,44 @@ func TestWindowedCountWithWindow(t *testing.T) {
 	}
 }
 
+func TestSetFailEarly(t *testing.T) {
+	server := &Server{}
+	server.Init()
+	server.numActions.Add(4)
+	server.numFallbacks.Add(3)
+	server.checkFailBuild()
+	if fail, _ := server.shouldFailBuild(); fail {
+		t.Fatalf("shouldFailBuild() = true with fail early disabled, want false")
+	}
+	server.SetFailEarly(2, 0.5, 0)
+	server.checkFailBuild()
+	if fail, err := server.shouldFailBuild(); !fail || err == nil {
+		t.Errorf("shouldFailBuild() = %v, %v after enabling fail early, want true and an error", fail, err)
+	}
+}
+
+func TestSetFailEarlyStartsMonitoring(t *testing.T) {
+	server := &Server{}
+	server.Init()
+	server.numActions.Add(4)
+	server.numFallbacks.Add(3)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// Returns right away since fail early is disabled.
+	server.monitorFailBuildConditions(ctx, 10*time.Millisecond)
+	server.SetFailEarly(2, 0.5, 0)
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		if fail, _ := server.shouldFailBuild(); fail {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("shouldFailBuild() = false after enabling fail early, want true")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
 func contains(list []string, s string) bool {
 	for _, v := range list {
 		if v == s {
@@ -6966,3 +7654,747 @@ func getFileInfo(t *testing.T, path string) (digest.Digest, time.Time) {
 	}
 	return d, info.ModTime()
 }
+
+func TestLocalExecutionTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Test uses bash")
+	}
+	env, cleanup := fakes.NewTestEnv(t)
+	t.Cleanup(cleanup)
+	resMgr := localresources.NewDefaultManager()
+	server := &Server{
+		LocalPool:      NewLocalPool(&subprocess.SystemExecutor{}, resMgr),
+		RemoteDisabled: true,
+		MaxHoldoff:     time.Minute,
+		DownloadTmp:    t.TempDir(),
+	}
+	server.Init()
+	server.SetInputProcessor(inputprocessor.NewInputProcessorWithStubDependencyScanner(&stubCPPDependencyScanner{}, false, nil, resMgr), func() {})
+	server.SetREClient(env.Client, func() {})
+	lg, err := logger.New(logger.TextFormat, env.ExecRoot, stats.New(), nil, nil, nil)
+	if err != nil {
+		t.Errorf("error initializing logger: %v", err)
+	}
+	server.Logger = lg
+	req := &ppb.RunRequest{
+		Command: &cpb.Command{
+			// The background sleep keeps stdout open unless the whole process group is killed.
+			Args:     []string{"/bin/bash", "-c", "echo partial; sleep 60 & sleep 60"},
+			ExecRoot: env.ExecRoot,
+		},
+		Labels: map[string]string{"type": "tool"},
+		ExecutionOptions: &ppb.ProxyExecutionOptions{
+			ExecutionStrategy: ppb.ExecutionStrategy_LOCAL,
+			LocalExecutionOptions: &ppb.LocalExecutionOptions{
+				ExecutionTimeout: 1,
+			},
+			ReclientTimeout:  3600,
+			IncludeActionLog: true,
+		},
+	}
+	start := time.Now()
+	got, err := server.RunCommand(context.Background(), req)
+	if err != nil {
+		t.Fatalf("RunCommand() returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 30*time.Second {
+		t.Errorf("RunCommand() took %v, want the command to be killed after its 1s local timeout", elapsed)
+	}
+	wantResult := &cpb.CommandResult{
+		Status:   cpb.CommandResultStatus_TIMEOUT,
+		ExitCode: LocalTimeoutExitCode,
+		Msg:      "local execution timed out after 1s",
+	}
+	if diff := cmp.Diff(wantResult, got.GetResult(), protocmp.Transform()); diff != "" {
+		t.Errorf("RunCommand() returned diff in result: (-want +got)\n%s", diff)
+	}
+	if got := string(got.GetStdout()); got != "partial\n" {
+		t.Errorf("RunCommand() returned stdout %q, want %q", got, "partial\n")
+	}
+	if got := got.GetActionLog().GetLocalMetadata().GetTimeoutPhase(); got != event.LocalCommandExecution {
+		t.Errorf("RunCommand() logged timeout phase %q, want %q", got, event.LocalCommandExecution)
+	}
+}
+
+func TestRacingLocalExecutionTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Test uses bash")
+	}
+	env, cleanup := fakes.NewTestEnv(t)
+	t.Cleanup(cleanup)
+	resMgr := localresources.NewDefaultManager()
+	server := &Server{
+		LocalPool:   NewLocalPool(&subprocess.SystemExecutor{}, resMgr),
+		Forecast:    &Forecast{},
+		MaxHoldoff:  time.Minute,
+		DownloadTmp: t.TempDir(),
+	}
+	server.Init()
+	server.SetInputProcessor(inputprocessor.NewInputProcessorWithStubDependencyScanner(&stubCPPDependencyScanner{}, false, nil, resMgr), func() {})
+	server.SetREClient(env.Client, func() {})
+	lg, err := logger.New(logger.TextFormat, env.ExecRoot, stats.New(), nil, nil, nil)
+	if err != nil {
+		t.Errorf("error initializing logger: %v", err)
+	}
+	server.Logger = lg
+	req := &ppb.RunRequest{
+		Command: &cpb.Command{
+			// The background sleep keeps stdout open unless the whole process group is killed.
+			Args:     []string{"/bin/bash", "-c", "echo partial; sleep 60 & sleep 60"},
+			ExecRoot: env.ExecRoot,
+		},
+		Labels: map[string]string{"type": "tool"},
+		ExecutionOptions: &ppb.ProxyExecutionOptions{
+			ExecutionStrategy: ppb.ExecutionStrategy_RACING,
+			LocalExecutionOptions: &ppb.LocalExecutionOptions{
+				ExecutionTimeout: 1,
+			},
+			ReclientTimeout:  3600,
+			IncludeActionLog: true,
+		},
+	}
+	cmd := &command.Command{
+		Identifiers: &command.Identifiers{},
+		Args:        req.Command.Args,
+		ExecRoot:    env.ExecRoot,
+		InputSpec:   &command.InputSpec{},
+	}
+	setPlatformOSFamily(cmd)
+	env.Set(cmd, command.DefaultExecutionOptions(), &command.Result{Status: command.SuccessResultStatus})
+	// Remote execution is blocked until local execution starts, so that local wins the race.
+	ctx := context.Background()
+	cCtx, cancel := context.WithCancel(ctx)
+	breCtx := context.WithValue(ctx, testOnlyBlockRemoteExecKey, func() { <-cCtx.Done() })
+	breCtx = context.WithValue(breCtx, testOnlyBlockLocalExecKey, func() { cancel() })
+	got, err := server.RunCommand(breCtx, req)
+	if err != nil {
+		t.Fatalf("RunCommand() returned error: %v", err)
+	}
+	wantResult := &cpb.CommandResult{
+		Status:   cpb.CommandResultStatus_TIMEOUT,
+		ExitCode: LocalTimeoutExitCode,
+		Msg:      "local execution timed out after 1s",
+	}
+	if diff := cmp.Diff(wantResult, got.GetResult(), protocmp.Transform()); diff != "" {
+		t.Errorf("RunCommand() returned diff in result: (-want +got)\n%s", diff)
+	}
+	if got := string(got.GetStdout()); got != "partial\n" {
+		t.Errorf("RunCommand() returned stdout %q, want %q", got, "partial\n")
+	}
+	if got := got.GetActionLog().GetLocalMetadata().GetTimeoutPhase(); got != event.LocalCommandExecution {
+		t.Errorf("RunCommand() logged timeout phase %q, want %q", got, event.LocalCommandExecution)
+	}
+	if got := got.GetActionLog().GetCompletionStatus(); got != lpb.CompletionStatus_STATUS_TIMEOUT {
+		t.Errorf("RunCommand() logged completion status %v, want %v", got, lpb.CompletionStatus_STATUS_TIMEOUT)
+	}
+}
+
+func TestValidateOutputs(t *testing.T) {
+	tests := []struct {
+		name             string
+		validate         bool
+		detectUndeclared bool
+		fail             bool
+		wantValidation   *lpb.OutputValidation
+		wantStatus       cpb.CommandResultStatus_Value
+	}{
+		{
+			name:       "Disabled",
+			wantStatus: cpb.CommandResultStatus_SUCCESS,
+		},
+		{
+			name:           "Missing",
+			validate:       true,
+			wantValidation: &lpb.OutputValidation{MissingOutputs: []string{"out/gen", "out/missing.o"}},
+			wantStatus:     cpb.CommandResultStatus_SUCCESS,
+		},
+		{
+			name:             "Undeclared",
+			detectUndeclared: true,
+			wantValidation:   &lpb.OutputValidation{UndeclaredOutputs: []string{"out/foo.dwo", "out/foo.o.tmp"}},
+			wantStatus:       cpb.CommandResultStatus_SUCCESS,
+		},
+		{
+			name:             "Fail",
+			validate:         true,
+			detectUndeclared: true,
+			fail:             true,
+			wantValidation: &lpb.OutputValidation{
+				MissingOutputs:    []string{"out/gen", "out/missing.o"},
+				UndeclaredOutputs: []string{"out/foo.dwo", "out/foo.o.tmp"},
+			},
+			wantStatus: cpb.CommandResultStatus_LOCAL_ERROR,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			env, cleanup := fakes.NewTestEnv(t)
+			t.Cleanup(cleanup)
+			outDir := filepath.Join(env.ExecRoot, "out")
+			if err := os.MkdirAll(outDir, 0755); err != nil {
+				t.Fatalf("MkdirAll(%v) failed: %v", outDir, err)
+			}
+			// Written before the action, so not an output of the action.
+			stale := filepath.Join(outDir, "foo.old")
+			if err := os.WriteFile(stale, []byte("stale"), 0644); err != nil {
+				t.Fatalf("WriteFile(%v) failed: %v", stale, err)
+			}
+			old := time.Now().Add(-time.Hour)
+			if err := os.Chtimes(stale, old, old); err != nil {
+				t.Fatalf("Chtimes(%v) failed: %v", stale, err)
+			}
+			executor := &cmdExecStub{localExec: func(cmd *command.Command) {
+				// other.o is not named after an output, so might have been written by another action.
+				for _, name := range []string{"foo.o", "foo.o.tmp", "foo.dwo", "other.o"} {
+					if err := os.WriteFile(filepath.Join(outDir, name), []byte(name), 0644); err != nil {
+						t.Errorf("WriteFile(%v) failed: %v", name, err)
+					}
+				}
+			}}
+			resMgr := localresources.NewDefaultManager()
+			server := &Server{
+				LocalPool:               NewLocalPool(executor, resMgr),
+				RemoteDisabled:          true,
+				MaxHoldoff:              time.Minute,
+				DownloadTmp:             t.TempDir(),
+				ValidateOutputs:         tc.validate,
+				DetectUndeclaredOutputs: tc.detectUndeclared,
+				FailOnInvalidOutputs:    tc.fail,
+			}
+			server.Init()
+			server.SetInputProcessor(inputprocessor.NewInputProcessorWithStubDependencyScanner(&stubCPPDependencyScanner{}, false, nil, resMgr), func() {})
+			server.SetREClient(env.Client, func() {})
+			lg, err := logger.New(logger.TextFormat, env.ExecRoot, stats.New(), nil, nil, nil)
+			if err != nil {
+				t.Errorf("error initializing logger: %v", err)
+			}
+			server.Logger = lg
+			req := &ppb.RunRequest{
+				Command: &cpb.Command{
+					Args:     []string{"tool"},
+					ExecRoot: env.ExecRoot,
+					Output: &cpb.OutputSpec{
+						OutputFiles:       []string{"out/foo.o", "out/missing.o"},
+						OutputDirectories: []string{"out/gen"},
+					},
+				},
+				Labels: map[string]string{"type": "tool"},
+				ExecutionOptions: &ppb.ProxyExecutionOptions{
+					ExecutionStrategy: ppb.ExecutionStrategy_LOCAL,
+					ReclientTimeout:   3600,
+					IncludeActionLog:  true,
+				},
+			}
+			got, err := server.RunCommand(context.Background(), req)
+			if err != nil {
+				t.Fatalf("RunCommand() returned error: %v", err)
+			}
+			if got.GetResult().GetStatus() != tc.wantStatus {
+				t.Errorf("RunCommand() returned status %v, want %v", got.GetResult().GetStatus(), tc.wantStatus)
+			}
+			if diff := cmp.Diff(tc.wantValidation, got.GetActionLog().GetOutputValidation(), protocmp.Transform()); diff != "" {
+				t.Errorf("RunCommand() logged diff in output validation: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestOutputValidationCaching verifies that the outputs of an action are validated before its
+// result is cached, so that a result with missing outputs is never cached, while undeclared outputs
+// alone do not prevent caching.
+func TestOutputValidationCaching(t *testing.T) {
+	tests := []struct {
+		name             string
+		outputs          []string
+		detectUndeclared bool
+		wantValidation   *lpb.OutputValidation
+		wantExecs        int
+	}{
+		{
+			name:           "Missing",
+			outputs:        []string{abOutPath, "missing.o"},
+			wantValidation: &lpb.OutputValidation{MissingOutputs: []string{"missing.o"}},
+			wantExecs:      2,
+		},
+		{
+			name:             "Undeclared",
+			outputs:          []string{abOutPath},
+			detectUndeclared: true,
+			wantValidation:   &lpb.OutputValidation{UndeclaredOutputs: []string{abOutPath + ".tmp"}},
+			wantExecs:        1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			env, cleanup := fakes.NewTestEnv(t)
+			fmc := filemetadata.NewSingleFlightCache()
+			env.Client.FileMetadataCache = fmc
+			t.Cleanup(cleanup)
+			numExecs := 0
+			executor := &execStub{
+				localExec: func() {
+					numExecs++
+					execroot.AddFileWithContent(t, filepath.Join(env.ExecRoot, abOutPath), []byte("output"))
+					execroot.AddFileWithContent(t, filepath.Join(env.ExecRoot, abOutPath+".tmp"), []byte("side output"))
+				},
+			}
+			lc, err := actioncache.New(t.TempDir(), 1<<20)
+			if err != nil {
+				t.Fatalf("actioncache.New() failed: %v", err)
+			}
+			resMgr := localresources.NewDefaultManager()
+			server := &Server{
+				LocalPool:               NewLocalPool(executor, resMgr),
+				LocalCache:              lc,
+				RemoteDisabled:          true,
+				MaxHoldoff:              time.Minute,
+				DownloadTmp:             t.TempDir(),
+				FileMetadataStore:       fmc,
+				ValidateOutputs:         true,
+				DetectUndeclaredOutputs: tc.detectUndeclared,
+			}
+			server.Init()
+			server.SetInputProcessor(inputprocessor.NewInputProcessorWithStubDependencyScanner(&stubCPPDependencyScanner{}, false, nil, resMgr), func() {})
+			server.SetREClient(env.Client, func() {})
+			lg, err := logger.New(logger.TextFormat, env.ExecRoot, stats.New(), nil, nil, nil)
+			if err != nil {
+				t.Errorf("error initializing logger: %v", err)
+			}
+			server.Logger = lg
+			req := &ppb.RunRequest{
+				Command: &cpb.Command{
+					Args:     []string{"tool"},
+					ExecRoot: env.ExecRoot,
+					Output:   &cpb.OutputSpec{OutputFiles: tc.outputs},
+				},
+				Labels: map[string]string{"type": "tool"},
+				ExecutionOptions: &ppb.ProxyExecutionOptions{
+					ExecutionStrategy:     ppb.ExecutionStrategy_LOCAL,
+					LocalExecutionOptions: &ppb.LocalExecutionOptions{AcceptCached: true},
+					ReclientTimeout:       3600,
+					IncludeActionLog:      true,
+				},
+			}
+			got, err := server.RunCommand(context.Background(), req)
+			if err != nil {
+				t.Fatalf("RunCommand() returned error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantValidation, got.GetActionLog().GetOutputValidation(), protocmp.Transform()); diff != "" {
+				t.Errorf("RunCommand() logged diff in output validation: (-want +got)\n%s", diff)
+			}
+			if _, err := server.RunCommand(context.Background(), req); err != nil {
+				t.Fatalf("RunCommand() returned error: %v", err)
+			}
+			if numExecs != tc.wantExecs {
+				t.Errorf("number of local executions = %v, want %v", numExecs, tc.wantExecs)
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	env, cleanup := fakes.NewTestEnv(t)
+	t.Cleanup(cleanup)
+	fmc := filemetadata.NewSingleFlightCache()
+	env.Client.FileMetadataCache = fmc
+	executed := 0
+	executor := &cmdExecStub{localExec: func(cmd *command.Command) { executed++ }}
+	resMgr := localresources.NewDefaultManager()
+	server := &Server{
+		LocalPool:           NewLocalPool(executor, resMgr),
+		BreakerErrorRatio:   0.5,
+		BreakerMinActions:   1,
+		BreakerOpenDuration: time.Hour,
+		BreakerProbes:       1,
+		MaxHoldoff:          time.Minute,
+		DownloadTmp:         t.TempDir(),
+		FileMetadataStore:   fmc,
+	}
+	server.Init()
+	server.SetInputProcessor(inputprocessor.NewInputProcessorWithStubDependencyScanner(&stubCPPDependencyScanner{}, false, nil, resMgr), func() {})
+	server.SetREClient(env.Client, func() {})
+	lg, err := logger.New(logger.TextFormat, env.ExecRoot, stats.New(), nil, nil, nil)
+	if err != nil {
+		t.Errorf("error initializing logger: %v", err)
+	}
+	server.Logger = lg
+	req := &ppb.RunRequest{
+		Command: &cpb.Command{
+			Args:     []string{"tool"},
+			ExecRoot: env.ExecRoot,
+			Output: &cpb.OutputSpec{
+				OutputFiles: []string{abOutPath},
+			},
+		},
+		Labels: map[string]string{"type": "tool"},
+		ExecutionOptions: &ppb.ProxyExecutionOptions{
+			ExecutionStrategy: ppb.ExecutionStrategy_REMOTE_LOCAL_FALLBACK,
+			// The fake returns cache hits for remote errors when the cache is accepted.
+			RemoteExecutionOptions: &ppb.RemoteExecutionOptions{AcceptCached: false},
+			ReclientTimeout:        3600,
+			IncludeActionLog:       true,
+		},
+	}
+	cmd := &command.Command{
+		Identifiers: &command.Identifiers{},
+		Args:        []string{"tool"},
+		ExecRoot:    env.ExecRoot,
+		InputSpec:   &command.InputSpec{},
+		OutputFiles: []string{abOutPath},
+	}
+	setPlatformOSFamily(cmd)
+	env.Set(cmd, command.DefaultExecutionOptions(), &command.Result{Status: command.RemoteErrorResultStatus, Err: status.Error(codes.Unavailable, "unavailable"), ExitCode: 45})
+	ctx := context.Background()
+
+	// The remote error trips the breaker, after the action falls back to local execution.
+	got, err := server.RunCommand(ctx, req)
+	if err != nil {
+		t.Fatalf("RunCommand() returned error: %v", err)
+	}
+	if got.GetRemoteFallbackInfo() == nil {
+		t.Errorf("RunCommand() did not fall back to local execution after a remote error")
+	}
+	if got.GetActionLog().GetLocalMetadata().GetRemoteBypassed() {
+		t.Errorf("RunCommand() bypassed remote execution before the breaker tripped")
+	}
+
+	// The next action skips remote execution.
+	got, err = server.RunCommand(ctx, req)
+	if err != nil {
+		t.Fatalf("RunCommand() returned error: %v", err)
+	}
+	if got.GetResult().GetStatus() != cpb.CommandResultStatus_SUCCESS {
+		t.Errorf("RunCommand() returned status %v, want SUCCESS", got.GetResult().GetStatus())
+	}
+	if got.GetRemoteFallbackInfo() != nil {
+		t.Errorf("RunCommand() attempted remote execution while the breaker was open: %v", got.GetRemoteFallbackInfo())
+	}
+	if !got.GetActionLog().GetLocalMetadata().GetRemoteBypassed() {
+		t.Errorf("RunCommand() did not log that remote execution was bypassed")
+	}
+	if executed != 2 {
+		t.Errorf("Executed %v actions locally, want 2", executed)
+	}
+	// Bypassing remote execution must not make the build fail early.
+	if nf := server.numFallbacks.Load(); nf != 1 {
+		t.Errorf("numFallbacks = %v, want 1", nf)
+	}
+}
+
+func TestRemoteRetries(t *testing.T) {
+	env, cleanup := fakes.NewTestEnv(t)
+	t.Cleanup(cleanup)
+	fmc := filemetadata.NewSingleFlightCache()
+	env.Client.FileMetadataCache = fmc
+	executed := 0
+	executor := &cmdExecStub{localExec: func(cmd *command.Command) { executed++ }}
+	resMgr := localresources.NewDefaultManager()
+	server := &Server{
+		LocalPool:         NewLocalPool(executor, resMgr),
+		FileMetadataStore: fmc,
+		RetryPolicy: &ppb.RemoteRetryPolicy{
+			Transient:  &ppb.RemoteRetryRule{MaxRetries: 2},
+			WorkerLost: &ppb.RemoteRetryRule{MaxRetries: 1, EscalatePlatform: map[string]string{"machine-type": "large"}},
+		},
+	}
+	server.Init()
+	server.SetInputProcessor(inputprocessor.NewInputProcessorWithStubDependencyScanner(&stubCPPDependencyScanner{}, false, nil, resMgr), func() {})
+	server.SetREClient(env.Client, func() {})
+	lg, err := logger.New(logger.TextFormat, env.ExecRoot, stats.New(), nil, nil, nil)
+	if err != nil {
+		t.Errorf("error initializing logger: %v", err)
+	}
+	server.Logger = lg
+	request := func(tool string, strategy ppb.ExecutionStrategy_Value, acceptCached bool) *ppb.RunRequest {
+		return &ppb.RunRequest{
+			Command: &cpb.Command{
+				Args:     []string{tool},
+				ExecRoot: env.ExecRoot,
+				Output: &cpb.OutputSpec{
+					OutputFiles: []string{abOutPath},
+				},
+				Platform: map[string]string{"machine-type": "small"},
+			},
+			Labels: map[string]string{"type": "tool"},
+			ExecutionOptions: &ppb.ProxyExecutionOptions{
+				ExecutionStrategy:      strategy,
+				RemoteExecutionOptions: &ppb.RemoteExecutionOptions{AcceptCached: acceptCached},
+				ReclientTimeout:        3600,
+				IncludeActionLog:       true,
+			},
+		}
+	}
+	remoteCmd := func(tool, machineType string) *command.Command {
+		cmd := &command.Command{
+			Identifiers: &command.Identifiers{},
+			Args:        []string{tool},
+			ExecRoot:    env.ExecRoot,
+			InputSpec:   &command.InputSpec{},
+			OutputFiles: []string{abOutPath},
+			Platform:    map[string]string{"machine-type": machineType},
+		}
+		setPlatformOSFamily(cmd)
+		return cmd
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		setup        func()
+		req          *ppb.RunRequest
+		wantStatus   cpb.CommandResultStatus_Value
+		wantAttempts []*lpb.RemoteAttempt
+		wantExecuted int
+	}{
+		{
+			name: "EscalatedPlatform",
+			setup: func() {
+				// The fake only executes a single action, so the escalated one is a cache hit.
+				env.Set(remoteCmd("oom", "large"), command.DefaultExecutionOptions(), &command.Result{Status: command.CacheHitResultStatus}, &fakes.OutputFile{abOutPath, "output"})
+				env.Set(remoteCmd("oom", "small"), command.DefaultExecutionOptions(), &command.Result{Status: command.NonZeroExitResultStatus, ExitCode: sigkillExitCode})
+			},
+			req:        request("oom", ppb.ExecutionStrategy_REMOTE, true),
+			wantStatus: cpb.CommandResultStatus_CACHE_HIT,
+			wantAttempts: []*lpb.RemoteAttempt{
+				{Attempt: 1, Result: &cpb.CommandResult{Status: cpb.CommandResultStatus_NON_ZERO_EXIT, ExitCode: sigkillExitCode}, ErrorClass: lpb.RemoteErrorClass_ERROR_CLASS_WORKER_LOST},
+				{Attempt: 2, Result: &cpb.CommandResult{Status: cpb.CommandResultStatus_CACHE_HIT}, PlatformOverrides: map[string]string{"machine-type": "large"}},
+			},
+		},
+		{
+			name: "RetriesExhausted",
+			setup: func() {
+				env.Set(remoteCmd("flaky", "small"), command.DefaultExecutionOptions(), &command.Result{Status: command.RemoteErrorResultStatus, Err: status.Error(codes.Unavailable, "unavailable"), ExitCode: 45})
+			},
+			// The fake returns cache hits for remote errors when the cache is accepted.
+			req:        request("flaky", ppb.ExecutionStrategy_REMOTE_LOCAL_FALLBACK, false),
+			wantStatus: cpb.CommandResultStatus_SUCCESS,
+			wantAttempts: []*lpb.RemoteAttempt{
+				{Attempt: 1, Result: &cpb.CommandResult{Status: cpb.CommandResultStatus_REMOTE_ERROR, ExitCode: 45}, ErrorClass: lpb.RemoteErrorClass_ERROR_CLASS_TRANSIENT},
+				{Attempt: 2, Result: &cpb.CommandResult{Status: cpb.CommandResultStatus_REMOTE_ERROR, ExitCode: 45}, ErrorClass: lpb.RemoteErrorClass_ERROR_CLASS_TRANSIENT},
+				{Attempt: 3, Result: &cpb.CommandResult{Status: cpb.CommandResultStatus_REMOTE_ERROR, ExitCode: 45}, ErrorClass: lpb.RemoteErrorClass_ERROR_CLASS_TRANSIENT},
+			},
+			// The action only falls back to local execution once the retries are exhausted.
+			wantExecuted: 1,
+		},
+		{
+			name: "NotRetried",
+			setup: func() {
+				env.Set(remoteCmd("fails", "small"), command.DefaultExecutionOptions(), &command.Result{Status: command.NonZeroExitResultStatus, ExitCode: 1})
+			},
+			req:        request("fails", ppb.ExecutionStrategy_REMOTE, false),
+			wantStatus: cpb.CommandResultStatus_NON_ZERO_EXIT,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// Set does not reset the status of earlier remote errors.
+			env.Server.Exec.Status = nil
+			tc.setup()
+			executed = 0
+			got, err := server.RunCommand(ctx, tc.req)
+			if err != nil {
+				t.Fatalf("RunCommand() returned error: %v", err)
+			}
+			if got.GetResult().GetStatus() != tc.wantStatus {
+				t.Errorf("RunCommand() returned status %v, want %v", got.GetResult().GetStatus(), tc.wantStatus)
+			}
+			opts := []cmp.Option{
+				protocmp.Transform(),
+				protocmp.IgnoreFields(&lpb.RemoteAttempt{}, "action_digest"),
+				protocmp.IgnoreFields(&cpb.CommandResult{}, "msg"),
+			}
+			if diff := cmp.Diff(tc.wantAttempts, got.GetActionLog().GetRemoteMetadata().GetAttempts(), opts...); diff != "" {
+				t.Errorf("RunCommand() logged diff in remote attempts: (-want +got)\n%s", diff)
+			}
+			if executed != tc.wantExecuted {
+				t.Errorf("Executed the action locally %v times, want %v", executed, tc.wantExecuted)
+			}
+		})
+	}
+}
+
+// TestRemoteRetriesReclientTimeout verifies that each remote attempt has its own reclient timeout,
+// so that attempts timed out by it are retried with a full timeout.
+func TestRemoteRetriesReclientTimeout(t *testing.T) {
+	env, cleanup := fakes.NewTestEnv(t)
+	t.Cleanup(cleanup)
+	fmc := filemetadata.NewSingleFlightCache()
+	env.Client.FileMetadataCache = fmc
+	resMgr := localresources.NewDefaultManager()
+	server := &Server{
+		LocalPool:         NewLocalPool(&cmdExecStub{}, resMgr),
+		FileMetadataStore: fmc,
+		RetryPolicy: &ppb.RemoteRetryPolicy{
+			DeadlineExceeded: &ppb.RemoteRetryRule{MaxRetries: 1},
+		},
+	}
+	server.Init()
+	server.SetInputProcessor(inputprocessor.NewInputProcessorWithStubDependencyScanner(&stubCPPDependencyScanner{}, false, nil, resMgr), func() {})
+	server.SetREClient(env.Client, func() {})
+	lg, err := logger.New(logger.TextFormat, env.ExecRoot, stats.New(), nil, nil, nil)
+	if err != nil {
+		t.Errorf("error initializing logger: %v", err)
+	}
+	server.Logger = lg
+	cmd := &command.Command{
+		Identifiers: &command.Identifiers{},
+		Args:        []string{"tool"},
+		ExecRoot:    env.ExecRoot,
+		InputSpec:   &command.InputSpec{},
+		OutputFiles: []string{abOutPath},
+	}
+	setPlatformOSFamily(cmd)
+	env.Set(cmd, command.DefaultExecutionOptions(), &command.Result{Status: command.SuccessResultStatus}, &fakes.OutputFile{Path: abOutPath, Contents: "output"})
+	req := &ppb.RunRequest{
+		Command: &cpb.Command{
+			Args:     []string{"tool"},
+			ExecRoot: env.ExecRoot,
+			Output:   &cpb.OutputSpec{OutputFiles: []string{abOutPath}},
+		},
+		Labels: map[string]string{"type": "tool"},
+		ExecutionOptions: &ppb.ProxyExecutionOptions{
+			ExecutionStrategy:      ppb.ExecutionStrategy_REMOTE,
+			RemoteExecutionOptions: &ppb.RemoteExecutionOptions{AcceptCached: true},
+			ReclientTimeout:        1,
+			IncludeActionLog:       true,
+		},
+	}
+	attempts := 0
+	// The first attempt outlives its reclient timeout, the second one does not.
+	ctx := context.WithValue(context.Background(), testOnlyRemoteAttemptKey, func(ctx context.Context) {
+		attempts++
+		if attempts == 1 {
+			<-ctx.Done()
+		}
+	})
+	got, err := server.RunCommand(ctx, req)
+	if err != nil {
+		t.Fatalf("RunCommand() returned error: %v", err)
+	}
+	if got.GetResult().GetStatus() != cpb.CommandResultStatus_SUCCESS {
+		t.Errorf("RunCommand() returned status %v, want %v", got.GetResult().GetStatus(), cpb.CommandResultStatus_SUCCESS)
+	}
+	wantAttempts := []*lpb.RemoteAttempt{
+		{Attempt: 1, Result: &cpb.CommandResult{Status: cpb.CommandResultStatus_TIMEOUT, ExitCode: ReclientTimeoutExitCode}, ErrorClass: lpb.RemoteErrorClass_ERROR_CLASS_DEADLINE_EXCEEDED},
+		{Attempt: 2, Result: &cpb.CommandResult{Status: cpb.CommandResultStatus_SUCCESS}},
+	}
+	opts := []cmp.Option{
+		protocmp.Transform(),
+		protocmp.IgnoreFields(&lpb.RemoteAttempt{}, "action_digest"),
+		protocmp.IgnoreFields(&cpb.CommandResult{}, "msg"),
+	}
+	if diff := cmp.Diff(wantAttempts, got.GetActionLog().GetRemoteMetadata().GetAttempts(), opts...); diff != "" {
+		t.Errorf("RunCommand() logged diff in remote attempts: (-want +got)\n%s", diff)
+	}
+}
+
+func TestRacingRemoteOnCacheHit(t *testing.T) {
+	env, cleanup := fakes.NewTestEnv(t)
+	t.Cleanup(cleanup)
+	fmc := filemetadata.NewSingleFlightCache()
+	env.Client.FileMetadataCache = fmc
+	var executed atomic.Int32
+	executor := &cmdExecStub{localExec: func(cmd *command.Command) { executed.Add(1) }}
+	resMgr := localresources.NewDefaultManager()
+	lbls := map[string]string{"type": "tool"}
+	server := &Server{
+		LocalPool:         NewLocalPool(executor, resMgr),
+		FileMetadataStore: fmc,
+		// Actions with these labels are always cache hits, and downloading their outputs takes no
+		// time, so starting local execution of cache hits is never worth it.
+		Forecast:    forecastWith(lbls, []int{0}, nil, []int{60000}, []bool{true, true, true}),
+		MaxHoldoff:  time.Minute,
+		DownloadTmp: t.TempDir(),
+	}
+	server.Init()
+	server.SetInputProcessor(inputprocessor.NewInputProcessorWithStubDependencyScanner(&stubCPPDependencyScanner{}, false, nil, resMgr), func() {})
+	server.SetREClient(env.Client, func() {})
+	lg, err := logger.New(logger.TextFormat, env.ExecRoot, stats.New(), nil, nil, nil)
+	if err != nil {
+		t.Errorf("error initializing logger: %v", err)
+	}
+	server.Logger = lg
+	req := &ppb.RunRequest{
+		Command: &cpb.Command{
+			Args:     []string{"tool"},
+			ExecRoot: env.ExecRoot,
+			Output: &cpb.OutputSpec{
+				OutputFiles: []string{abOutPath},
+			},
+		},
+		Labels:           lbls,
+		ExecutionOptions: &ppb.ProxyExecutionOptions{ExecutionStrategy: ppb.ExecutionStrategy_RACING, ReclientTimeout: 3600},
+	}
+	cmd := &command.Command{
+		Identifiers: &command.Identifiers{},
+		Args:        []string{"tool"},
+		ExecRoot:    env.ExecRoot,
+		InputSpec:   &command.InputSpec{},
+		OutputFiles: []string{abOutPath},
+	}
+	setPlatformOSFamily(cmd)
+	env.Set(cmd, command.DefaultExecutionOptions(), &command.Result{Status: command.CacheHitResultStatus}, &fakes.OutputFile{abOutPath, "hello"})
+	got, err := server.RunCommand(context.Background(), req)
+	if err != nil {
+		t.Fatalf("RunCommand() returned error: %v", err)
+	}
+	if got.GetResult().GetStatus() != cpb.CommandResultStatus_CACHE_HIT {
+		t.Errorf("RunCommand() returned status %v, want CACHE_HIT", got.GetResult().GetStatus())
+	}
+	if n := executed.Load(); n != 0 {
+		t.Errorf("RunCommand() executed the cache hit locally %v times, want 0", n)
+	}
+}
+
+func TestTraceExporter(t *testing.T) {
+	env, cleanup := fakes.NewTestEnv(t)
+	t.Cleanup(cleanup)
+	tracePath := filepath.Join(t.TempDir(), "traces.jsonl")
+	te, err := otlptrace.NewFileExporter(tracePath, nil)
+	if err != nil {
+		t.Fatalf("NewFileExporter() returned error: %v", err)
+	}
+	resMgr := localresources.NewDefaultManager()
+	server := &Server{
+		LocalPool:      NewLocalPool(&execStub{localExec: func() {}}, resMgr),
+		RemoteDisabled: true,
+		MaxHoldoff:     time.Minute,
+		DownloadTmp:    t.TempDir(),
+		TraceExporter:  te,
+	}
+	server.Init()
+	server.SetInputProcessor(inputprocessor.NewInputProcessorWithStubDependencyScanner(&stubCPPDependencyScanner{}, false, nil, resMgr), func() {})
+	server.SetREClient(env.Client, func() {})
+	lg, err := logger.New(logger.TextFormat, env.ExecRoot, stats.New(), nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error initializing logger: %v", err)
+	}
+	server.Logger = lg
+	req := &ppb.RunRequest{
+		Command: &cpb.Command{
+			Identifiers: &cpb.Identifiers{CommandId: "cmd", InvocationId: "invocation"},
+			Args:        []string{"tool"},
+			ExecRoot:    env.ExecRoot,
+		},
+		Labels: map[string]string{"type": "tool"},
+		ExecutionOptions: &ppb.ProxyExecutionOptions{
+			ExecutionStrategy: ppb.ExecutionStrategy_LOCAL,
+			ReclientTimeout:   3600,
+		},
+	}
+	if _, err := server.RunCommand(context.Background(), req); err != nil {
+		t.Fatalf("RunCommand() returned error: %v", err)
+	}
+	if err := te.Close(); err != nil {
+		t.Fatalf("Close() returned error: %v", err)
+	}
+	b, err := os.ReadFile(tracePath)
+	if err != nil {
+		t.Fatalf("Failed to read %v: %v", tracePath, err)
+	}
+	for _, name := range []string{"RunCommand", event.LocalCommandQueued, event.LocalCommandExecution} {
+		if !strings.Contains(string(b), fmt.Sprintf("%q", name)) {
+			t.Errorf("RunCommand() exported spans %s, want a %v span", b, name)
+		}
+	}
+}
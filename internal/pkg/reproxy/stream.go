@@ -0,0 +1,143 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reproxy
+
+import (
+	"sync"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/outerr"
+
+	ppb "github.com/bazelbuild/reclient/api/proxy"
+	log "github.com/golang/glog"
+)
+
+// outputChunkSize is the maximum size of stdout and stderr in a single streamed response.
+const outputChunkSize = 1 << 20
+
+// outputStream sends the output of an action to the client of a RunCommandStream call as it is
+// produced by local execution.
+type outputStream struct {
+	executionID string
+	send        func(*ppb.RunResponse) error
+
+	mu sync.Mutex
+	// oe records the output that was streamed so far, sentOut and sentErr being the number of bytes
+	// of its stdout and stderr that were sent.
+	oe      *outerr.RecordingOutErr
+	sentOut int
+	sentErr int
+	// err is the first error returned by send, after which nothing more is streamed.
+	err error
+}
+
+// tee returns an OutErr that writes to oe and streams every write to the client.
+func (s *outputStream) tee(oe outerr.OutErr) outerr.OutErr {
+	roe, ok := oe.(*outerr.RecordingOutErr)
+	if !ok {
+		return oe
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.oe != roe {
+		s.oe = roe
+		s.sentOut, s.sentErr = 0, 0
+		// Send what was recorded before, so that the streamed output is always a prefix of oe.
+		s.sendLocked(roe.Stdout(), roe.Stderr())
+	}
+	return &streamingOutErr{s: s, oe: roe}
+}
+
+// sendStarted sends a response without output, which only carries the execution ID.
+func (s *outputStream) sendStarted() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err = s.send(&ppb.RunResponse{ExecutionId: s.executionID}); s.err != nil {
+		log.Warningf("%v: Failed to stream output: %v", s.executionID, s.err)
+	}
+}
+
+// unsent returns the output recorded in oe that was not streamed yet.
+func (s *outputStream) unsent(oe *outerr.RecordingOutErr) (stdout, stderr []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if oe != s.oe {
+		return oe.Stdout(), oe.Stderr()
+	}
+	return oe.Stdout()[s.sentOut:], oe.Stderr()[s.sentErr:]
+}
+
+// sendFinal sends the final response of the action, split into several responses if its output
+// does not fit into a single chunk.
+func (s *outputStream) sendFinal(resp *ppb.RunResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stdout, stderr := resp.GetStdout(), resp.GetStderr()
+	for len(stdout) > outputChunkSize || len(stderr) > outputChunkSize {
+		var out, err []byte
+		out, stdout = splitChunk(stdout)
+		err, stderr = splitChunk(stderr)
+		if e := s.send(&ppb.RunResponse{ExecutionId: resp.GetExecutionId(), Stdout: out, Stderr: err}); e != nil {
+			return e
+		}
+	}
+	resp.Stdout, resp.Stderr = stdout, stderr
+	return s.send(resp)
+}
+
+// sendLocked streams the given output in chunks. Must be called with s.mu held.
+func (s *outputStream) sendLocked(stdout, stderr []byte) {
+	for s.err == nil && (len(stdout) > 0 || len(stderr) > 0) {
+		var out, err []byte
+		out, stdout = splitChunk(stdout)
+		err, stderr = splitChunk(stderr)
+		if s.err = s.send(&ppb.RunResponse{ExecutionId: s.executionID, Stdout: out, Stderr: err}); s.err != nil {
+			log.Warningf("%v: Failed to stream output: %v", s.executionID, s.err)
+			return
+		}
+		s.sentOut += len(out)
+		s.sentErr += len(err)
+	}
+}
+
+func splitChunk(b []byte) (chunk, rest []byte) {
+	if len(b) <= outputChunkSize {
+		return b, nil
+	}
+	return b[:outputChunkSize], b[outputChunkSize:]
+}
+
+// streamingOutErr records output in a RecordingOutErr and streams it to the client.
+type streamingOutErr struct {
+	s  *outputStream
+	oe *outerr.RecordingOutErr
+}
+
+func (o *streamingOutErr) WriteOut(buf []byte) {
+	o.s.mu.Lock()
+	defer o.s.mu.Unlock()
+	o.oe.WriteOut(buf)
+	if o.s.oe == o.oe {
+		o.s.sendLocked(buf, nil)
+	}
+}
+
+func (o *streamingOutErr) WriteErr(buf []byte) {
+	o.s.mu.Lock()
+	defer o.s.mu.Unlock()
+	o.oe.WriteErr(buf)
+	if o.s.oe == o.oe {
+		o.s.sendLocked(nil, buf)
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reproxy
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bazelbuild/reclient/internal/pkg/logger"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+
+	ppb "github.com/bazelbuild/reclient/api/proxy"
+)
+
+// watchBufferSize is the number of events buffered for each WatchActions stream. Streams that fall
+// further behind miss events rather than slowing down the actions.
+const watchBufferSize = 1024
+
+// actionWatcher is a WatchActions stream.
+type actionWatcher struct {
+	invocationID string
+	events       chan *ppb.ActionEvent
+	// dropped is the number of events dropped since the last event sent to the stream.
+	dropped int64
+}
+
+// actionWatchers broadcasts the events of the actions to the WatchActions streams.
+type actionWatchers struct {
+	mu       sync.Mutex
+	watchers map[*actionWatcher]bool
+}
+
+// add registers a stream for the events of the actions of the given invocation, or of all actions
+// if invocationID is empty.
+func (w *actionWatchers) add(invocationID string) *actionWatcher {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.watchers == nil {
+		w.watchers = make(map[*actionWatcher]bool)
+	}
+	aw := &actionWatcher{invocationID: invocationID, events: make(chan *ppb.ActionEvent, watchBufferSize)}
+	w.watchers[aw] = true
+	return aw
+}
+
+func (w *actionWatchers) remove(aw *actionWatcher) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.watchers, aw)
+}
+
+// publish sends the event returned by ev to the streams watching its action. ev is only called if
+// there are any.
+func (w *actionWatchers) publish(invocationID string, ev func() *ppb.ActionEvent) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var e *ppb.ActionEvent
+	for aw := range w.watchers {
+		if aw.invocationID != "" && aw.invocationID != invocationID {
+			continue
+		}
+		if e == nil {
+			e = ev()
+		}
+		awe := e
+		if aw.dropped > 0 {
+			awe = proto.Clone(e).(*ppb.ActionEvent)
+			awe.DroppedEvents = aw.dropped
+		}
+		select {
+		case aw.events <- awe:
+			aw.dropped = 0
+		default:
+			aw.dropped++
+		}
+	}
+}
+
+// actionProgress tracks the phase of a running action and reports its events to the watchers. A
+// nil actionProgress, e.g. that of the reruns of compare mode, reports nothing.
+type actionProgress struct {
+	watchers     *actionWatchers
+	executionID  string
+	invocationID string
+	commandID    string
+	labels       map[string]string
+	strategy     ppb.ExecutionStrategy_Value
+
+	mu         sync.Mutex
+	startTime  time.Time
+	phase      ppb.ActionPhase_Value
+	phaseStart time.Time
+}
+
+func newActionProgress(w *actionWatchers, cmd *command.Command, lbls map[string]string, strategy ppb.ExecutionStrategy_Value) *actionProgress {
+	return &actionProgress{
+		watchers:     w,
+		executionID:  cmd.Identifiers.ExecutionID,
+		invocationID: cmd.Identifiers.InvocationID,
+		commandID:    cmd.Identifiers.CommandID,
+		labels:       lbls,
+		strategy:     strategy,
+	}
+}
+
+func (p *actionProgress) event(t ppb.ActionEvent_Type, now time.Time) *ppb.ActionEvent {
+	return &ppb.ActionEvent{
+		Type:              t,
+		Time:              tspb.New(now),
+		ExecutionId:       p.executionID,
+		InvocationId:      p.invocationID,
+		CommandId:         p.commandID,
+		Labels:            p.labels,
+		ExecutionStrategy: p.strategy,
+	}
+}
+
+// start reports that the action was received.
+func (p *actionProgress) start() {
+	if p == nil {
+		return
+	}
+	now := time.Now()
+	p.mu.Lock()
+	p.startTime, p.phaseStart = now, now
+	p.mu.Unlock()
+	p.watchers.publish(p.invocationID, func() *ppb.ActionEvent {
+		return p.event(ppb.ActionEvent_STARTED, now)
+	})
+}
+
+// setPhase reports that the action entered the given phase.
+func (p *actionProgress) setPhase(phase ppb.ActionPhase_Value) {
+	if p == nil {
+		return
+	}
+	now := time.Now()
+	p.mu.Lock()
+	p.phase, p.phaseStart = phase, now
+	p.mu.Unlock()
+	p.watchers.publish(p.invocationID, func() *ppb.ActionEvent {
+		ev := p.event(ppb.ActionEvent_PHASE_CHANGED, now)
+		ev.Phase = phase
+		return ev
+	})
+}
+
+// complete reports that the record of the action is final.
+func (p *actionProgress) complete(rec *logger.LogRecord) {
+	if p == nil {
+		return
+	}
+	p.watchers.publish(p.invocationID, func() *ppb.ActionEvent {
+		ev := p.event(ppb.ActionEvent_COMPLETED, time.Now())
+		ev.CompletionStatus = rec.GetCompletionStatus()
+		ev.Result = rec.GetResult()
+		return ev
+	})
+}
+
+// running returns the state of the running action.
+func (p *actionProgress) running() *ppb.RunningAction {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return &ppb.RunningAction{
+		ExecutionId:       p.executionID,
+		InvocationId:      p.invocationID,
+		CommandId:         p.commandID,
+		Labels:            p.labels,
+		ExecutionStrategy: p.strategy,
+		StartTime:         tspb.New(p.startTime),
+		Phase:             p.phase,
+		PhaseStartTime:    tspb.New(p.phaseStart),
+	}
+}
+
+type progressKey struct{}
+
+// withProgress returns a context through which LocalPool.Run reports the local phases of the
+// action.
+func (p *actionProgress) withProgress(ctx context.Context) context.Context {
+	if p == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, progressKey{}, p)
+}
+
+// setPhase reports the phase of the action whose progress ctx carries, if any.
+func setPhase(ctx context.Context, phase ppb.ActionPhase_Value) {
+	if p, ok := ctx.Value(progressKey{}).(*actionProgress); ok {
+		p.setPhase(phase)
+	}
+}
+
+// WatchActions streams the events of the actions started after the call, until the client cancels
+// the call or the server has shut down.
+func (s *Server) WatchActions(req *ppb.WatchActionsRequest, srv ppb.Stats_WatchActionsServer) error {
+	aw := s.watchers.add(req.GetInvocationId())
+	defer s.watchers.remove(aw)
+	for {
+		select {
+		case ev := <-aw.events:
+			if err := srv.Send(ev); err != nil {
+				return err
+			}
+		case <-srv.Context().Done():
+			return srv.Context().Err()
+		case <-s.cleanupDone:
+			// The actions completed while draining were reported before cleanup.
+			for {
+				select {
+				case ev := <-aw.events:
+					if err := srv.Send(ev); err != nil {
+						return err
+					}
+				default:
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// GetRunningActions returns the actions currently running, from the oldest to the most recent,
+// along with the length of the local execution queue.
+func (s *Server) GetRunningActions(ctx context.Context, req *ppb.GetRunningActionsRequest) (*ppb.GetRunningActionsResponse, error) {
+	if req.GetMaxActions() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid max_actions: %v, want >= 0", req.GetMaxActions())
+	}
+	var actions []*ppb.RunningAction
+	s.activeActions.Range(func(_, v any) bool {
+		a, ok := v.(*action)
+		if !ok || a.progress == nil {
+			return true
+		}
+		if req.GetInvocationId() != "" && a.progress.invocationID != req.GetInvocationId() {
+			return true
+		}
+		actions = append(actions, a.progress.running())
+		return true
+	})
+	sort.Slice(actions, func(i, j int) bool {
+		return actions[i].GetStartTime().AsTime().Before(actions[j].GetStartTime().AsTime())
+	})
+	resp := &ppb.GetRunningActionsResponse{TotalActions: int32(len(actions))}
+	if n := int(req.GetMaxActions()); n > 0 && len(actions) > n {
+		actions = actions[:n]
+	}
+	resp.Actions = actions
+	if s.LocalPool != nil {
+		resp.LocalQueueLength = s.LocalPool.QueueLength()
+	}
+	return resp, nil
+}
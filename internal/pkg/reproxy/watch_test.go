@@ -0,0 +1,263 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reproxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bazelbuild/reclient/internal/pkg/localresources"
+	"github.com/bazelbuild/reclient/internal/pkg/logger"
+	"github.com/bazelbuild/reclient/internal/pkg/stats"
+	"github.com/bazelbuild/reclient/pkg/inputprocessor"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/fakes"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/testing/protocmp"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+
+	lpb "github.com/bazelbuild/reclient/api/log"
+	ppb "github.com/bazelbuild/reclient/api/proxy"
+
+	cpb "github.com/bazelbuild/remote-apis-sdks/go/api/command"
+)
+
+// watchStreamStub forwards the events sent to a WatchActions stream to a channel.
+type watchStreamStub struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *ppb.ActionEvent
+}
+
+func (s *watchStreamStub) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchStreamStub) Send(ev *ppb.ActionEvent) error {
+	s.events <- ev
+	return nil
+}
+
+// watch starts a WatchActions call and waits until it is registered.
+func watch(t *testing.T, server *Server, invocationID string) *watchStreamStub {
+	t.Helper()
+	numWatchers := func() int {
+		server.watchers.mu.Lock()
+		defer server.watchers.mu.Unlock()
+		return len(server.watchers.watchers)
+	}
+	n := numWatchers()
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &watchStreamStub{ctx: ctx, events: make(chan *ppb.ActionEvent, 100)}
+	done := make(chan error)
+	go func() {
+		done <- server.WatchActions(&ppb.WatchActionsRequest{InvocationId: invocationID}, stream)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != context.Canceled {
+			t.Errorf("WatchActions() returned %v after the call was canceled, want %v", err, context.Canceled)
+		}
+	})
+	for start := time.Now(); numWatchers() == n; time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > 10*time.Second {
+			t.Fatalf("WatchActions() did not register the stream")
+		}
+	}
+	return stream
+}
+
+func TestWatchActions(t *testing.T) {
+	env, cleanup := fakes.NewTestEnv(t)
+	t.Cleanup(cleanup)
+	resMgr := localresources.NewDefaultManager()
+	server := &Server{
+		LocalPool:      NewLocalPool(&execStub{localExec: func() {}}, resMgr),
+		RemoteDisabled: true,
+		MaxHoldoff:     time.Minute,
+		DownloadTmp:    t.TempDir(),
+	}
+	server.Init()
+	server.SetInputProcessor(inputprocessor.NewInputProcessorWithStubDependencyScanner(&stubCPPDependencyScanner{}, false, nil, resMgr), func() {})
+	server.SetREClient(env.Client, func() {})
+	lg, err := logger.New(logger.TextFormat, env.ExecRoot, stats.New(), nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error initializing logger: %v", err)
+	}
+	server.Logger = lg
+	all := watch(t, server, "")
+	other := watch(t, server, "other-invocation")
+
+	req := &ppb.RunRequest{
+		Command: &cpb.Command{
+			Identifiers: &cpb.Identifiers{CommandId: "cmd", InvocationId: "invocation"},
+			Args:        []string{"tool"},
+			ExecRoot:    env.ExecRoot,
+		},
+		Labels: map[string]string{"type": "tool"},
+		ExecutionOptions: &ppb.ProxyExecutionOptions{
+			ExecutionStrategy: ppb.ExecutionStrategy_LOCAL,
+			ReclientTimeout:   3600,
+		},
+	}
+	if _, err := server.RunCommand(context.Background(), req); err != nil {
+		t.Fatalf("RunCommand() returned error: %v", err)
+	}
+
+	action := func(ev *ppb.ActionEvent) *ppb.ActionEvent {
+		ev.InvocationId = "invocation"
+		ev.CommandId = "8ea55c85-cmd" // The command ID is prefixed with the digest of the labels.
+		ev.Labels = map[string]string{"type": "tool"}
+		ev.ExecutionStrategy = ppb.ExecutionStrategy_LOCAL
+		return ev
+	}
+	want := []*ppb.ActionEvent{
+		action(&ppb.ActionEvent{Type: ppb.ActionEvent_STARTED}),
+		action(&ppb.ActionEvent{Type: ppb.ActionEvent_PHASE_CHANGED, Phase: ppb.ActionPhase_LOCAL_QUEUE}),
+		action(&ppb.ActionEvent{Type: ppb.ActionEvent_PHASE_CHANGED, Phase: ppb.ActionPhase_LOCAL_EXECUTION}),
+		action(&ppb.ActionEvent{
+			Type:             ppb.ActionEvent_COMPLETED,
+			CompletionStatus: lpb.CompletionStatus_STATUS_LOCAL_EXECUTION,
+			Result:           &cpb.CommandResult{Status: cpb.CommandResultStatus_SUCCESS},
+		}),
+	}
+	var got []*ppb.ActionEvent
+	for len(got) < len(want) {
+		select {
+		case ev := <-all.events:
+			got = append(got, ev)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("WatchActions() sent %v events, want %v", len(got), len(want))
+		}
+	}
+	executionID := got[0].GetExecutionId()
+	for _, ev := range got {
+		if ev.GetExecutionId() != executionID || ev.GetTime() == nil {
+			t.Errorf("WatchActions() sent event %v, want execution ID %q and a time", ev, executionID)
+		}
+	}
+	if diff := cmp.Diff(want, got, protocmp.IgnoreFields(&ppb.ActionEvent{}, "execution_id", "time"), protocmp.Transform()); diff != "" {
+		t.Errorf("WatchActions() sent diff in events: (-want +got)\n%s", diff)
+	}
+	select {
+	case ev := <-other.events:
+		t.Errorf("WatchActions() for another invocation sent %v, want no events", ev)
+	default:
+	}
+}
+
+func TestActionWatchersDropsEvents(t *testing.T) {
+	var w actionWatchers
+	aw := w.add("")
+	ev := func() *ppb.ActionEvent { return &ppb.ActionEvent{Type: ppb.ActionEvent_STARTED} }
+	for i := 0; i < watchBufferSize+2; i++ {
+		w.publish("invocation", ev)
+	}
+	for i := 0; i < watchBufferSize; i++ {
+		<-aw.events
+	}
+	w.publish("invocation", ev)
+	if got := (<-aw.events).GetDroppedEvents(); got != 2 {
+		t.Errorf("publish() sent an event with %v dropped events, want 2", got)
+	}
+	w.publish("invocation", ev)
+	if got := (<-aw.events).GetDroppedEvents(); got != 0 {
+		t.Errorf("publish() sent an event with %v dropped events, want 0", got)
+	}
+}
+
+func TestGetRunningActions(t *testing.T) {
+	server := &Server{LocalPool: NewLocalPool(&execStub{localExec: func() {}}, localresources.NewDefaultManager())}
+	start := time.Now()
+	add := func(id, invocationID string, age time.Duration, phase ppb.ActionPhase_Value) {
+		p := &actionProgress{
+			executionID:  id,
+			invocationID: invocationID,
+			commandID:    "cmd-" + id,
+			strategy:     ppb.ExecutionStrategy_REMOTE,
+			startTime:    start.Add(-age),
+			phase:        phase,
+			phaseStart:   start,
+		}
+		server.activeActions.Store(id, &action{progress: p})
+	}
+	add("new", "inv1", time.Second, ppb.ActionPhase_INPUT_PROCESSING)
+	add("old", "inv1", time.Minute, ppb.ActionPhase_UPLOAD_AND_EXECUTE)
+	add("other", "inv2", time.Hour, ppb.ActionPhase_DOWNLOAD)
+	// Reruns of compare mode are not tracked.
+	server.activeActions.Store("rerun", &action{})
+
+	tests := []struct {
+		name      string
+		req       *ppb.GetRunningActionsRequest
+		wantIDs   []string
+		wantTotal int32
+	}{
+		{
+			name:      "All",
+			req:       &ppb.GetRunningActionsRequest{},
+			wantIDs:   []string{"other", "old", "new"},
+			wantTotal: 3,
+		},
+		{
+			name:      "Invocation",
+			req:       &ppb.GetRunningActionsRequest{InvocationId: "inv1"},
+			wantIDs:   []string{"old", "new"},
+			wantTotal: 2,
+		},
+		{
+			name:      "MaxActions",
+			req:       &ppb.GetRunningActionsRequest{MaxActions: 2},
+			wantIDs:   []string{"other", "old"},
+			wantTotal: 3,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := server.GetRunningActions(context.Background(), tc.req)
+			if err != nil {
+				t.Fatalf("GetRunningActions(%v) returned error: %v", tc.req, err)
+			}
+			var ids []string
+			for _, a := range resp.GetActions() {
+				ids = append(ids, a.GetExecutionId())
+			}
+			if diff := cmp.Diff(tc.wantIDs, ids); diff != "" {
+				t.Errorf("GetRunningActions(%v) returned diff in actions: (-want +got)\n%s", tc.req, diff)
+			}
+			if resp.GetTotalActions() != tc.wantTotal {
+				t.Errorf("GetRunningActions(%v) returned %v total actions, want %v", tc.req, resp.GetTotalActions(), tc.wantTotal)
+			}
+		})
+	}
+	resp, err := server.GetRunningActions(context.Background(), &ppb.GetRunningActionsRequest{MaxActions: 1})
+	if err != nil {
+		t.Fatalf("GetRunningActions() returned error: %v", err)
+	}
+	want := &ppb.RunningAction{
+		ExecutionId:       "other",
+		InvocationId:      "inv2",
+		CommandId:         "cmd-other",
+		ExecutionStrategy: ppb.ExecutionStrategy_REMOTE,
+		StartTime:         tspb.New(start.Add(-time.Hour)),
+		Phase:             ppb.ActionPhase_DOWNLOAD,
+		PhaseStartTime:    tspb.New(start),
+	}
+	if diff := cmp.Diff(want, resp.GetActions()[0], protocmp.Transform()); diff != "" {
+		t.Errorf("GetRunningActions() returned diff in the oldest action: (-want +got)\n%s", diff)
+	}
+}
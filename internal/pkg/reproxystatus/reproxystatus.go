@@ -17,24 +17,36 @@ package reproxystatus
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"sort"
 	"strings"
 	"sync"
+	"text/tabwriter"
+	"time"
 
 	"github.com/fatih/color"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 
 	lpb "github.com/bazelbuild/reclient/api/log"
 	ppb "github.com/bazelbuild/reclient/api/proxy"
+	"github.com/bazelbuild/reclient/internal/pkg/event"
 	"github.com/bazelbuild/reclient/internal/pkg/ipc"
 )
 
-// Summary describes the result of calling Status.GetStatusSummary on a reproxy instance at Addr.
+// recordsPageSize is the maximum number of records fetched from reproxy at a time.
+const recordsPageSize = 100
+
+// Summary describes the result of calling Status.GetStatusSummary on a reproxy instance at Addr,
+// and, if requested, Stats.GetRunningActions.
 type Summary struct {
-	Addr string
-	Resp *ppb.GetStatusSummaryResponse
-	Err  error
+	Addr       string
+	Resp       *ppb.GetStatusSummaryResponse
+	Err        error
+	Running    *ppb.GetRunningActionsResponse
+	RunningErr error
 }
 
 // HumanReadable formats Summary into a human-readable format.
@@ -116,11 +128,39 @@ type ReproxyTracker interface {
 	FetchAllStatusSummaries(ctx context.Context) []*Summary
 }
 
+// reproxyClient is a connection to the services of a reproxy instance.
+type reproxyClient struct {
+	status ppb.StatusClient
+	stats  ppb.StatsClient
+}
+
+func newReproxyClient(conn *grpc.ClientConn) *reproxyClient {
+	return &reproxyClient{status: ppb.NewStatusClient(conn), stats: ppb.NewStatsClient(conn)}
+}
+
+// fetchSummary returns the Summary of the reproxy instance at addr, along with up to
+// runningActions of its oldest running actions if runningActions is greater than 0.
+func (c *reproxyClient) fetchSummary(ctx context.Context, addr string, runningActions int) *Summary {
+	resp, err := c.status.GetStatusSummary(ctx, &ppb.GetStatusSummaryRequest{})
+	s := &Summary{
+		Addr: addr,
+		Err:  err,
+		Resp: resp,
+	}
+	if err == nil && runningActions > 0 {
+		s.Running, s.RunningErr = c.stats.GetRunningActions(ctx, &ppb.GetRunningActionsRequest{MaxActions: int32(runningActions)})
+	}
+	return s
+}
+
 // SingleReproxyTracker manages a connection to a single reproxy instance listening on ServerAddress
 type SingleReproxyTracker struct {
 	ServerAddress string
-	client        ppb.StatusClient
-	mu            sync.RWMutex
+	// RunningActions is the number of the oldest running actions to fetch along with the summary,
+	// none if 0.
+	RunningActions int
+	client         *reproxyClient
+	mu             sync.RWMutex
 }
 
 // FetchAllStatusSummaries a Summary the reproxy instance at ServerAddress.
@@ -137,23 +177,19 @@ func (rt *SingleReproxyTracker) FetchAllStatusSummaries(ctx context.Context) []*
 				},
 			}
 		}
-		rt.client = ppb.NewStatusClient(conn)
-	}
-	resp, err := rt.client.GetStatusSummary(ctx, &ppb.GetStatusSummaryRequest{})
-	return []*Summary{
-		{
-			Addr: rt.ServerAddress,
-			Err:  err,
-			Resp: resp,
-		},
+		rt.client = newReproxyClient(conn)
 	}
+	return []*Summary{rt.client.fetchSummary(ctx, rt.ServerAddress, rt.RunningActions)}
 }
 
 // SocketReproxyTracker manages connections to all reproxy instances listening on unix sockets or windows pipes.
 type SocketReproxyTracker struct {
-	clients    map[string]ppb.StatusClient
-	dialErrors map[string]error
-	mu         sync.RWMutex
+	// RunningActions is the number of the oldest running actions of each instance to fetch along
+	// with the summaries, none if 0.
+	RunningActions int
+	clients        map[string]*reproxyClient
+	dialErrors     map[string]error
+	mu             sync.RWMutex
 }
 
 var (
@@ -168,18 +204,12 @@ func (rt *SocketReproxyTracker) FetchAllStatusSummaries(ctx context.Context) []*
 	rt.updateClients(ctx)
 	var wg sync.WaitGroup
 	out := make(chan *Summary, len(rt.clients))
-	for addr, statusClient := range rt.clients {
+	for addr, client := range rt.clients {
 		wg.Add(1)
-		go func(addr string, statusClient ppb.StatusClient) {
+		go func(addr string, client *reproxyClient) {
 			defer wg.Done()
-			resp, err := statusClient.GetStatusSummary(ctx, &ppb.GetStatusSummaryRequest{})
-			out <- &Summary{
-				Addr: addr,
-				Err:  err,
-				Resp: resp,
-			}
-
-		}(addr, statusClient)
+			out <- client.fetchSummary(ctx, addr, rt.RunningActions)
+		}(addr, client)
 	}
 	go func() {
 		wg.Wait()
@@ -206,7 +236,7 @@ func (rt *SocketReproxyTracker) FetchAllStatusSummaries(ctx context.Context) []*
 // These connections will be cached for future calls.
 func (rt *SocketReproxyTracker) updateClients(ctx context.Context) {
 	if rt.clients == nil {
-		rt.clients = map[string]ppb.StatusClient{}
+		rt.clients = map[string]*reproxyClient{}
 	}
 	var addrs []string
 	if testOnlyReproxySocketsKey != nil {
@@ -221,12 +251,12 @@ func (rt *SocketReproxyTracker) updateClients(ctx context.Context) {
 	}
 	rt.dialErrors = map[string]error{}
 	oldClients := rt.clients
-	rt.clients = make(map[string]ppb.StatusClient, len(addrs))
+	rt.clients = make(map[string]*reproxyClient, len(addrs))
 	for _, addr := range addrs {
 		if _, ok := oldClients[addr]; ok {
 			rt.clients[addr] = oldClients[addr]
 		} else if conn, err := ipc.DialContext(ctx, addr); err == nil {
-			rt.clients[addr] = ppb.NewStatusClient(conn)
+			rt.clients[addr] = newReproxyClient(conn)
 		} else {
 			rt.dialErrors[addr] = fmt.Errorf("failed to dial reproxy: %w", err)
 		}
@@ -244,6 +274,251 @@ func PrintSummaries(ctx context.Context, writer io.Writer, tracker ReproxyTracke
 	}
 }
 
+// runningActionsLess orders the running actions by each of the keys they can be sorted by.
+var runningActionsLess = map[string]func(a, b *ppb.RunningAction) bool{
+	// Longest running first.
+	"elapsed": func(a, b *ppb.RunningAction) bool {
+		return a.GetStartTime().AsTime().Before(b.GetStartTime().AsTime())
+	},
+	// Longest in their current phase first.
+	"phase_elapsed": func(a, b *ppb.RunningAction) bool {
+		return a.GetPhaseStartTime().AsTime().Before(b.GetPhaseStartTime().AsTime())
+	},
+	"command_id": func(a, b *ppb.RunningAction) bool {
+		return a.GetCommandId() < b.GetCommandId()
+	},
+	"labels": func(a, b *ppb.RunningAction) bool {
+		return formatLabels(a.GetLabels()) < formatLabels(b.GetLabels())
+	},
+	"strategy": func(a, b *ppb.RunningAction) bool {
+		return a.GetExecutionStrategy() < b.GetExecutionStrategy()
+	},
+	"phase": func(a, b *ppb.RunningAction) bool {
+		return a.GetPhase() < b.GetPhase()
+	},
+}
+
+// RunningActionsSortKeys returns the keys the running actions can be sorted by.
+func RunningActionsSortKeys() []string {
+	keys := make([]string, 0, len(runningActionsLess))
+	for k := range runningActionsLess {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SortRunningActions sorts the running actions by the given key. Actions with the same value of
+// the key keep their order.
+func SortRunningActions(actions []*ppb.RunningAction, by string) error {
+	less, ok := runningActionsLess[by]
+	if !ok {
+		return fmt.Errorf("invalid sort key %q, want one of %v", by, RunningActionsSortKeys())
+	}
+	sort.SliceStable(actions, func(i, j int) bool { return less(actions[i], actions[j]) })
+	return nil
+}
+
+// FormatRunningActions formats the running actions of a reproxy instance as a table, along with
+// the length of its local execution queue. Elapsed times are relative to now.
+func FormatRunningActions(running *ppb.GetRunningActionsResponse, now time.Time) string {
+	var sb strings.Builder
+	queued := fmt.Sprintf("local execution queue length: %d", running.GetLocalQueueLength())
+	if len(running.GetActions()) == 0 {
+		fmt.Fprintf(&sb, "No running actions, %s\n", queued)
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, "Oldest running actions (%d of %d), %s\n", len(running.GetActions()), running.GetTotalActions(), queued)
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "COMMAND ID\tLABELS\tSTRATEGY\tPHASE\tPHASE TIME\tELAPSED")
+	for _, a := range running.GetActions() {
+		phase := "starting"
+		if a.GetPhase() != ppb.ActionPhase_UNSPECIFIED {
+			phase = humanize(a.GetPhase().String())
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			a.GetCommandId(),
+			formatLabels(a.GetLabels()),
+			humanize(a.GetExecutionStrategy().String()),
+			phase,
+			formatElapsed(now.Sub(a.GetPhaseStartTime().AsTime())),
+			formatElapsed(now.Sub(a.GetStartTime().AsTime())))
+	}
+	tw.Flush()
+	return sb.String()
+}
+
+// PrintSummariesAndRunningActions is like PrintSummaries, but also prints the running actions of
+// each reproxy instance fetched by the tracker, sorted by the given key.
+func PrintSummariesAndRunningActions(ctx context.Context, writer io.Writer, tracker ReproxyTracker, sortBy string) error {
+	if _, ok := runningActionsLess[sortBy]; !ok {
+		return fmt.Errorf("invalid sort key %q, want one of %v", sortBy, RunningActionsSortKeys())
+	}
+	sums := tracker.FetchAllStatusSummaries(ctx)
+	if len(sums) == 0 {
+		fmt.Fprintf(writer, color.RedString("Reproxy is not running")+"\n")
+		return nil
+	}
+	now := time.Now()
+	for _, s := range sums {
+		fmt.Fprintf(writer, "%s\n", s.HumanReadable())
+		switch {
+		case s.RunningErr != nil:
+			fmt.Fprintf(writer, "%s\n\n", color.RedString("Failed to get the running actions: %v", s.RunningErr))
+		case s.Running != nil:
+			SortRunningActions(s.Running.GetActions(), sortBy)
+			fmt.Fprintf(writer, "%s\n", FormatRunningActions(s.Running, now))
+		}
+	}
+	return nil
+}
+
+func formatElapsed(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	return d.Truncate(100 * time.Millisecond).String()
+}
+
+// FollowActions prints the events of the actions of the reproxy instance at addr as they happen,
+// until ctx is done or reproxy shuts down.
+func FollowActions(ctx context.Context, writer io.Writer, addr string) error {
+	conn, err := ipc.DialContext(ctx, addr)
+	if err != nil {
+		return fmt.Errorf("failed to dial reproxy: %w", err)
+	}
+	defer conn.Close()
+	stream, err := ppb.NewStatsClient(conn).WatchActions(ctx, &ppb.WatchActionsRequest{})
+	if err != nil {
+		return err
+	}
+	for {
+		ev, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(writer, FormatActionEvent(ev))
+	}
+}
+
+// FormatActionEvent formats an event of an action into a single human-readable line.
+func FormatActionEvent(ev *ppb.ActionEvent) string {
+	var what string
+	switch ev.GetType() {
+	case ppb.ActionEvent_STARTED:
+		what = fmt.Sprintf("started %s (%s)", formatLabels(ev.GetLabels()), humanize(ev.GetExecutionStrategy().String()))
+	case ppb.ActionEvent_PHASE_CHANGED:
+		what = humanize(ev.GetPhase().String())
+	case ppb.ActionEvent_COMPLETED:
+		what = "completed: " + humanize(strings.TrimPrefix(ev.GetCompletionStatus().String(), "STATUS_"))
+		if code := ev.GetResult().GetExitCode(); code != 0 {
+			what += fmt.Sprintf(" (exit code %d)", code)
+		}
+		switch ev.GetCompletionStatus() {
+		case lpb.CompletionStatus_STATUS_NON_ZERO_EXIT, lpb.CompletionStatus_STATUS_TIMEOUT:
+			what = color.RedString(what)
+		case lpb.CompletionStatus_STATUS_REMOTE_FAILURE, lpb.CompletionStatus_STATUS_LOCAL_FAILURE:
+			what = color.MagentaString(what)
+		case lpb.CompletionStatus_STATUS_LOCAL_FALLBACK:
+			what = color.YellowString(what)
+		}
+	default:
+		what = humanize(ev.GetType().String())
+	}
+	line := fmt.Sprintf("%s %s %s", ev.GetTime().AsTime().Local().Format("15:04:05.000"), ev.GetCommandId(), what)
+	if n := ev.GetDroppedEvents(); n > 0 {
+		line += color.YellowString(" (%d earlier events missed)", n)
+	}
+	return line
+}
+
+// FetchRecords returns up to n of the most recent records kept by the reproxy instance at addr
+// that match the filters of req, from the most recent to the oldest.
+func FetchRecords(ctx context.Context, addr string, req *ppb.GetRecordsRequest, n int) ([]*lpb.LogRecord, error) {
+	conn, err := ipc.DialContext(ctx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial reproxy: %w", err)
+	}
+	defer conn.Close()
+	client := ppb.NewStatsClient(conn)
+	req = proto.Clone(req).(*ppb.GetRecordsRequest)
+	req.MostRecentFirst = true
+	var recs []*lpb.LogRecord
+	for len(recs) < n {
+		req.PageSize = recordsPageSize
+		if left := n - len(recs); left < recordsPageSize {
+			req.PageSize = int32(left)
+		}
+		resp, err := client.GetRecords(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		recs = append(recs, resp.GetRecords()...)
+		if resp.GetNextPageToken() == "" {
+			break
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+	return recs, nil
+}
+
+// FormatRecord formats the record of an action into a single human-readable line.
+func FormatRecord(rec *lpb.LogRecord) string {
+	completed, dur := "??:??:??.???", "?"
+	if ex := rec.GetLocalMetadata().GetEventTimes()[event.ProxyExecution]; ex.GetFrom() != nil && ex.GetTo() != nil {
+		completed = ex.GetTo().AsTime().Local().Format("15:04:05.000")
+		dur = ex.GetTo().AsTime().Sub(ex.GetFrom().AsTime()).Round(time.Millisecond).String()
+	}
+	line := fmt.Sprintf("%s %s %s in %s %s", completed, rec.GetCommand().GetIdentifiers().GetCommandId(),
+		humanize(strings.TrimPrefix(rec.GetCompletionStatus().String(), "STATUS_")), dur,
+		formatLabels(rec.GetLocalMetadata().GetLabels()))
+	if code := rec.GetResult().GetExitCode(); code != 0 {
+		line += fmt.Sprintf(" (exit code %d)", code)
+	}
+	if msg := rec.GetResult().GetMsg(); msg != "" {
+		line += ": " + msg
+	}
+	return line
+}
+
+// ParseCompletionStatuses parses a comma-separated list of completion statuses, such as
+// "remote_failure,local_fallback". The STATUS_ prefix of the names is optional.
+func ParseCompletionStatuses(list string) ([]lpb.CompletionStatus, error) {
+	var statuses []lpb.CompletionStatus
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		name = strings.ToUpper(name)
+		if !strings.HasPrefix(name, "STATUS_") {
+			name = "STATUS_" + name
+		}
+		st, ok := lpb.CompletionStatus_value[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown completion status %q", name)
+		}
+		statuses = append(statuses, lpb.CompletionStatus(st))
+	}
+	return statuses, nil
+}
+
+func formatLabels(lbls map[string]string) string {
+	kvs := make([]string, 0, len(lbls))
+	for k, v := range lbls {
+		kvs = append(kvs, k+"="+v)
+	}
+	sort.Strings(kvs)
+	return "[" + strings.Join(kvs, ",") + "]"
+}
+
+// humanize formats the name of an enum value as lower case words.
+func humanize(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", " "))
+}
+
 func sortByOrdinal(stats map[string]int32) []string {
 	keys := make([]string, 0, len(stats))
 	for k := range stats {
@@ -24,14 +24,19 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
 
 	lpb "github.com/bazelbuild/reclient/api/log"
 	ppb "github.com/bazelbuild/reclient/api/proxy"
+	"github.com/bazelbuild/reclient/internal/pkg/event"
 	"github.com/bazelbuild/reclient/internal/pkg/ipc"
+
+	cpb "github.com/bazelbuild/remote-apis-sdks/go/api/command"
 )
 
 var (
@@ -182,6 +187,10 @@ func (f *fakeStatusServer) GetStatusSummary(ctx context.Context, _ *ppb.GetStatu
 	return f.resp, f.err
 }
 
+func (f *fakeStatusServer) GetInvocationStats(ctx context.Context, _ *ppb.GetInvocationStatsRequest) (*ppb.GetInvocationStatsResponse, error) {
+	return &ppb.GetInvocationStatsResponse{}, f.err
+}
+
 // GenRandomUDSAddress generates a random socket file address on which reproxy
 // can be started.
 func genRandomUDSAddress() string {
@@ -354,3 +363,321 @@ func setReproxySockets(t *testing.T, addrs []string) {
 	})
 	testOnlyReproxySocketsKey = addrs
 }
+
+type fakeStatsServer struct {
+	ppb.UnimplementedStatsServer
+	events  []*ppb.ActionEvent
+	records []*lpb.LogRecord
+	reqs    []*ppb.GetRecordsRequest
+	running *ppb.GetRunningActionsResponse
+	// maxActions is the max_actions of the last GetRunningActions request.
+	maxActions int32
+}
+
+// GetRecords returns pages of the records, in order.
+func (f *fakeStatsServer) GetRecords(ctx context.Context, req *ppb.GetRecordsRequest) (*ppb.GetRecordsResponse, error) {
+	f.reqs = append(f.reqs, req)
+	i := 0
+	if req.GetPageToken() != "" {
+		i, _ = strconv.Atoi(req.GetPageToken())
+	}
+	j := i + int(req.GetPageSize())
+	if req.GetPageSize() == 0 || j > len(f.records) {
+		j = len(f.records)
+	}
+	resp := &ppb.GetRecordsResponse{Records: f.records[i:j]}
+	if j < len(f.records) {
+		resp.NextPageToken = strconv.Itoa(j)
+	}
+	return resp, nil
+}
+
+func startStatsServer(t *testing.T, statsServer ppb.StatsServer, address string) {
+	t.Helper()
+	listener, err := ipc.Listen(address)
+	if err != nil {
+		t.Fatalf("Unable to start stats server: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	grpcServer := grpc.NewServer()
+	ppb.RegisterStatsServer(grpcServer, statsServer)
+	t.Cleanup(grpcServer.Stop)
+	go grpcServer.Serve(listener)
+}
+
+func (f *fakeStatsServer) WatchActions(_ *ppb.WatchActionsRequest, srv ppb.Stats_WatchActionsServer) error {
+	for _, ev := range f.events {
+		if err := srv.Send(ev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeStatsServer) GetRunningActions(ctx context.Context, req *ppb.GetRunningActionsRequest) (*ppb.GetRunningActionsResponse, error) {
+	f.maxActions = req.GetMaxActions()
+	return f.running, nil
+}
+
+func TestPrintSummariesAndRunningActions(t *testing.T) {
+	serverAddress := genRandomUDSAddress()
+	listener, err := ipc.Listen(serverAddress)
+	if err != nil {
+		t.Fatalf("Unable to start reproxy server: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	// Actions that start in the future have run for 0s, which keeps the output stable.
+	start := time.Now().Add(time.Hour)
+	statsServer := &fakeStatsServer{
+		running: &ppb.GetRunningActionsResponse{
+			Actions: []*ppb.RunningAction{
+				{
+					CommandId:         "cmd1",
+					Labels:            map[string]string{"type": "compile", "lang": "cpp"},
+					ExecutionStrategy: ppb.ExecutionStrategy_REMOTE,
+					StartTime:         tspb.New(start),
+					Phase:             ppb.ActionPhase_UPLOAD_AND_EXECUTE,
+					PhaseStartTime:    tspb.New(start),
+				},
+				{
+					CommandId:         "cmd2",
+					Labels:            map[string]string{"type": "tool"},
+					ExecutionStrategy: ppb.ExecutionStrategy_LOCAL,
+					StartTime:         tspb.New(start),
+					PhaseStartTime:    tspb.New(start),
+				},
+			},
+			TotalActions:     7,
+			LocalQueueLength: 3,
+		},
+	}
+	grpcServer := grpc.NewServer()
+	ppb.RegisterStatusServer(grpcServer, &fakeStatusServer{resp: &ppb.GetStatusSummaryResponse{RunningActions: 7}})
+	ppb.RegisterStatsServer(grpcServer, statsServer)
+	t.Cleanup(grpcServer.Stop)
+	go grpcServer.Serve(listener)
+
+	tracker := &SingleReproxyTracker{
+		ServerAddress:  serverAddress,
+		RunningActions: 2,
+	}
+	var sb strings.Builder
+	if err := PrintSummariesAndRunningActions(context.Background(), &sb, tracker, "strategy"); err != nil {
+		t.Fatalf("PrintSummariesAndRunningActions() returned error: %v", err)
+	}
+	want := fmt.Sprintf(`Reproxy(%s) is OK
+Actions completed: 0
+Actions in progress: 7
+QPS: 0
+
+Oldest running actions (2 of 7), local execution queue length: 3
+COMMAND ID  LABELS                   STRATEGY  PHASE               PHASE TIME  ELAPSED
+cmd2        [type=tool]              local     starting            0s          0s
+cmd1        [lang=cpp,type=compile]  remote    upload and execute  0s          0s
+
+`, serverAddress)
+	if diff := cmp.Diff(want, sb.String()); diff != "" {
+		t.Errorf("PrintSummariesAndRunningActions() generated wrong output \n (-want +got): %v", diff)
+	}
+	if statsServer.maxActions != 2 {
+		t.Errorf("PrintSummariesAndRunningActions() requested %v running actions, want 2", statsServer.maxActions)
+	}
+	if err := PrintSummariesAndRunningActions(context.Background(), &sb, tracker, "size"); err == nil {
+		t.Errorf("PrintSummariesAndRunningActions() with an invalid sort key returned no error")
+	}
+}
+
+func TestSortRunningActions(t *testing.T) {
+	start := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	actions := []*ppb.RunningAction{
+		{
+			CommandId:         "b",
+			Labels:            map[string]string{"type": "link"},
+			ExecutionStrategy: ppb.ExecutionStrategy_LOCAL,
+			StartTime:         tspb.New(start.Add(time.Second)),
+			Phase:             ppb.ActionPhase_LOCAL_EXECUTION,
+			PhaseStartTime:    tspb.New(start.Add(3 * time.Second)),
+		},
+		{
+			CommandId:         "c",
+			Labels:            map[string]string{"type": "compile"},
+			ExecutionStrategy: ppb.ExecutionStrategy_REMOTE,
+			StartTime:         tspb.New(start),
+			Phase:             ppb.ActionPhase_DOWNLOAD,
+			PhaseStartTime:    tspb.New(start.Add(2 * time.Second)),
+		},
+		{
+			CommandId:         "a",
+			Labels:            map[string]string{"type": "tool"},
+			ExecutionStrategy: ppb.ExecutionStrategy_RACING,
+			StartTime:         tspb.New(start.Add(2 * time.Second)),
+			Phase:             ppb.ActionPhase_INPUT_PROCESSING,
+			PhaseStartTime:    tspb.New(start.Add(4 * time.Second)),
+		},
+	}
+	tests := []struct {
+		by   string
+		want []string
+	}{
+		{by: "elapsed", want: []string{"c", "b", "a"}},
+		{by: "phase_elapsed", want: []string{"c", "b", "a"}},
+		{by: "command_id", want: []string{"a", "b", "c"}},
+		{by: "labels", want: []string{"c", "b", "a"}},
+		{by: "strategy", want: []string{"b", "c", "a"}},
+		{by: "phase", want: []string{"a", "c", "b"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.by, func(t *testing.T) {
+			got := append([]*ppb.RunningAction(nil), actions...)
+			if err := SortRunningActions(got, tc.by); err != nil {
+				t.Fatalf("SortRunningActions(%q) returned error: %v", tc.by, err)
+			}
+			var ids []string
+			for _, a := range got {
+				ids = append(ids, a.GetCommandId())
+			}
+			if diff := cmp.Diff(tc.want, ids); diff != "" {
+				t.Errorf("SortRunningActions(%q) returned diff in order: (-want +got)\n%s", tc.by, diff)
+			}
+		})
+	}
+	if err := SortRunningActions(actions, "size"); err == nil {
+		t.Errorf("SortRunningActions(%q) returned no error", "size")
+	}
+}
+
+func TestFormatRunningActions(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	running := &ppb.GetRunningActionsResponse{
+		Actions: []*ppb.RunningAction{
+			{
+				CommandId:         "cmd1",
+				ExecutionStrategy: ppb.ExecutionStrategy_REMOTE_LOCAL_FALLBACK,
+				StartTime:         tspb.New(now.Add(-90 * time.Second)),
+				Phase:             ppb.ActionPhase_CACHE_LOOKUP,
+				PhaseStartTime:    tspb.New(now.Add(-1234 * time.Millisecond)),
+			},
+		},
+		TotalActions: 1,
+	}
+	want := `Oldest running actions (1 of 1), local execution queue length: 0
+COMMAND ID  LABELS  STRATEGY               PHASE         PHASE TIME  ELAPSED
+cmd1        []      remote local fallback  cache lookup  1.2s        1m30s
+`
+	if diff := cmp.Diff(want, FormatRunningActions(running, now)); diff != "" {
+		t.Errorf("FormatRunningActions() generated wrong output \n (-want +got): %v", diff)
+	}
+	want = "No running actions, local execution queue length: 2\n"
+	if got := FormatRunningActions(&ppb.GetRunningActionsResponse{LocalQueueLength: 2}, now); got != want {
+		t.Errorf("FormatRunningActions() = %q, want %q", got, want)
+	}
+}
+
+func TestFollowActions(t *testing.T) {
+	serverAddress := genRandomUDSAddress()
+	start := time.Date(2023, 1, 2, 3, 4, 5, 0, time.Local)
+	statsServer := &fakeStatsServer{
+		events: []*ppb.ActionEvent{
+			{
+				Type:              ppb.ActionEvent_STARTED,
+				Time:              tspb.New(start),
+				CommandId:         "cmd1",
+				Labels:            map[string]string{"type": "compile", "lang": "cpp"},
+				ExecutionStrategy: ppb.ExecutionStrategy_REMOTE_LOCAL_FALLBACK,
+			},
+			{
+				Type:      ppb.ActionEvent_PHASE_CHANGED,
+				Time:      tspb.New(start.Add(time.Millisecond)),
+				CommandId: "cmd1",
+				Phase:     ppb.ActionPhase_UPLOAD_AND_EXECUTE,
+			},
+			{
+				Type:             ppb.ActionEvent_COMPLETED,
+				Time:             tspb.New(start.Add(time.Second)),
+				CommandId:        "cmd1",
+				CompletionStatus: lpb.CompletionStatus_STATUS_NON_ZERO_EXIT,
+				Result:           &cpb.CommandResult{Status: cpb.CommandResultStatus_NON_ZERO_EXIT, ExitCode: 1},
+				DroppedEvents:    3,
+			},
+		},
+	}
+	startStatsServer(t, statsServer, serverAddress)
+
+	var sb strings.Builder
+	if err := FollowActions(context.Background(), &sb, serverAddress); err != nil {
+		t.Fatalf("FollowActions() returned error: %v", err)
+	}
+	want := `03:04:05.000 cmd1 started [lang=cpp,type=compile] (remote local fallback)
+03:04:05.001 cmd1 upload and execute
+03:04:06.000 cmd1 completed: non zero exit (exit code 1) (3 earlier events missed)
+`
+	if diff := cmp.Diff(want, sb.String()); diff != "" {
+		t.Errorf("FollowActions() generated wrong output \n (-want +got): %v", diff)
+	}
+}
+
+func TestFetchRecords(t *testing.T) {
+	serverAddress := genRandomUDSAddress()
+	statsServer := &fakeStatsServer{}
+	for i := 0; i < 2*recordsPageSize+10; i++ {
+		statsServer.records = append(statsServer.records, &lpb.LogRecord{
+			Command: &cpb.Command{Identifiers: &cpb.Identifiers{CommandId: strconv.Itoa(i)}},
+		})
+	}
+	startStatsServer(t, statsServer, serverAddress)
+
+	req := &ppb.GetRecordsRequest{InvocationId: "invocation"}
+	recs, err := FetchRecords(context.Background(), serverAddress, req, recordsPageSize+20)
+	if err != nil {
+		t.Fatalf("FetchRecords() returned error: %v", err)
+	}
+	if len(recs) != recordsPageSize+20 || recs[len(recs)-1].GetCommand().GetIdentifiers().GetCommandId() != strconv.Itoa(recordsPageSize+19) {
+		t.Errorf("FetchRecords() returned %v records, want the first %v", len(recs), recordsPageSize+20)
+	}
+	var sizes []int32
+	for _, r := range statsServer.reqs {
+		if r.GetInvocationId() != "invocation" || !r.GetMostRecentFirst() {
+			t.Errorf("FetchRecords() sent request %v, want the filters of %v and the most recent records first", r, req)
+		}
+		sizes = append(sizes, r.GetPageSize())
+	}
+	if diff := cmp.Diff([]int32{recordsPageSize, 20}, sizes); diff != "" {
+		t.Errorf("FetchRecords() requested pages of wrong sizes: (-want +got)\n%s", diff)
+	}
+}
+
+func TestFormatRecord(t *testing.T) {
+	completed := time.Date(2023, 1, 2, 3, 4, 5, 0, time.Local)
+	rec := &lpb.LogRecord{
+		Command:          &cpb.Command{Identifiers: &cpb.Identifiers{CommandId: "cmd1"}},
+		CompletionStatus: lpb.CompletionStatus_STATUS_REMOTE_FAILURE,
+		Result:           &cpb.CommandResult{Status: cpb.CommandResultStatus_REMOTE_ERROR, ExitCode: 45, Msg: "deadline exceeded"},
+		LocalMetadata: &lpb.LocalMetadata{
+			Labels: map[string]string{"type": "tool"},
+			EventTimes: map[string]*cpb.TimeInterval{
+				event.ProxyExecution: {
+					From: tspb.New(completed.Add(-1500 * time.Millisecond)),
+					To:   tspb.New(completed),
+				},
+			},
+		},
+	}
+	want := "03:04:05.000 cmd1 remote failure in 1.5s [type=tool] (exit code 45): deadline exceeded"
+	if got := FormatRecord(rec); got != want {
+		t.Errorf("FormatRecord() = %q, want %q", got, want)
+	}
+}
+
+func TestParseCompletionStatuses(t *testing.T) {
+	got, err := ParseCompletionStatuses("remote_failure, STATUS_LOCAL_FALLBACK")
+	if err != nil {
+		t.Fatalf("ParseCompletionStatuses() returned error: %v", err)
+	}
+	want := []lpb.CompletionStatus{lpb.CompletionStatus_STATUS_REMOTE_FAILURE, lpb.CompletionStatus_STATUS_LOCAL_FALLBACK}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseCompletionStatuses() returned diff: (-want +got)\n%s", diff)
+	}
+	if _, err := ParseCompletionStatuses("remote_success"); err == nil {
+		t.Errorf("ParseCompletionStatuses(%q) returned no error, want an error", "remote_success")
+	}
+}
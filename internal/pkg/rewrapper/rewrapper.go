@@ -19,9 +19,11 @@ package rewrapper
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bazelbuild/reclient/internal/pkg/rsp"
@@ -37,11 +39,16 @@ import (
 
 	ppb "github.com/bazelbuild/reclient/api/proxy"
 	cpb "github.com/bazelbuild/remote-apis-sdks/go/api/command"
+
+	log "github.com/golang/glog"
 )
 
 const (
 	// WrapperOverheadKey is the key for the wrapper overhead metric passed to the proxy.
 	WrapperOverheadKey = "WrapperOverhead"
+
+	// cancelTimeout is how long to wait for the proxy to acknowledge the cancellation of a command.
+	cancelTimeout = 5 * time.Second
 )
 
 var (
@@ -67,6 +74,13 @@ type Proxy interface {
 	RunCommand(context.Context, *ppb.RunRequest, ...grpc.CallOption) (*ppb.RunResponse, error)
 }
 
+// StreamingProxy is the interface of the RE Proxy API supporting streamed command output.
+type StreamingProxy interface {
+	Proxy
+	RunCommandStream(context.Context, *ppb.RunRequest, ...grpc.CallOption) (ppb.Commands_RunCommandStreamClient, error)
+	CancelCommand(context.Context, *ppb.CancelCommandRequest, ...grpc.CallOption) (*ppb.CancelCommandResponse, error)
+}
+
 // CommandOptions contains command execution options passed to the rewrapper.
 type CommandOptions struct {
 	CommandID                    string
@@ -99,6 +113,10 @@ type CommandOptions struct {
 	NumLocalReruns               int
 	NumRemoteReruns              int
 	LocalWrapper                 string
+	LocalSandbox                 bool
+	LocalCPUs                    int
+	LocalRAMMBs                  int
+	LocalExecTimeout             time.Duration
 	RemoteWrapper                string
 	PreserveSymlink              bool
 	CanonicalizeWorkingDir       bool
@@ -124,6 +142,96 @@ func RunCommand(ctx context.Context, dialTimeout time.Duration, proxy Proxy, cmd
 	return resp, err
 }
 
+// RunCommandStream runs a command through the RE proxy, writing its output to stdout and stderr as
+// it is streamed back. The returned response does not contain the output. Falls back to RunCommand
+// if the proxy does not support streaming.
+func RunCommandStream(ctx context.Context, dialTimeout time.Duration, proxy StreamingProxy, cmd []string, opts *CommandOptions, stdout, stderr io.Writer) (*ppb.RunResponse, error) {
+	req, err := createRequest(cmd, opts)
+	if err != nil {
+		return nil, err
+	}
+	// The stream outlives ctx, so that the proxy can still report the result of a command that is
+	// canceled through ctx.
+	sCtx, sCancel := context.WithCancel(context.Background())
+	defer sCancel()
+	var mu sync.Mutex
+	executionID := ""
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+		}
+		mu.Lock()
+		id := executionID
+		mu.Unlock()
+		if id == "" {
+			sCancel()
+			return
+		}
+		if err := cancelCommand(proxy, id); err != nil {
+			log.Warningf("Failed to cancel command %v: %v", id, err)
+			sCancel()
+		}
+	}()
+	var resp *ppb.RunResponse
+	// Once output was written, the command cannot be retried without duplicating it.
+	received := false
+	st := time.Now()
+	err = retry.WithPolicy(ctx, func(err error) bool { return !received && shouldRetry(err) }, backoff, func() error {
+		if time.Since(st) > dialTimeout {
+			return fmt.Errorf("dial_timeout of %v expired before being able to connect to reproxy", dialTimeout)
+		}
+		stream, err := proxy.RunCommandStream(sCtx, req)
+		if err != nil {
+			return err
+		}
+		for {
+			r, err := stream.Recv()
+			if err == io.EOF {
+				if resp == nil {
+					return fmt.Errorf("reproxy closed the stream without a response")
+				}
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if !received {
+				mu.Lock()
+				executionID = r.GetExecutionId()
+				mu.Unlock()
+			}
+			received = true
+			stdout.Write(r.GetStdout())
+			stderr.Write(r.GetStderr())
+			// The last response carries the result of the command.
+			r.Stdout, r.Stderr = nil, nil
+			resp = r
+		}
+	})
+	if !received && status.Code(err) == codes.Unimplemented {
+		resp, err = RunCommand(ctx, dialTimeout, proxy, cmd, opts)
+		if err != nil || resp == nil {
+			return resp, err
+		}
+		stdout.Write(resp.GetStdout())
+		stderr.Write(resp.GetStderr())
+		resp.Stdout, resp.Stderr = nil, nil
+	}
+	return resp, err
+}
+
+// cancelCommand asks the RE proxy to cancel the command with the given execution ID.
+func cancelCommand(proxy StreamingProxy, executionID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cancelTimeout)
+	defer cancel()
+	_, err := proxy.CancelCommand(ctx, &ppb.CancelCommandRequest{ExecutionId: executionID})
+	return err
+}
+
 func createRequest(cmd []string, opts *CommandOptions) (*ppb.RunRequest, error) {
 	inputs := opts.Inputs
 	for _, p := range opts.InputListPaths {
@@ -177,6 +285,10 @@ func createRequest(cmd []string, opts *CommandOptions) (*ppb.RunRequest, error)
 	if opts.PreserveSymlink {
 		c.Input.SymlinkBehavior = cpb.SymlinkBehaviorType_PRESERVE
 	}
+	localPlatform := ppb.LocalExecutionOptions_UNSPECIFIED
+	if opts.LocalSandbox {
+		localPlatform = ppb.LocalExecutionOptions_SANDBOX
+	}
 	return &ppb.RunRequest{
 		Command: c,
 		Labels:  opts.Labels,
@@ -198,9 +310,13 @@ func createRequest(cmd []string, opts *CommandOptions) (*ppb.RunRequest, error)
 				PreserveUnchangedOutputMtime: opts.PreserveUnchangedOutputMtime,
 			},
 			LocalExecutionOptions: &ppb.LocalExecutionOptions{
-				AcceptCached: opts.RemoteAcceptCache,
-				DoNotCache:   !opts.RemoteUpdateCache,
-				Wrapper:      opts.LocalWrapper,
+				Platform:         localPlatform,
+				AcceptCached:     opts.RemoteAcceptCache,
+				DoNotCache:       !opts.RemoteUpdateCache,
+				Wrapper:          opts.LocalWrapper,
+				Cpus:             int64(opts.LocalCPUs),
+				RamMb:            int64(opts.LocalRAMMBs),
+				ExecutionTimeout: ceilSeconds(opts.LocalExecTimeout),
 			},
 			LogEnvironment:   opts.LogEnvironment,
 			IncludeActionLog: opts.ActionLog != "",
@@ -210,6 +326,12 @@ func createRequest(cmd []string, opts *CommandOptions) (*ppb.RunRequest, error)
 	}, nil
 }
 
+// ceilSeconds returns a duration in whole seconds, rounded up so that a sub-second duration does
+// not become 0, which means no timeout.
+func ceilSeconds(d time.Duration) int32 {
+	return int32((d + time.Second - 1) / time.Second)
+}
+
 func envVars(allowlist []string) map[string]string {
 	vars := make(map[string]string)
 	for _, v := range allowlist {
@@ -15,7 +15,9 @@
 package rewrapper
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"os"
 	"strings"
 	"testing"
@@ -88,6 +90,9 @@ func TestRunCommand(t *testing.T) {
 		StartTime:              st,
 		PreserveSymlink:        true,
 		CanonicalizeWorkingDir: true,
+		LocalCPUs:              4,
+		LocalRAMMBs:            2048,
+		LocalExecTimeout:       time.Minute,
 	}
 	want := &ppb.RunRequest{
 		Command: &cpb.Command{
@@ -122,7 +127,10 @@ func TestRunCommand(t *testing.T) {
 				CanonicalizeWorkingDir: true,
 			},
 			LocalExecutionOptions: &ppb.LocalExecutionOptions{
-				AcceptCached: true,
+				AcceptCached:     true,
+				Cpus:             4,
+				RamMb:            2048,
+				ExecutionTimeout: 60,
 			},
 		},
 		Metadata: &ppb.Metadata{
@@ -139,6 +147,17 @@ func TestRunCommand(t *testing.T) {
 	}
 }
 
+func TestRunCommandSubSecondLocalExecTimeout(t *testing.T) {
+	p := &proxyStub{}
+	opts := &CommandOptions{ExecStrategy: "local", LocalExecTimeout: 500 * time.Millisecond}
+	if _, err := RunCommand(context.Background(), time.Hour, p, []string{"clang"}, opts); err != nil {
+		t.Fatalf("RunCommand() returned error: %v", err)
+	}
+	if got := p.req.GetExecutionOptions().GetLocalExecutionOptions().GetExecutionTimeout(); got != 1 {
+		t.Errorf("RunCommand() sent local execution timeout %vs, want 1s", got)
+	}
+}
+
 func TestRunCommandTimeout(t *testing.T) {
 	p := &proxyStub{err: status.Error(codes.Unavailable, "error")}
 	if _, err := RunCommand(context.Background(), time.Second, p, []string{}, &CommandOptions{}); err == nil {
@@ -146,6 +165,107 @@ func TestRunCommandTimeout(t *testing.T) {
 	}
 }
 
+type streamingProxyStub struct {
+	proxyStub
+	resps     []*ppb.RunResponse
+	streamErr error
+	// If set, the stream calls interrupt after the first response and blocks until the command is
+	// canceled.
+	interrupt  func()
+	canceledID string
+	canceled   chan struct{}
+}
+
+func (s *streamingProxyStub) RunCommandStream(_ context.Context, req *ppb.RunRequest, _ ...grpc.CallOption) (ppb.Commands_RunCommandStreamClient, error) {
+	s.req = req
+	return &streamStub{p: s}, nil
+}
+
+func (s *streamingProxyStub) CancelCommand(_ context.Context, req *ppb.CancelCommandRequest, _ ...grpc.CallOption) (*ppb.CancelCommandResponse, error) {
+	s.canceledID = req.GetExecutionId()
+	close(s.canceled)
+	return &ppb.CancelCommandResponse{}, nil
+}
+
+type streamStub struct {
+	grpc.ClientStream
+	p    *streamingProxyStub
+	sent int
+}
+
+func (s *streamStub) Recv() (*ppb.RunResponse, error) {
+	if s.p.streamErr != nil {
+		return nil, s.p.streamErr
+	}
+	if s.p.interrupt != nil && s.sent == 1 {
+		s.p.interrupt()
+		<-s.p.canceled
+	}
+	if s.sent == len(s.p.resps) {
+		return nil, io.EOF
+	}
+	s.sent++
+	return s.p.resps[s.sent-1], nil
+}
+
+func TestRunCommandStream(t *testing.T) {
+	p := &streamingProxyStub{resps: []*ppb.RunResponse{
+		{ExecutionId: "id", Stdout: []byte("out1"), Stderr: []byte("err1")},
+		{ExecutionId: "id", Stdout: []byte("out2")},
+		{ExecutionId: "id", Stderr: []byte("err2"), Result: &cpb.CommandResult{ExitCode: 3}},
+	}}
+	var stdout, stderr bytes.Buffer
+	resp, err := RunCommandStream(context.Background(), time.Hour, p, []string{"echo"}, &CommandOptions{}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("RunCommandStream() returned error: %v", err)
+	}
+	if got, want := stdout.String(), "out1out2"; got != want {
+		t.Errorf("RunCommandStream() wrote stdout %q, want %q", got, want)
+	}
+	if got, want := stderr.String(), "err1err2"; got != want {
+		t.Errorf("RunCommandStream() wrote stderr %q, want %q", got, want)
+	}
+	want := &ppb.RunResponse{ExecutionId: "id", Result: &cpb.CommandResult{ExitCode: 3}}
+	if diff := cmp.Diff(want, resp, protocmp.Transform()); diff != "" {
+		t.Errorf("RunCommandStream() returned bad response. (-want +got): %s", diff)
+	}
+}
+
+func TestRunCommandStreamUnimplemented(t *testing.T) {
+	p := &streamingProxyStub{streamErr: status.Error(codes.Unimplemented, "unknown method")}
+	var stdout, stderr bytes.Buffer
+	if _, err := RunCommandStream(context.Background(), time.Hour, p, []string{"echo"}, &CommandOptions{}, &stdout, &stderr); err != nil {
+		t.Fatalf("RunCommandStream() returned error: %v", err)
+	}
+	if p.req == nil || len(p.req.GetCommand().GetArgs()) != 1 {
+		t.Errorf("RunCommandStream() did not fall back to RunCommand, got request %v", p.req)
+	}
+}
+
+func TestRunCommandStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p := &streamingProxyStub{
+		resps: []*ppb.RunResponse{
+			{ExecutionId: "id"},
+			{ExecutionId: "id", Result: &cpb.CommandResult{Status: cpb.CommandResultStatus_INTERRUPTED}},
+		},
+		interrupt: cancel,
+		canceled:  make(chan struct{}),
+	}
+	var stdout, stderr bytes.Buffer
+	resp, err := RunCommandStream(ctx, time.Hour, p, []string{"echo"}, &CommandOptions{}, &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("RunCommandStream() returned error: %v", err)
+	}
+	if p.canceledID != "id" {
+		t.Errorf("RunCommandStream() canceled execution ID %q, want %q", p.canceledID, "id")
+	}
+	if got := resp.GetResult().GetStatus(); got != cpb.CommandResultStatus_INTERRUPTED {
+		t.Errorf("RunCommandStream() returned status %v, want %v", got, cpb.CommandResultStatus_INTERRUPTED)
+	}
+}
+
 func TestParseVirtualInputs(t *testing.T) {
 	cmd := []string{"cat", "foo.txt"}
 	st := time.Now()
@@ -0,0 +1,255 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sandbox executes commands locally such that only their declared inputs and outputs are
+// visible under the exec root.
+//
+// On Linux, the command is run inside a new user and mount namespace. An empty scratch directory
+// with the declared inputs bind mounted into it (read-only) is mounted over the exec root, so any
+// undeclared file under the exec root is not visible to the command. Declared outputs are written
+// to the scratch directory and moved to the real exec root once the command finishes. Paths outside
+// of the exec root (e.g. system toolchains) stay visible.
+//
+// Binaries that use the Executor must call Init at the very beginning of main, since the sandbox is
+// set up by re-executing the current binary inside the new namespaces.
+package sandbox
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
+)
+
+// ErrUnsupported is returned when sandboxed execution is not supported on the current platform.
+var ErrUnsupported = errors.New("sandboxed local execution is not supported on this platform")
+
+// Executor runs commands inside a sandbox.
+type Executor struct {
+	// TmpDir is the directory in which per-command scratch directories are created. If empty, the
+	// system temporary directory is used. Outputs are moved out of the scratch directory with a
+	// rename where possible, so this should preferably be on the same device as the exec root.
+	TmpDir string
+}
+
+// layout describes the exec root of a sandboxed command. All paths are relative to the exec root.
+type layout struct {
+	// inputs are bind mounted read-only into the sandbox.
+	inputs []string
+	// copies are inputs that are also outputs, so they are copied into the sandbox to be writable.
+	copies []string
+	// dirs are directories that are created empty in the sandbox.
+	dirs []string
+	// outputFiles are moved from the sandbox to the exec root after the command finishes.
+	outputFiles []string
+	// outputDirs are moved from the sandbox to the exec root after the command finishes.
+	outputDirs []string
+}
+
+// newLayout computes the sandbox layout of the given command. Inputs that do not exist locally are
+// skipped, as well as inputs contained in a directory input.
+func newLayout(cmd *command.Command) *layout {
+	l := &layout{}
+	outs := make(map[string]bool)
+	for _, f := range cmd.OutputFiles {
+		p := filepath.Clean(filepath.Join(cmd.WorkingDir, f))
+		l.outputFiles = append(l.outputFiles, p)
+		l.dirs = append(l.dirs, filepath.Dir(p))
+		outs[p] = true
+	}
+	for _, d := range cmd.OutputDirs {
+		p := filepath.Clean(filepath.Join(cmd.WorkingDir, d))
+		l.outputDirs = append(l.outputDirs, p)
+		l.dirs = append(l.dirs, p)
+		outs[p] = true
+	}
+	l.dirs = append(l.dirs, filepath.Clean(cmd.WorkingDir))
+	var inputs []string
+	if cmd.InputSpec != nil {
+		for _, in := range cmd.InputSpec.Inputs {
+			inputs = append(inputs, filepath.Clean(in))
+		}
+		for _, vi := range cmd.InputSpec.VirtualInputs {
+			p := filepath.Clean(vi.Path)
+			if vi.IsEmptyDirectory {
+				l.dirs = append(l.dirs, p)
+				continue
+			}
+			inputs = append(inputs, p)
+		}
+	}
+	sort.Strings(inputs)
+	var boundDirs []string
+	for i, in := range inputs {
+		if filepath.IsAbs(in) || in == ".." || strings.HasPrefix(in, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if i > 0 && in == inputs[i-1] || underAny(in, boundDirs) {
+			continue
+		}
+		fi, err := os.Stat(filepath.Join(cmd.ExecRoot, in))
+		if err != nil {
+			continue
+		}
+		if outs[in] && !fi.IsDir() {
+			l.copies = append(l.copies, in)
+			continue
+		}
+		if fi.IsDir() {
+			boundDirs = append(boundDirs, in)
+		}
+		l.inputs = append(l.inputs, in)
+	}
+	return l
+}
+
+// underAny returns whether path is contained in any of the given directories.
+func underAny(path string, dirs []string) bool {
+	for _, d := range dirs {
+		if d == "." || strings.HasPrefix(path, d+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// populate creates the placeholders that inputs are mounted over, the copied inputs and the
+// empty directories of the layout under root.
+func (l *layout) populate(execRoot, root string) error {
+	for _, d := range l.dirs {
+		if err := os.MkdirAll(filepath.Join(root, d), os.ModePerm); err != nil {
+			return err
+		}
+	}
+	for _, in := range l.inputs {
+		dst := filepath.Join(root, in)
+		fi, err := os.Stat(filepath.Join(execRoot, in))
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			if err := os.MkdirAll(dst, os.ModePerm); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		f.Close()
+	}
+	for _, in := range l.copies {
+		dst := filepath.Join(root, in)
+		if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+			return err
+		}
+		if err := copyFile(filepath.Join(execRoot, in), dst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectOutputs moves the outputs produced by the command from root to the exec root.
+func (l *layout) collectOutputs(root, execRoot string) error {
+	for _, f := range l.outputFiles {
+		src := filepath.Join(root, f)
+		if _, err := os.Lstat(src); err != nil {
+			continue
+		}
+		if err := moveOutput(src, filepath.Join(execRoot, f)); err != nil {
+			return fmt.Errorf("failed to move output file %v out of the sandbox: %w", f, err)
+		}
+	}
+	for _, d := range l.outputDirs {
+		src := filepath.Join(root, d)
+		if fi, err := os.Stat(src); err != nil || !fi.IsDir() {
+			continue
+		}
+		if err := moveOutput(src, filepath.Join(execRoot, d)); err != nil {
+			return fmt.Errorf("failed to move output directory %v out of the sandbox: %w", d, err)
+		}
+	}
+	return nil
+}
+
+// moveOutput moves src to dst, replacing dst if it exists. It falls back to copying when src and
+// dst are on different devices.
+func moveOutput(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+	return copyTree(src, dst)
+}
+
+func copyTree(src, dst string) error {
+	return filepath.Walk(src, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case fi.IsDir():
+			return os.MkdirAll(target, fi.Mode().Perm())
+		case fi.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		default:
+			return copyFile(path, target)
+		}
+	})
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	fi, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, fi.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
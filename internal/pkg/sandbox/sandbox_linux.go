@@ -0,0 +1,228 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/bazelbuild/reclient/internal/pkg/cgroups"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/outerr"
+
+	log "github.com/golang/glog"
+)
+
+const (
+	// initArg is the first argument of the current binary when re-executed to set up a sandbox.
+	initArg = "--reclient_sandbox_init"
+	// errFd is the file descriptor the sandbox init process reports setup errors on. It is closed on
+	// exec of the sandboxed command, so the parent reads EOF if the setup succeeded.
+	errFd = 3
+
+	capSysAdmin = 21
+
+	prCapAmbient         = 47
+	prCapAmbientClearAll = 4
+)
+
+// spec is the sandbox configuration passed to the sandbox init process.
+type spec struct {
+	ExecRoot   string
+	Root       string
+	Inputs     []string
+	WorkingDir string
+	Path       string
+	Args       []string
+	Env        []string
+}
+
+// Init sets up the sandbox mounts and executes the sandboxed command if the current process was
+// started as a sandbox init process, in which case it never returns. Otherwise, it is a noop.
+func Init() {
+	if len(os.Args) != 3 || os.Args[1] != initArg {
+		return
+	}
+	errPipe := os.NewFile(errFd, "sandbox-err")
+	err := runInit(os.Args[2])
+	fmt.Fprintf(errPipe, "%v", err)
+	os.Exit(1)
+}
+
+// runInit mounts the sandbox over the exec root and executes the command. It only returns on
+// failure.
+func runInit(specPath string) error {
+	blob, err := os.ReadFile(specPath)
+	if err != nil {
+		return err
+	}
+	sp := &spec{}
+	if err := json.Unmarshal(blob, sp); err != nil {
+		return err
+	}
+	syscall.CloseOnExec(errFd)
+	if err := syscall.Mount("", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("failed to make mounts private: %w", err)
+	}
+	for _, in := range sp.Inputs {
+		src := filepath.Join(sp.ExecRoot, in)
+		dst := filepath.Join(sp.Root, in)
+		if err := bindReadOnly(src, dst); err != nil {
+			return fmt.Errorf("failed to mount input %v: %w", in, err)
+		}
+	}
+	if err := syscall.Mount(sp.Root, sp.ExecRoot, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+		return fmt.Errorf("failed to mount sandbox over exec root: %w", err)
+	}
+	if err := os.Chdir(filepath.Join(sp.ExecRoot, sp.WorkingDir)); err != nil {
+		return err
+	}
+	// The capabilities were only needed for mounting, the command runs without them.
+	if _, _, errno := syscall.RawSyscall6(syscall.SYS_PRCTL, prCapAmbient, prCapAmbientClearAll, 0, 0, 0, 0); errno != 0 {
+		return fmt.Errorf("failed to clear ambient capabilities: %w", errno)
+	}
+	return syscall.Exec(sp.Path, sp.Args, sp.Env)
+}
+
+// bindReadOnly bind mounts src on dst and makes the mount read-only. Flags of the mount
+// containing src are preserved since a user namespace is not allowed to clear them.
+func bindReadOnly(src, dst string) error {
+	if err := syscall.Mount(src, dst, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+		return err
+	}
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dst, &st); err != nil {
+		return err
+	}
+	flags := uintptr(syscall.MS_REMOUNT | syscall.MS_BIND | syscall.MS_RDONLY)
+	for _, f := range []uintptr{syscall.MS_NOSUID, syscall.MS_NODEV, syscall.MS_NOEXEC, syscall.MS_NOATIME, syscall.MS_NODIRATIME, syscall.MS_RELATIME} {
+		// The ST_* flags reported by statfs share their values with MS_* flags, except for relatime.
+		stFlag := f
+		if f == syscall.MS_RELATIME {
+			stFlag = 4096
+		}
+		if uintptr(st.Flags)&stFlag != 0 {
+			flags |= f
+		}
+	}
+	return syscall.Mount("", dst, "", flags, "")
+}
+
+// ExecuteWithOutErr runs the given command inside a sandbox and returns stdout and stderr in an
+// OutErr object. Returns *exec.ExitError if the command ran with a non-zero exit code.
+func (e *Executor) ExecuteWithOutErr(ctx context.Context, cmd *command.Command, oe outerr.OutErr) error {
+	if len(cmd.Args) < 1 {
+		return fmt.Errorf("command must have more than 1 argument")
+	}
+	path := cmd.Args[0]
+	if !strings.Contains(path, string(filepath.Separator)) {
+		var err error
+		if path, err = exec.LookPath(path); err != nil {
+			return err
+		}
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	base, err := os.MkdirTemp(e.TmpDir, "reclient-sandbox-")
+	if err != nil {
+		return fmt.Errorf("failed to create sandbox directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(base); err != nil {
+			log.Warningf("Failed to remove sandbox directory %v: %v", base, err)
+		}
+	}()
+	root := filepath.Join(base, "root")
+	l := newLayout(cmd)
+	if err := l.populate(cmd.ExecRoot, root); err != nil {
+		return fmt.Errorf("failed to populate sandbox: %w", err)
+	}
+	sp := &spec{
+		ExecRoot:   cmd.ExecRoot,
+		Root:       root,
+		Inputs:     l.inputs,
+		WorkingDir: cmd.WorkingDir,
+		Path:       path,
+		Args:       cmd.Args,
+		Env:        os.Environ(),
+	}
+	if cmd.InputSpec != nil && cmd.InputSpec.EnvironmentVariables != nil {
+		sp.Env = make([]string, 0, len(cmd.InputSpec.EnvironmentVariables))
+		for k, v := range cmd.InputSpec.EnvironmentVariables {
+			sp.Env = append(sp.Env, fmt.Sprintf("%s=%s", k, v))
+		}
+	}
+	blob, err := json.Marshal(sp)
+	if err != nil {
+		return err
+	}
+	specPath := filepath.Join(base, "spec.json")
+	if err := os.WriteFile(specPath, blob, 0600); err != nil {
+		return err
+	}
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer errR.Close()
+
+	c := exec.CommandContext(ctx, self, initArg, specPath)
+	c.Stdout = outerr.NewOutWriter(oe)
+	c.Stderr = outerr.NewErrWriter(oe)
+	c.ExtraFiles = []*os.File{errW}
+	c.SysProcAttr = &syscall.SysProcAttr{
+		Cloneflags: syscall.CLONE_NEWUSER | syscall.CLONE_NEWNS,
+		UidMappings: []syscall.SysProcIDMap{
+			{ContainerID: os.Getuid(), HostID: os.Getuid(), Size: 1},
+		},
+		GidMappings: []syscall.SysProcIDMap{
+			{ContainerID: os.Getgid(), HostID: os.Getgid(), Size: 1},
+		},
+		AmbientCaps: []uintptr{capSysAdmin},
+		// Kill the processes spawned by the command along with it when ctx is canceled.
+		Setpgid: true,
+	}
+	c.Cancel = func() error {
+		return syscall.Kill(-c.Process.Pid, syscall.SIGKILL)
+	}
+	cgroups.SetCmdCgroup(ctx, c)
+	err = c.Start()
+	errW.Close()
+	if err != nil {
+		return fmt.Errorf("failed to start sandbox: %w", err)
+	}
+	setupErr, _ := io.ReadAll(errR)
+	err = c.Wait()
+	if len(setupErr) > 0 {
+		return fmt.Errorf("failed to set up sandbox: %s", setupErr)
+	}
+	if err != nil {
+		log.V(2).Infof("Executed sandboxed command %v\n >> err=%v", cmd.Args, err)
+	}
+	if cErr := l.collectOutputs(root, cmd.ExecRoot); cErr != nil && err == nil {
+		return cErr
+	}
+	return err
+}
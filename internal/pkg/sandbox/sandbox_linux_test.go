@@ -0,0 +1,138 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
+	"github.com/bazelbuild/remote-apis-sdks/go/pkg/outerr"
+)
+
+func TestMain(m *testing.M) {
+	Init()
+	os.Exit(m.Run())
+}
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for f, c := range files {
+		p := filepath.Join(root, f)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("MkdirAll(%v) failed: %v", filepath.Dir(p), err)
+		}
+		if err := os.WriteFile(p, []byte(c), 0644); err != nil {
+			t.Fatalf("WriteFile(%v) failed: %v", p, err)
+		}
+	}
+}
+
+// skipIfUnsupported skips the test if the environment does not allow creating user namespaces.
+func skipIfUnsupported(t *testing.T, e *Executor) {
+	t.Helper()
+	cmd := &command.Command{Args: []string{"true"}, ExecRoot: t.TempDir()}
+	if err := e.ExecuteWithOutErr(context.Background(), cmd, outerr.NewRecordingOutErr()); err != nil {
+		t.Skipf("Sandboxing is not supported in this environment: %v", err)
+	}
+}
+
+func TestExecuteWithOutErr(t *testing.T) {
+	e := &Executor{TmpDir: t.TempDir()}
+	skipIfUnsupported(t, e)
+	execRoot := t.TempDir()
+	writeFiles(t, execRoot, map[string]string{
+		"src/in.txt":         "input",
+		"src/undeclared.txt": "undeclared",
+		"lib/a.txt":          "a",
+		"out/old.txt":        "stale",
+	})
+	cmd := &command.Command{
+		Args: []string{"/bin/sh", "-c", strings.Join([]string{
+			"cat ../src/in.txt ../lib/a.txt > gen/out.txt",
+			"mkdir -p gendir && echo dir > gendir/f.txt",
+			"ls ../src",
+			"test -e old.txt || echo no old",
+		}, " && ")},
+		ExecRoot:   execRoot,
+		WorkingDir: "out",
+		InputSpec: &command.InputSpec{
+			Inputs: []string{"src/in.txt", "lib"},
+		},
+		OutputFiles: []string{"gen/out.txt"},
+		OutputDirs:  []string{"gendir"},
+	}
+	oe := outerr.NewRecordingOutErr()
+	if err := e.ExecuteWithOutErr(context.Background(), cmd, oe); err != nil {
+		t.Fatalf("ExecuteWithOutErr() returned error: %v, stderr: %s", err, oe.Stderr())
+	}
+	if got, want := string(oe.Stdout()), "in.txt\nno old\n"; got != want {
+		t.Errorf("ExecuteWithOutErr() stdout = %q, want %q", got, want)
+	}
+	for f, want := range map[string]string{
+		"out/gen/out.txt":    "inputa",
+		"out/gendir/f.txt":   "dir\n",
+		"out/old.txt":        "stale",
+		"src/undeclared.txt": "undeclared",
+	} {
+		got, err := os.ReadFile(filepath.Join(execRoot, f))
+		if err != nil {
+			t.Errorf("ReadFile(%v) failed: %v", f, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("ReadFile(%v) = %q, want %q", f, got, want)
+		}
+	}
+}
+
+func TestExecuteWithOutErrReadOnlyInputs(t *testing.T) {
+	e := &Executor{TmpDir: t.TempDir()}
+	skipIfUnsupported(t, e)
+	execRoot := t.TempDir()
+	writeFiles(t, execRoot, map[string]string{
+		"in.txt":    "input",
+		"inout.txt": "inout",
+	})
+	cmd := &command.Command{
+		Args:        []string{"/bin/sh", "-c", "echo modified >> inout.txt; echo modified > in.txt"},
+		ExecRoot:    execRoot,
+		InputSpec:   &command.InputSpec{Inputs: []string{"in.txt", "inout.txt"}},
+		OutputFiles: []string{"inout.txt"},
+	}
+	err := e.ExecuteWithOutErr(context.Background(), cmd, outerr.NewRecordingOutErr())
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("ExecuteWithOutErr() = %v, want *exec.ExitError", err)
+	}
+	for f, want := range map[string]string{
+		"in.txt":    "input",
+		"inout.txt": "inoutmodified\n",
+	} {
+		got, err := os.ReadFile(filepath.Join(execRoot, f))
+		if err != nil {
+			t.Errorf("ReadFile(%v) failed: %v", f, err)
+			continue
+		}
+		if string(got) != want {
+			t.Errorf("ReadFile(%v) = %q, want %q", f, got, want)
+		}
+	}
+}
@@ -203,7 +203,12 @@ func (stt *statTree) addLocalMetadata(lm *lpb.LocalMetadata, name string, cmdID
 	lmStt.addBool(lm.ExecutedLocally, "ExecutedLocally", cmdID)
 	lmStt.addBool(lm.ValidCacheHit, "ValidCacheHit", cmdID)
 	lmStt.addBool(lm.UpdatedCache, "UpdatedCache", cmdID)
+	lmStt.addBool(lm.LocalCacheHit, "LocalCacheHit", cmdID)
+	lmStt.addBool(lm.UpdatedLocalCache, "UpdatedLocalCache", cmdID)
+	lmStt.addBool(lm.OomKilled, "OomKilled", cmdID)
+	lmStt.addBool(lm.RemoteBypassed, "RemoteBypassed", cmdID)
 	lmStt.addVerification(lm.Verification, "Verification", cmdID)
+	lmStt.addInputTrace(lm.InputTrace, "InputTrace", cmdID)
 	lmStt.addEventTimes(lm.EventTimes, "EventTimes", cmdID)
 	lmStt.addRerunMetadatas(lm.RerunMetadata, "RerunMetadata", cmdID)
 }
@@ -245,6 +250,15 @@ func (stt *statTree) addVerification(vf *lpb.Verification, name string, cmdID st
 	vfStt.addNum(vf.TotalVerified, "TotalVerified", cmdID, false)
 }
 
+func (stt *statTree) addInputTrace(it *lpb.InputTrace, name string, cmdID string) {
+	itStt := stt.child(name)
+	if it == nil {
+		return
+	}
+	itStt.addNum(int64(len(it.UnderdeclaredInputs)), "NumUnderdeclaredInputs", cmdID, false)
+	itStt.addNum(int64(len(it.OverdeclaredInputs)), "NumOverdeclaredInputs", cmdID, false)
+}
+
 func (stt *statTree) addMismatches(mismatches []*lpb.Verification_Mismatch, name string, cmdID string) {
 	mmStt := stt.child(name)
 	if mismatches == nil {
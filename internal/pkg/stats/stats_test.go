@@ -705,6 +705,65 @@ func TestVerification(t *testing.T) {
 	}
 }
 
+func TestInputTrace(t *testing.T) {
+	lbls := map[string]string{"type": "tool"}
+	recs := []*lpb.LogRecord{
+		&lpb.LogRecord{
+			Command: &cpb.Command{Identifiers: &cpb.Identifiers{CommandId: "a"}},
+			LocalMetadata: &lpb.LocalMetadata{
+				Labels: lbls,
+				InputTrace: &lpb.InputTrace{
+					UnderdeclaredInputs: []string{"foo.h", "bar.h"},
+					OverdeclaredInputs:  []string{"baz.h"},
+				},
+			},
+		},
+		&lpb.LogRecord{
+			Command: &cpb.Command{Identifiers: &cpb.Identifiers{CommandId: "b"}},
+			LocalMetadata: &lpb.LocalMetadata{
+				Labels:     lbls,
+				InputTrace: &lpb.InputTrace{UnderdeclaredInputs: []string{"foo.h"}},
+			},
+		},
+	}
+	s := NewFromRecords(recs, nil)
+	var got []*stpb.Stat
+	for _, st := range s.ToProto().GetStats() {
+		if strings.Contains(st.Name, "InputTrace") {
+			got = append(got, st)
+		}
+	}
+	stats := func(prefix string) []*stpb.Stat {
+		return []*stpb.Stat{
+			{
+				Name:         prefix + "LocalMetadata.InputTrace.NumOverdeclaredInputs",
+				Count:        1,
+				Median:       1,
+				Percentile75: 1,
+				Percentile85: 1,
+				Percentile95: 1,
+				Average:      0.5,
+				Outliers:     []*stpb.Outlier{{CommandId: "a", Value: 1}},
+			},
+			{
+				Name:         prefix + "LocalMetadata.InputTrace.NumUnderdeclaredInputs",
+				Count:        3,
+				Median:       2,
+				Percentile75: 2,
+				Percentile85: 2,
+				Percentile95: 2,
+				Average:      1.5,
+				Outliers:     []*stpb.Outlier{{CommandId: "a", Value: 2}, {CommandId: "b", Value: 1}},
+			},
+		}
+	}
+	// The stats are also aggregated by labels.
+	want := append(stats(""), stats("[type=tool].")...)
+	if diff := cmp.Diff(want, got, append(cmpStatsOpts, cmpStatsIgnoreBuildLatency)...); diff != "" {
+		t.Errorf("InputTrace stats returned diff in result: (-want +got)\n%s", diff)
+	}
+}
+
 func TestTimeStats(t *testing.T) {
 	// Maps in Go have random iteration order, which is a good test for our stats.
 	m := make(map[string]int)
@@ -23,6 +23,8 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/bazelbuild/reclient/internal/pkg/cgroups"
+
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/outerr"
 
@@ -46,13 +48,16 @@ func (SystemExecutor) Execute(ctx context.Context, cmd *command.Command) (string
 	return stdout.String(), stderr.String(), err
 }
 
-// ExecuteWithOutErr runs the given command and returns stdout and stderr in an OutErr object.
+// ExecuteWithOutErr runs the given command and writes stdout and stderr to an OutErr object as
+// they are produced.
 // Returns *exec.ExitError if the command ran with a non-zero exit code.
 func (SystemExecutor) ExecuteWithOutErr(ctx context.Context, cmd *command.Command, oe outerr.OutErr) error {
-	cmdCtx, stdout, stderr, err := setupCommand(ctx, cmd)
+	cmdCtx, _, _, err := setupCommand(ctx, cmd)
 	if err != nil {
 		return err
 	}
+	cmdCtx.Stdout = outerr.NewOutWriter(oe)
+	cmdCtx.Stderr = outerr.NewErrWriter(oe)
 	if err = cmdCtx.Start(); err != nil {
 		log.V(2).Infof("Starting command %v >> err=%v", cmd.Args, err)
 		return err
@@ -62,16 +67,11 @@ func (SystemExecutor) ExecuteWithOutErr(ctx context.Context, cmd *command.Comman
 	// Wait for the command to complete, whether to completion, or if cancelled.
 	go func() {
 		err = cmdCtx.Wait()
-		if err != nil {
-			log.V(2).Infof("Executed command %v\n >> err=%v", cmd.Args, err)
-		}
-		oe.WriteOut([]byte(stdout.String()))
-		oe.WriteErr([]byte(stderr.String()))
 		wg.Done()
 	}()
 	wg.Wait()
 	if err != nil {
-		log.V(2).Infof("Executed command %v\n >> stdout=%v\n >> stderr=%v\n >> err=%v", cmd.Args, stdout, stderr, err)
+		log.V(2).Infof("Executed command %v\n >> err=%v", cmd.Args, err)
 	}
 	return err
 }
@@ -113,6 +113,8 @@ func setupCommand(ctx context.Context, cmd *command.Command) (*exec.Cmd, *string
 	}
 	cmdCtx := exec.CommandContext(ctx, cmd.Args[0], cmd.Args[1:]...)
 	cmdCtx.Dir = filepath.Join(cmd.ExecRoot, cmd.WorkingDir)
+	setProcessGroup(cmdCtx)
+	cgroups.SetCmdCgroup(ctx, cmdCtx)
 	if cmd.InputSpec != nil && cmd.InputSpec.EnvironmentVariables != nil {
 		cmdCtx.Env = envVarList(cmd.InputSpec.EnvironmentVariables)
 	}
@@ -16,8 +16,10 @@ package subprocess
 
 import (
 	"context"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/command"
 	"github.com/bazelbuild/remote-apis-sdks/go/pkg/outerr"
@@ -92,3 +94,21 @@ func TestExecuteInBackgroundCancellation(t *testing.T) {
 		t.Errorf("ExecuteInBackground(%v) stdout = %v, want %q", cmd, got, want)
 	}
 }
+
+func TestExecuteWithOutErrCancellationKillsChildren(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("process groups are not killed on Windows")
+	}
+	oe := outerr.NewRecordingOutErr()
+	// The background sleep keeps stdout open, so the command only completes once it is killed too.
+	cmd := &command.Command{Args: []string{"bash", "-c", "sleep 60 & wait"}}
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := (SystemExecutor{}).ExecuteWithOutErr(ctx, cmd, oe); err == nil {
+		t.Errorf("ExecuteWithOutErr(%v) succeeded, want error after cancellation", cmd)
+	}
+	if d := time.Since(start); d > 10*time.Second {
+		t.Errorf("ExecuteWithOutErr(%v) returned after %v, want the spawned processes to be killed on cancellation", cmd, d)
+	}
+}